@@ -4,6 +4,7 @@
 package v1beta2
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/projectcapsule/capsule/pkg/api"
@@ -31,6 +32,64 @@ type CapsuleConfigurationSpec struct {
 	// when not using an already provided CA and certificate, or when these are managed externally with Vault, or cert-manager.
 	// +kubebuilder:default=true
 	EnableTLSReconciler bool `json:"enableTLSReconciler"` //nolint:tagliatelle
+	// Trims the rules of the Capsule webhooks intercepting resources which are not subject to any Tenant policy,
+	// such as Services when no Tenant defines Service options, reducing the admission overhead.
+	// The original rules are restored as soon as a Tenant requires them.
+	// +kubebuilder:default=false
+	MinimizeWebhookRules bool `json:"minimizeWebhookRules,omitempty"`
+	// Enables the per-Tenant discovery documents served by the webhook server at /discovery/tenants/,
+	// allowing CLI tooling to bootstrap the Tenant access programmatically.
+	// Optional.
+	Discovery *DiscoverySpec `json:"discovery,omitempty"`
+	// Requires the confirmation of the Tenant deletions exceeding any of the given thresholds:
+	// such Tenants can be deleted only once annotated with capsule.clastix.io/confirm-deletion set to the Tenant name.
+	// Optional.
+	DeletionImpactThresholds *DeletionImpactThresholds `json:"deletionImpactThresholds,omitempty"`
+	// Defines the Ingress controllers serving the IngressClasses of the Tenants,
+	// used to generate the NetworkPolicies of the Tenants enabling the generateNetworkPolicies Ingress option.
+	// Optional.
+	IngressControllers []IngressControllerSpec `json:"ingressControllers,omitempty"`
+}
+
+type IngressControllerSpec struct {
+	// The names of the IngressClasses served by the Ingress controller.
+	// +kubebuilder:validation:MinItems=1
+	IngressClasses []string `json:"ingressClasses"`
+	// Selects the Namespaces where the Ingress controller Pods are running.
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+	// Selects the Ingress controller Pods in the selected Namespaces.
+	// Optional: when unset, all the Pods of the selected Namespaces are allowed.
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+}
+
+type DeletionImpactThresholds struct {
+	// Maximum number of Namespaces deleted along with the Tenant without confirmation.
+	// +kubebuilder:validation:Minimum=0
+	Namespaces *int32 `json:"namespaces,omitempty"`
+	// Maximum number of workloads, such as Deployments, StatefulSets, DaemonSets, Jobs, CronJobs, and standalone Pods,
+	// deleted along with the Tenant without confirmation.
+	// +kubebuilder:validation:Minimum=0
+	Workloads *int32 `json:"workloads,omitempty"`
+	// Maximum storage requested by the PersistentVolumeClaims deleted along with the Tenant without confirmation.
+	Storage *resource.Quantity `json:"storage,omitempty"`
+	// Maximum number of LoadBalancer Services deleted along with the Tenant without confirmation.
+	// +kubebuilder:validation:Minimum=0
+	LoadBalancers *int32 `json:"loadBalancers,omitempty"`
+}
+
+type DiscoverySpec struct {
+	// The URL of the API server, or of the Capsule Proxy, the Tenant owners must connect to.
+	ServerURL string `json:"serverURL"`
+	// The PEM encoded Certificate Authority of the server URL.
+	// When empty, the cluster Certificate Authority published in the kube-root-ca.crt ConfigMap is used.
+	// Optional.
+	CertificateAuthority string `json:"certificateAuthority,omitempty"`
+	// The OIDC issuer URL the Tenant owners must authenticate against.
+	// Optional.
+	IssuerURL string `json:"issuerURL,omitempty"`
+	// The OIDC client ID the Tenant owners must use to authenticate.
+	// Optional.
+	ClientID string `json:"clientID,omitempty"`
 }
 
 type NodeMetadata struct {
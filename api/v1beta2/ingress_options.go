@@ -30,4 +30,20 @@ type IngressOptions struct {
 	AllowedHostnames *api.AllowedListSpec `json:"allowedHostnames,omitempty"`
 	// Toggles the ability for Ingress resources created in a Tenant to have a hostname wildcard.
 	AllowWildcardHostnames bool `json:"allowWildcardHostnames,omitempty"`
+	// Specifies the maximum number of Ingress resources allowed for the Tenant across all its Namespaces,
+	// protecting the shared Ingress controllers.
+	// Optional.
+	// +kubebuilder:validation:Minimum=0
+	Quota *int32 `json:"quota,omitempty"`
+	// Specifies the maximum number of cert-manager Certificate resources allowed for the Tenant across all its Namespaces,
+	// protecting the ACME rate limits shared by the Tenants.
+	// Optional.
+	// +kubebuilder:validation:Minimum=0
+	CertificateQuota *int32 `json:"certificateQuota,omitempty"`
+	// Generates, in each Tenant Namespace, the NetworkPolicies allowing the traffic from the Ingress controllers
+	// to the Pods backing the Ingress resources with an allowed IngressClass and hostname, and nothing else,
+	// keeping the L7 exposure and the L3/L4 policies in sync.
+	// The Ingress controllers are defined by the cluster administrators in the CapsuleConfiguration.
+	// Optional.
+	GenerateNetworkPolicies bool `json:"generateNetworkPolicies,omitempty"`
 }
@@ -17,4 +17,12 @@ type NamespaceOptions struct {
 	ForbiddenLabels api.ForbiddenListSpec `json:"forbiddenLabels,omitempty"`
 	// Define the annotations that a Tenant Owner cannot set for their Namespace resources.
 	ForbiddenAnnotations api.ForbiddenListSpec `json:"forbiddenAnnotations,omitempty"`
+	// Define the labels that a Tenant Owner cannot add, change, or remove after the creation of their Namespace resources,
+	// such as the Pod Security Standards ones.
+	ImmutableLabels api.ImmutableListSpec `json:"immutableLabels,omitempty"`
+	// Define the annotations that a Tenant Owner cannot add, change, or remove after the creation of their Namespace resources.
+	ImmutableAnnotations api.ImmutableListSpec `json:"immutableAnnotations,omitempty"`
+	// Specifies the image pull Secrets and the token automount policy the Capsule operator applies to the default ServiceAccount
+	// of any Namespace in the Tenant, without requiring a mutation of each Pod. Optional.
+	DefaultServiceAccount *api.DefaultServiceAccountSpec `json:"defaultServiceAccount,omitempty"`
 }
@@ -37,6 +37,35 @@ func (in *Tenant) AssignNamespaces(namespaces []corev1.Namespace) {
 	in.Status.Size = uint(len(l))
 }
 
+// MaxTenantChanges is the number of changes retained in the Tenant status.
+const MaxTenantChanges = 20
+
+// MaxTenantViolations is the number of violations retained in the Tenant status.
+const MaxTenantViolations = 50
+
+// RecordChanges prepends the given changes, sorted by time, to the ones in the Tenant status,
+// retaining only the latest MaxTenantChanges.
+func (in *Tenant) RecordChanges(changes ...TenantChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	latest := make([]TenantChange, 0, len(changes)+len(in.Status.Changes))
+	latest = append(latest, changes...)
+
+	sort.SliceStable(latest, func(i, j int) bool {
+		return latest[j].Time.Before(&latest[i].Time)
+	})
+
+	latest = append(latest, in.Status.Changes...)
+
+	if len(latest) > MaxTenantChanges {
+		latest = latest[:MaxTenantChanges]
+	}
+
+	in.Status.Changes = latest
+}
+
 func (in *Tenant) GetOwnerProxySettings(name string, kind OwnerKind) []ProxySettings {
 	return in.Spec.Owners.FindOwner(name, kind).ProxyOperations
 }
@@ -6,9 +6,11 @@ package v1beta2
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/projectcapsule/capsule/pkg/api"
 	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var tenant = &Tenant{
@@ -163,6 +165,34 @@ func TestGetClusterRolesBySubject(t *testing.T) {
 	}
 }
 
+func TestRecordChanges(t *testing.T) {
+	now := time.Now()
+
+	tnt := &Tenant{}
+	tnt.RecordChanges(
+		TenantChange{Time: metav1.NewTime(now.Add(-time.Minute)), Name: "older"},
+		TenantChange{Time: metav1.NewTime(now), Name: "newer"},
+	)
+
+	if tnt.Status.Changes[0].Name != "newer" || tnt.Status.Changes[1].Name != "older" {
+		t.Errorf("Expected changes sorted newest first, but got %v", tnt.Status.Changes)
+	}
+
+	for i := 0; i < MaxTenantChanges; i++ {
+		tnt.RecordChanges(TenantChange{Time: metav1.NewTime(now.Add(time.Duration(i+1) * time.Second)), Name: "latest"})
+	}
+
+	if len(tnt.Status.Changes) != MaxTenantChanges {
+		t.Errorf("Expected %d changes, but got %d", MaxTenantChanges, len(tnt.Status.Changes))
+	}
+
+	for _, change := range tnt.Status.Changes {
+		if change.Name != "latest" {
+			t.Errorf("Expected only the latest changes to be retained, but got %s", change.Name)
+		}
+	}
+}
+
 // Helper function to run tests
 func TestMain(t *testing.M) {
 	t.Run()
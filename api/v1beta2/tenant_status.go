@@ -3,6 +3,10 @@
 
 package v1beta2
 
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // +kubebuilder:validation:Enum=Cordoned;Active
 type tenantState string
 
@@ -20,4 +24,101 @@ type TenantStatus struct {
 	Size uint `json:"size"`
 	// List of namespaces assigned to the Tenant.
 	Namespaces []string `json:"namespaces,omitempty"`
+	// How many Ingress resources are in the Tenant namespaces.
+	Ingresses uint `json:"ingresses,omitempty"`
+	// How many cert-manager Certificate resources are in the Tenant namespaces.
+	Certificates uint `json:"certificates,omitempty"`
+	// The outcome of the last isolation verification, populated only when the verification is enabled.
+	Isolation *IsolationStatus `json:"isolation,omitempty"`
+	// The last changes applied by Capsule to the Tenant Namespaces and their resources, newest first.
+	// Only the latest 20 changes are retained.
+	Changes []TenantChange `json:"changes,omitempty"`
+	// The existing objects violating the Tenant policies, re-evaluated in background upon the Tenant specification changes,
+	// since the admission enforcement does not apply to the objects created before. Populated only when the re-evaluation is enabled.
+	Violations *TenantViolations `json:"violations,omitempty"`
+}
+
+// TenantViolations reports the outcome of the last re-evaluation of the existing objects against the Tenant policies.
+type TenantViolations struct {
+	// When the last re-evaluation has been completed.
+	LastEvaluation metav1.Time `json:"lastEvaluation"`
+	// The generation of the Tenant the objects have been evaluated against.
+	TenantGeneration int64 `json:"tenantGeneration"`
+	// The total number of violations.
+	Count int `json:"count"`
+	// The violations, only the first 50 ones are retained.
+	Items []TenantViolation `json:"items,omitempty"`
+}
+
+// TenantViolation is an existing object not complying with a Tenant policy.
+type TenantViolation struct {
+	// The kind of the offending object.
+	Kind string `json:"kind"`
+	// The Namespace of the offending object.
+	Namespace string `json:"namespace"`
+	// The name of the offending object.
+	Name string `json:"name"`
+	// The code of the violated policy.
+	Code string `json:"code,omitempty"`
+	// The message returned by the policy.
+	Message string `json:"message"`
+}
+
+// +kubebuilder:validation:Enum=Created;Updated
+type TenantChangeOperation string
+
+const (
+	TenantChangeCreated TenantChangeOperation = "Created"
+	TenantChangeUpdated TenantChangeOperation = "Updated"
+)
+
+// TenantSpecAuthorAnnotation is set by Capsule upon the Tenant admission to the user who last changed the Tenant specification.
+const TenantSpecAuthorAnnotation = "capsule.clastix.io/spec-author"
+
+// TenantChange records a modification applied by Capsule to a resource of the Tenant.
+type TenantChange struct {
+	// When the change has been applied.
+	Time metav1.Time `json:"time"`
+	// The Namespace of the changed resource, empty for the cluster scoped ones.
+	Namespace string `json:"namespace,omitempty"`
+	// The kind of the changed resource.
+	Kind string `json:"kind"`
+	// The name of the changed resource.
+	Name string `json:"name"`
+	// The applied operation. Possible values are "Created", "Updated".
+	Operation TenantChangeOperation `json:"operation"`
+	// What caused the change, such as the Tenant specification or the Tenant scoped quota balancing.
+	Reason string `json:"reason,omitempty"`
+	// The generation of the Tenant the change has been computed from.
+	TenantGeneration int64 `json:"tenantGeneration,omitempty"`
+	// The user who changed the Tenant specification the change has been computed from,
+	// as reported by the admission request: empty when the change is not caused by the Tenant specification.
+	Author string `json:"author,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Passed;Failed;Skipped;Error
+type IsolationProbeResult string
+
+const (
+	IsolationProbePassed  IsolationProbeResult = "Passed"
+	IsolationProbeFailed  IsolationProbeResult = "Failed"
+	IsolationProbeSkipped IsolationProbeResult = "Skipped"
+	IsolationProbeError   IsolationProbeResult = "Error"
+)
+
+// IsolationStatus reports the outcome of the last isolation verification, performed with short-lived probe Pods.
+type IsolationStatus struct {
+	// When the last verification has been completed.
+	LastVerification metav1.Time `json:"lastVerification"`
+	// Whether a Pod of the Tenant can reach another Pod of the Tenant through its Service DNS name.
+	IntraTenant IsolationProbe `json:"intraTenant"`
+	// Whether a Pod outside of the Tenant, in the Namespace dedicated to the probes, is denied reaching a Pod of the Tenant.
+	CrossTenant IsolationProbe `json:"crossTenant"`
+}
+
+type IsolationProbe struct {
+	// The result of the probe. Possible values are "Passed", "Failed", "Skipped", "Error".
+	Result IsolationProbeResult `json:"result"`
+	// Human readable details about the result.
+	Message string `json:"message,omitempty"`
 }
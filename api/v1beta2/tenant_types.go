@@ -49,6 +49,24 @@ type TenantSpec struct {
 	// A default value can be specified, and all the Pod resources created will inherit the declared class.
 	// Optional.
 	PriorityClasses *api.DefaultAllowedListSpec `json:"priorityClasses,omitempty"`
+	// Specifies the restrictions applied to the Secrets Store CSI driver in the Tenant namespaces.
+	// Capsule assures that the SecretProviderClass resources, and the Pods mounting them, can use only the allowed providers,
+	// and that the given parameters only refer to the allowed prefixes, preventing access to the secrets of other Tenants.
+	// Optional.
+	SecretsStore *api.SecretsStoreSpec `json:"secretsStore,omitempty"`
+	// Specifies whether the Tenant owners can install namespaced operators, such as the OLM ones, and from which catalogs.
+	// Optional: when unset, operators installation is not restricted.
+	Operators *api.OperatorsSpec `json:"operators,omitempty"`
+	// Specifies the maximum size of the objects, such as ConfigMap, Secret, or custom resources, created in the Tenant namespaces,
+	// protecting etcd from Tenants storing large blobs. When more limits match a kind, the most specific one is applied.
+	// Optional.
+	ObjectSizeLimits api.ObjectSizeLimitsSpec `json:"objectSizeLimits,omitempty"`
+	// Specifies the workload hygiene rules, such as the required probes and labels, checked on the Pods and the workloads
+	// created in the Tenant Namespaces: violations are reported as warnings, unless enforced. Optional.
+	WorkloadHygiene *api.WorkloadHygieneSpec `json:"workloadHygiene,omitempty"`
+	// Specifies the cluster scoped resources the Tenant owners can read, such as the nodes of the Tenant node pool and their metrics:
+	// Capsule generates a ClusterRole restricted by resource name, bound to the Tenant owners. Optional.
+	ClusterAccess *api.ClusterAccessSpec `json:"clusterAccess,omitempty"`
 	// Toggling the Tenant resources cordoning, when enable resources cannot be deleted.
 	//+kubebuilder:default:=false
 	Cordoned bool `json:"cordoned,omitempty"`
@@ -0,0 +1,203 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta2
+
+import (
+	"encoding/json"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// TenantGroupFieldsAnnotation reports the Tenant specification fields set by the TenantGroups,
+// as a JSON object whose keys are the fields, and whose values are the names of the groups.
+const TenantGroupFieldsAnnotation = "capsule.clastix.io/tenant-group-fields"
+
+// TenantSpecDefaults are the values set by the API server defaulting on the Tenant specification fields left empty,
+// keyed by field name: they are derived from the Tenant CustomResourceDefinition schema.
+//
+// +kubebuilder:object:generate=false
+type TenantSpecDefaults map[string]json.RawMessage
+
+// Selects returns true when the Tenant is a member of the TenantGroup.
+func (in *TenantGroup) Selects(tnt *Tenant) (bool, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&in.Spec.TenantSelector)
+	if err != nil {
+		return false, err
+	}
+
+	return selector.Matches(labels.Set(tnt.GetLabels())), nil
+}
+
+// ApplyTenantGroups sets the policies of the TenantGroups the Tenant is member of in its specification: a policy is set
+// unless defined by the Tenant itself, or by a group preceding in alphabetical order, while the policies previously set
+// by a group and no longer defined by any of them are removed. The fields set by the groups are tracked by the
+// TenantGroupFieldsAnnotation. A Tenant field is considered as not defined when it holds its zero value,
+// or the value the API server defaults it to. It returns the overridden policies keyed by group name, and whether the Tenant changed.
+func ApplyTenantGroups(tnt *Tenant, groups []TenantGroup, defaults TenantSpecDefaults) (overridden map[string][]string, changed bool, err error) {
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].GetName() < groups[j].GetName()
+	})
+
+	owned := map[string]string{}
+
+	if value, ok := tnt.GetAnnotations()[TenantGroupFieldsAnnotation]; ok {
+		// A malformed annotation cannot be trusted: the fields set by the groups are assigned again.
+		if json.Unmarshal([]byte(value), &owned) != nil {
+			owned = map[string]string{}
+		}
+	}
+
+	spec, err := toRawFields(tnt.Spec)
+	if err != nil {
+		return nil, false, err
+	}
+
+	zero, err := toRawFields(TenantSpec{})
+	if err != nil {
+		return nil, false, err
+	}
+
+	type policy struct {
+		group string
+		value json.RawMessage
+	}
+
+	desired := map[string]policy{}
+	overridden = map[string][]string{}
+
+	for _, group := range groups {
+		if group.Spec.Policies == nil {
+			continue
+		}
+
+		policies, policiesErr := toRawFields(group.Spec.Policies)
+		if policiesErr != nil {
+			return nil, false, policiesErr
+		}
+
+		// The policies fields are omitted when not set.
+		for _, field := range sortedKeys(policies) {
+			if _, ok := desired[field]; ok {
+				overridden[group.GetName()] = append(overridden[group.GetName()], field)
+
+				continue
+			}
+
+			desired[field] = policy{group: group.GetName(), value: policies[field]}
+		}
+	}
+
+	for field := range owned {
+		if _, ok := desired[field]; !ok {
+			delete(spec, field)
+		}
+	}
+
+	nextOwned := map[string]string{}
+
+	for _, field := range sortedKeys(desired) {
+		if _, ok := owned[field]; !ok && !isUnsetField(spec[field], zero[field], defaults[field]) {
+			overridden[desired[field].group] = append(overridden[desired[field].group], field)
+
+			continue
+		}
+
+		spec[field] = desired[field].value
+		nextOwned[field] = desired[field].group
+	}
+
+	encoded, err := json.Marshal(spec)
+	if err != nil {
+		return nil, false, err
+	}
+
+	nextSpec := TenantSpec{}
+	if err = json.Unmarshal(encoded, &nextSpec); err != nil {
+		return nil, false, err
+	}
+
+	if !equality.Semantic.DeepEqual(tnt.Spec, nextSpec) {
+		tnt.Spec = nextSpec
+		changed = true
+	}
+
+	annotations := tnt.GetAnnotations()
+	previous, hadAnnotation := annotations[TenantGroupFieldsAnnotation]
+
+	switch {
+	case len(nextOwned) == 0 && hadAnnotation:
+		delete(annotations, TenantGroupFieldsAnnotation)
+		tnt.SetAnnotations(annotations)
+
+		changed = true
+	case len(nextOwned) > 0:
+		value, marshalErr := json.Marshal(nextOwned)
+		if marshalErr != nil {
+			return nil, false, marshalErr
+		}
+
+		if previous != string(value) {
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+
+			annotations[TenantGroupFieldsAnnotation] = string(value)
+			tnt.SetAnnotations(annotations)
+
+			changed = true
+		}
+	}
+
+	return overridden, changed, nil
+}
+
+func toRawFields(obj interface{}) (map[string]json.RawMessage, error) {
+	encoded, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]json.RawMessage{}
+
+	return fields, json.Unmarshal(encoded, &fields)
+}
+
+// isUnsetField returns true when the Tenant specification field is missing, or holds its zero, or defaulted, value.
+func isUnsetField(value, zero, defaulted json.RawMessage) bool {
+	if len(value) == 0 || string(value) == "null" {
+		return true
+	}
+
+	return equalRaw(value, zero) || equalRaw(value, defaulted)
+}
+
+// equalRaw returns true when the JSON values are semantically equal.
+func equalRaw(a, b json.RawMessage) bool {
+	if len(b) == 0 {
+		return false
+	}
+
+	var x, y interface{}
+
+	if json.Unmarshal(a, &x) != nil || json.Unmarshal(b, &y) != nil {
+		return false
+	}
+
+	return equality.Semantic.DeepEqual(x, y)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
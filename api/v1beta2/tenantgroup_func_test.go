@@ -0,0 +1,172 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta2
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+func TestTenantGroup_Selects(t *testing.T) {
+	group := TenantGroup{Spec: TenantGroupSpec{TenantSelector: metav1.LabelSelector{MatchLabels: map[string]string{"profile": "gold"}}}}
+
+	selected, err := group.Selects(&Tenant{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"profile": "gold"}}})
+	require.NoError(t, err)
+	assert.True(t, selected)
+
+	selected, err = group.Selects(&Tenant{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"profile": "silver"}}})
+	require.NoError(t, err)
+	assert.False(t, selected)
+}
+
+// defaults are the ones of the Tenant CustomResourceDefinition schema.
+//
+//nolint:gochecknoglobals
+var defaults = TenantSpecDefaults{
+	"ingressOptions": json.RawMessage(`{"hostnameCollisionScope":"Disabled"}`),
+	"resourceQuotas": json.RawMessage(`{"scope":"Tenant"}`),
+}
+
+func TestApplyTenantGroups(t *testing.T) {
+	gold := TenantGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "gold"},
+		Spec: TenantGroupSpec{Policies: &TenantGroupPolicies{
+			ContainerRegistries: &api.AllowedListSpec{Exact: []string{"quay.io"}},
+			NodeSelector:        map[string]string{"pool": "gold"},
+			ResourceQuota:       &api.ResourceQuotaSpec{Scope: api.ResourceQuotaScopeTenant},
+		}},
+	}
+	platform := TenantGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform"},
+		Spec: TenantGroupSpec{Policies: &TenantGroupPolicies{
+			ContainerRegistries: &api.AllowedListSpec{Exact: []string{"docker.io"}},
+			PodOptions:          &api.PodOptions{MaxPodsPerNode: ptr.To[int32](10)},
+		}},
+	}
+
+	tnt := &Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "oil"},
+		Spec: TenantSpec{
+			Owners:        OwnerListSpec{{Kind: UserOwner, Name: "alice"}},
+			NodeSelector:  map[string]string{"pool": "oil"},
+			ResourceQuota: api.ResourceQuotaSpec{Scope: api.ResourceQuotaScopeTenant},
+		},
+	}
+
+	overridden, changed, err := ApplyTenantGroups(tnt, []TenantGroup{platform, gold}, defaults)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	// The Tenant node selector is retained, and the gold group precedes the platform one.
+	assert.Equal(t, map[string]string{"pool": "oil"}, tnt.Spec.NodeSelector)
+	assert.Equal(t, []string{"quay.io"}, tnt.Spec.ContainerRegistries.Exact)
+	assert.Equal(t, int32(10), *tnt.Spec.PodOptions.MaxPodsPerNode)
+	assert.Equal(t, []string{"nodeSelector"}, overridden["gold"])
+	assert.Equal(t, []string{"containerRegistries"}, overridden["platform"])
+	assert.Equal(t, "alice", tnt.Spec.Owners[0].Name)
+	assert.JSONEq(t, `{"containerRegistries":"gold","podOptions":"platform","resourceQuotas":"gold"}`, tnt.GetAnnotations()[TenantGroupFieldsAnnotation])
+
+	_, changed, err = ApplyTenantGroups(tnt, []TenantGroup{platform, gold}, defaults)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	// Leaving the platform group, the registries are still set by the gold one, while the Pod options are removed.
+	_, changed, err = ApplyTenantGroups(tnt, []TenantGroup{gold}, defaults)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Nil(t, tnt.Spec.PodOptions)
+	assert.Equal(t, []string{"quay.io"}, tnt.Spec.ContainerRegistries.Exact)
+
+	_, changed, err = ApplyTenantGroups(tnt, nil, defaults)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Nil(t, tnt.Spec.ContainerRegistries)
+	assert.Equal(t, map[string]string{"pool": "oil"}, tnt.Spec.NodeSelector)
+	assert.NotContains(t, tnt.GetAnnotations(), TenantGroupFieldsAnnotation)
+}
+
+func TestApplyTenantGroupsDefaults(t *testing.T) {
+	gold := TenantGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "gold"},
+		Spec: TenantGroupSpec{Policies: &TenantGroupPolicies{
+			IngressOptions:  &IngressOptions{HostnameCollisionScope: api.HostnameCollisionScopeTenant},
+			PreventDeletion: ptr.To(true),
+		}},
+	}
+	// The Tenant as returned by the API server, with the defaulted ingress options.
+	tnt := &Tenant{Spec: TenantSpec{IngressOptions: IngressOptions{HostnameCollisionScope: api.HostnameCollisionScopeDisabled}}}
+
+	// Without the defaults, the defaulted ingress options are considered as defined by the Tenant.
+	overridden, _, err := ApplyTenantGroups(tnt.DeepCopy(), []TenantGroup{gold}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ingressOptions"}, overridden["gold"])
+
+	overridden, changed, err := ApplyTenantGroups(tnt, []TenantGroup{gold}, defaults)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Empty(t, overridden)
+	assert.Equal(t, api.HostnameCollisionScopeTenant, tnt.Spec.IngressOptions.HostnameCollisionScope)
+	assert.True(t, tnt.Spec.PreventDeletion)
+}
+
+// TestTenantGroupPoliciesCoverTenantSpec fails when a Tenant specification field is not available as a TenantGroup policy,
+// or when a policy cannot be told apart from a missing one.
+func TestTenantGroupPoliciesCoverTenantSpec(t *testing.T) {
+	// Fields specific to each Tenant.
+	excluded := map[string]bool{"owners": true, "cordoned": true}
+
+	jsonName := func(field reflect.StructField) string {
+		return strings.Split(field.Tag.Get("json"), ",")[0]
+	}
+
+	policies := map[string]reflect.StructField{}
+
+	policiesType := reflect.TypeOf(TenantGroupPolicies{})
+	for i := range policiesType.NumField() {
+		field := policiesType.Field(i)
+		policies[jsonName(field)] = field
+
+		switch field.Type.Kind() { //nolint:exhaustive
+		case reflect.Ptr, reflect.Slice, reflect.Map:
+		default:
+			t.Errorf("policy %s is not a pointer, a slice, or a map", jsonName(field))
+		}
+
+		assert.True(t, strings.HasSuffix(field.Tag.Get("json"), ",omitempty"), "policy %s is not omitted when empty", jsonName(field))
+	}
+
+	specType := reflect.TypeOf(TenantSpec{})
+	for i := range specType.NumField() {
+		field := specType.Field(i)
+		name := jsonName(field)
+
+		if excluded[name] {
+			assert.NotContains(t, policies, name)
+
+			continue
+		}
+
+		policy, ok := policies[name]
+		if !assert.True(t, ok, "Tenant field %s is not a TenantGroup policy", name) {
+			continue
+		}
+
+		expected := field.Type
+		if expected.Kind() != reflect.Ptr && expected.Kind() != reflect.Slice && expected.Kind() != reflect.Map {
+			expected = reflect.PointerTo(expected)
+		}
+
+		assert.Equal(t, expected, policy.Type, "policy %s type differs from the Tenant one", name)
+	}
+
+	assert.Len(t, policies, specType.NumField()-len(excluded))
+}
@@ -0,0 +1,104 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+// TenantGroupPolicies defines the policies applied to the member Tenants of a TenantGroup:
+// the fields have the same semantic of the Tenant specification ones, which are all covered but the owners,
+// and the cordoning, being specific to each Tenant. A policy is applied when its field is set.
+type TenantGroupPolicies struct {
+	NamespaceOptions       *NamespaceOptions                `json:"namespaceOptions,omitempty"`
+	ServiceOptions         *api.ServiceOptions              `json:"serviceOptions,omitempty"`
+	PodOptions             *api.PodOptions                  `json:"podOptions,omitempty"`
+	StorageClasses         *api.DefaultAllowedListSpec      `json:"storageClasses,omitempty"`
+	IngressOptions         *IngressOptions                  `json:"ingressOptions,omitempty"`
+	ContainerRegistries    *api.AllowedListSpec             `json:"containerRegistries,omitempty"`
+	NodeSelector           map[string]string                `json:"nodeSelector,omitempty"`
+	NetworkPolicies        *api.NetworkPolicySpec           `json:"networkPolicies,omitempty"`
+	LimitRanges            *api.LimitRangesSpec             `json:"limitRanges,omitempty"`
+	ResourceQuota          *api.ResourceQuotaSpec           `json:"resourceQuotas,omitempty"`
+	AdditionalRoleBindings []api.AdditionalRoleBindingsSpec `json:"additionalRoleBindings,omitempty"`
+	ImagePullPolicies      []api.ImagePullPolicySpec        `json:"imagePullPolicies,omitempty"`
+	RuntimeClasses         *api.DefaultAllowedListSpec      `json:"runtimeClasses,omitempty"`
+	PriorityClasses        *api.DefaultAllowedListSpec      `json:"priorityClasses,omitempty"`
+	ObjectSizeLimits       api.ObjectSizeLimitsSpec         `json:"objectSizeLimits,omitempty"`
+	WorkloadHygiene        *api.WorkloadHygieneSpec         `json:"workloadHygiene,omitempty"`
+	SecretsStore           *api.SecretsStoreSpec            `json:"secretsStore,omitempty"`
+	Operators              *api.OperatorsSpec               `json:"operators,omitempty"`
+	ClusterAccess          *api.ClusterAccessSpec           `json:"clusterAccess,omitempty"`
+	PreventDeletion        *bool                            `json:"preventDeletion,omitempty"`
+	ForceTenantPrefix      *bool                            `json:"forceTenantPrefix,omitempty"`
+}
+
+// TenantGroupNotificationTarget receives the Warning Events of the member Tenants of a TenantGroup,
+// such as the policy violations and the failed isolation verifications.
+type TenantGroupNotificationTarget struct {
+	// Name of the target, reported upon the delivery failures.
+	Name string `json:"name"`
+	// URL receiving each Event as a JSON object with a POST request.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+	// Reasons of the Events delivered to the target, all of them when empty. Optional.
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// TenantGroupSpec defines the desired state of TenantGroup.
+type TenantGroupSpec struct {
+	// Selects the member Tenants of the group.
+	TenantSelector metav1.LabelSelector `json:"tenantSelector"`
+	// Specifies the policies applied to the member Tenants: each policy is set in the Tenant specification,
+	// unless already defined by the Tenant itself, or by a TenantGroup preceding in alphabetical order.
+	// Since Capsule updates the Tenant specification, the tools applying the Tenants, such as the GitOps ones,
+	// must ignore the fields set by the groups, otherwise they keep reverting each other. Optional.
+	Policies *TenantGroupPolicies `json:"policies,omitempty"`
+	// Specifies the resources replicated in the Namespaces of the member Tenants,
+	// with the same semantic of the GlobalTenantResource ones. Optional.
+	Templates *TenantResourceSpec `json:"templates,omitempty"`
+	// Specifies the targets notified of the Warning Events of the member Tenants. Optional.
+	Notifications []TenantGroupNotificationTarget `json:"notifications,omitempty"`
+}
+
+// TenantGroupStatus defines the observed state of TenantGroup.
+type TenantGroupStatus struct {
+	// Names of the member Tenants.
+	Tenants []string `json:"tenants,omitempty"`
+	// Count of the member Tenants.
+	Size uint `json:"size"`
+	// Policies of the group not applied to a member Tenant, since defined by the Tenant itself,
+	// or by a TenantGroup preceding in alphabetical order, in the <tenant>:<policy> format.
+	Overridden []string `json:"overridden,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=tntgrp
+// +kubebuilder:printcolumn:name="Tenants",type="integer",JSONPath=".status.size",description="The count of the member Tenants"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
+
+// TenantGroup applies the same policies and replicated resources to all the Tenants selected by its label selector.
+type TenantGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TenantGroupSpec   `json:"spec,omitempty"`
+	Status TenantGroupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TenantGroupList contains a list of TenantGroup.
+type TenantGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TenantGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TenantGroup{}, &TenantGroupList{})
+}
@@ -127,6 +127,23 @@ func (in *CapsuleConfigurationSpec) DeepCopyInto(out *CapsuleConfigurationSpec)
 		*out = new(NodeMetadata)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Discovery != nil {
+		in, out := &in.Discovery, &out.Discovery
+		*out = new(DiscoverySpec)
+		**out = **in
+	}
+	if in.DeletionImpactThresholds != nil {
+		in, out := &in.DeletionImpactThresholds, &out.DeletionImpactThresholds
+		*out = new(DeletionImpactThresholds)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IngressControllers != nil {
+		in, out := &in.IngressControllers, &out.IngressControllers
+		*out = make([]IngressControllerSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapsuleConfigurationSpec.
@@ -154,6 +171,56 @@ func (in *CapsuleResources) DeepCopy() *CapsuleResources {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeletionImpactThresholds) DeepCopyInto(out *DeletionImpactThresholds) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.LoadBalancers != nil {
+		in, out := &in.LoadBalancers, &out.LoadBalancers
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeletionImpactThresholds.
+func (in *DeletionImpactThresholds) DeepCopy() *DeletionImpactThresholds {
+	if in == nil {
+		return nil
+	}
+	out := new(DeletionImpactThresholds)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiscoverySpec) DeepCopyInto(out *DiscoverySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscoverySpec.
+func (in *DiscoverySpec) DeepCopy() *DiscoverySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DiscoverySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GlobalTenantResource) DeepCopyInto(out *GlobalTenantResource) {
 	*out = *in
@@ -255,6 +322,32 @@ func (in *GlobalTenantResourceStatus) DeepCopy() *GlobalTenantResourceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressControllerSpec) DeepCopyInto(out *IngressControllerSpec) {
+	*out = *in
+	if in.IngressClasses != nil {
+		in, out := &in.IngressClasses, &out.IngressClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressControllerSpec.
+func (in *IngressControllerSpec) DeepCopy() *IngressControllerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressControllerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IngressOptions) DeepCopyInto(out *IngressOptions) {
 	*out = *in
@@ -268,6 +361,16 @@ func (in *IngressOptions) DeepCopyInto(out *IngressOptions) {
 		*out = new(api.AllowedListSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Quota != nil {
+		in, out := &in.Quota, &out.Quota
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CertificateQuota != nil {
+		in, out := &in.CertificateQuota, &out.CertificateQuota
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressOptions.
@@ -280,6 +383,39 @@ func (in *IngressOptions) DeepCopy() *IngressOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IsolationProbe) DeepCopyInto(out *IsolationProbe) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IsolationProbe.
+func (in *IsolationProbe) DeepCopy() *IsolationProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(IsolationProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IsolationStatus) DeepCopyInto(out *IsolationStatus) {
+	*out = *in
+	in.LastVerification.DeepCopyInto(&out.LastVerification)
+	out.IntraTenant = in.IntraTenant
+	out.CrossTenant = in.CrossTenant
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IsolationStatus.
+func (in *IsolationStatus) DeepCopy() *IsolationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IsolationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespaceOptions) DeepCopyInto(out *NamespaceOptions) {
 	*out = *in
@@ -295,6 +431,13 @@ func (in *NamespaceOptions) DeepCopyInto(out *NamespaceOptions) {
 	}
 	in.ForbiddenLabels.DeepCopyInto(&out.ForbiddenLabels)
 	in.ForbiddenAnnotations.DeepCopyInto(&out.ForbiddenAnnotations)
+	in.ImmutableLabels.DeepCopyInto(&out.ImmutableLabels)
+	in.ImmutableAnnotations.DeepCopyInto(&out.ImmutableAnnotations)
+	if in.DefaultServiceAccount != nil {
+		in, out := &in.DefaultServiceAccount, &out.DefaultServiceAccount
+		*out = new(api.DefaultServiceAccountSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceOptions.
@@ -556,6 +699,285 @@ func (in *Tenant) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantChange) DeepCopyInto(out *TenantChange) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantChange.
+func (in *TenantChange) DeepCopy() *TenantChange {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantGroup) DeepCopyInto(out *TenantGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantGroup.
+func (in *TenantGroup) DeepCopy() *TenantGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TenantGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantGroupList) DeepCopyInto(out *TenantGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TenantGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantGroupList.
+func (in *TenantGroupList) DeepCopy() *TenantGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TenantGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantGroupNotificationTarget) DeepCopyInto(out *TenantGroupNotificationTarget) {
+	*out = *in
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantGroupNotificationTarget.
+func (in *TenantGroupNotificationTarget) DeepCopy() *TenantGroupNotificationTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantGroupNotificationTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantGroupPolicies) DeepCopyInto(out *TenantGroupPolicies) {
+	*out = *in
+	if in.NamespaceOptions != nil {
+		in, out := &in.NamespaceOptions, &out.NamespaceOptions
+		*out = new(NamespaceOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceOptions != nil {
+		in, out := &in.ServiceOptions, &out.ServiceOptions
+		*out = new(api.ServiceOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodOptions != nil {
+		in, out := &in.PodOptions, &out.PodOptions
+		*out = new(api.PodOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StorageClasses != nil {
+		in, out := &in.StorageClasses, &out.StorageClasses
+		*out = new(api.DefaultAllowedListSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IngressOptions != nil {
+		in, out := &in.IngressOptions, &out.IngressOptions
+		*out = new(IngressOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContainerRegistries != nil {
+		in, out := &in.ContainerRegistries, &out.ContainerRegistries
+		*out = new(api.AllowedListSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NetworkPolicies != nil {
+		in, out := &in.NetworkPolicies, &out.NetworkPolicies
+		*out = new(api.NetworkPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LimitRanges != nil {
+		in, out := &in.LimitRanges, &out.LimitRanges
+		*out = new(api.LimitRangesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceQuota != nil {
+		in, out := &in.ResourceQuota, &out.ResourceQuota
+		*out = new(api.ResourceQuotaSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalRoleBindings != nil {
+		in, out := &in.AdditionalRoleBindings, &out.AdditionalRoleBindings
+		*out = make([]api.AdditionalRoleBindingsSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ImagePullPolicies != nil {
+		in, out := &in.ImagePullPolicies, &out.ImagePullPolicies
+		*out = make([]api.ImagePullPolicySpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.RuntimeClasses != nil {
+		in, out := &in.RuntimeClasses, &out.RuntimeClasses
+		*out = new(api.DefaultAllowedListSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PriorityClasses != nil {
+		in, out := &in.PriorityClasses, &out.PriorityClasses
+		*out = new(api.DefaultAllowedListSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObjectSizeLimits != nil {
+		in, out := &in.ObjectSizeLimits, &out.ObjectSizeLimits
+		*out = make(api.ObjectSizeLimitsSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WorkloadHygiene != nil {
+		in, out := &in.WorkloadHygiene, &out.WorkloadHygiene
+		*out = new(api.WorkloadHygieneSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretsStore != nil {
+		in, out := &in.SecretsStore, &out.SecretsStore
+		*out = new(api.SecretsStoreSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Operators != nil {
+		in, out := &in.Operators, &out.Operators
+		*out = new(api.OperatorsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterAccess != nil {
+		in, out := &in.ClusterAccess, &out.ClusterAccess
+		*out = new(api.ClusterAccessSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PreventDeletion != nil {
+		in, out := &in.PreventDeletion, &out.PreventDeletion
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ForceTenantPrefix != nil {
+		in, out := &in.ForceTenantPrefix, &out.ForceTenantPrefix
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantGroupPolicies.
+func (in *TenantGroupPolicies) DeepCopy() *TenantGroupPolicies {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantGroupPolicies)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantGroupSpec) DeepCopyInto(out *TenantGroupSpec) {
+	*out = *in
+	in.TenantSelector.DeepCopyInto(&out.TenantSelector)
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = new(TenantGroupPolicies)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = new(TenantResourceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]TenantGroupNotificationTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantGroupSpec.
+func (in *TenantGroupSpec) DeepCopy() *TenantGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantGroupStatus) DeepCopyInto(out *TenantGroupStatus) {
+	*out = *in
+	if in.Tenants != nil {
+		in, out := &in.Tenants, &out.Tenants
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Overridden != nil {
+		in, out := &in.Overridden, &out.Overridden
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantGroupStatus.
+func (in *TenantGroupStatus) DeepCopy() *TenantGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TenantList) DeepCopyInto(out *TenantList) {
 	*out = *in
@@ -763,6 +1185,33 @@ func (in *TenantSpec) DeepCopyInto(out *TenantSpec) {
 		*out = new(api.DefaultAllowedListSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SecretsStore != nil {
+		in, out := &in.SecretsStore, &out.SecretsStore
+		*out = new(api.SecretsStoreSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Operators != nil {
+		in, out := &in.Operators, &out.Operators
+		*out = new(api.OperatorsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObjectSizeLimits != nil {
+		in, out := &in.ObjectSizeLimits, &out.ObjectSizeLimits
+		*out = make(api.ObjectSizeLimitsSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WorkloadHygiene != nil {
+		in, out := &in.WorkloadHygiene, &out.WorkloadHygiene
+		*out = new(api.WorkloadHygieneSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterAccess != nil {
+		in, out := &in.ClusterAccess, &out.ClusterAccess
+		*out = new(api.ClusterAccessSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ForceTenantPrefix != nil {
 		in, out := &in.ForceTenantPrefix, &out.ForceTenantPrefix
 		*out = new(bool)
@@ -788,6 +1237,23 @@ func (in *TenantStatus) DeepCopyInto(out *TenantStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Isolation != nil {
+		in, out := &in.Isolation, &out.Isolation
+		*out = new(IsolationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]TenantChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Violations != nil {
+		in, out := &in.Violations, &out.Violations
+		*out = new(TenantViolations)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantStatus.
@@ -799,3 +1265,39 @@ func (in *TenantStatus) DeepCopy() *TenantStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantViolation) DeepCopyInto(out *TenantViolation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantViolation.
+func (in *TenantViolation) DeepCopy() *TenantViolation {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantViolation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantViolations) DeepCopyInto(out *TenantViolations) {
+	*out = *in
+	in.LastEvaluation.DeepCopyInto(&out.LastEvaluation)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TenantViolation, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantViolations.
+func (in *TenantViolations) DeepCopy() *TenantViolations {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantViolations)
+	in.DeepCopyInto(out)
+	return out
+}
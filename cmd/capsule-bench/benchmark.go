@@ -0,0 +1,269 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/bench"
+)
+
+// benchLabel marks the synthetic Tenants with the identifier of the run.
+const benchLabel = "capsule.clastix.io/bench"
+
+type benchmark struct {
+	opts      options
+	config    *rest.Config
+	client    client.Client
+	clientset kubernetes.Interface
+	runID     string
+}
+
+func (b *benchmark) tenantName(i int) string {
+	return fmt.Sprintf("bench-%s-%d", b.runID, i)
+}
+
+func (b *benchmark) ownerName(i int) string {
+	return fmt.Sprintf("bench-%s-owner-%d", b.runID, i)
+}
+
+func (b *benchmark) namespaceName(tenant, j int) string {
+	return fmt.Sprintf("%s-ns-%d", b.tenantName(tenant), j)
+}
+
+// ownerClientset returns the clientset impersonating the owner of the Tenant.
+func (b *benchmark) ownerClientset(i int) (kubernetes.Interface, error) {
+	config := rest.CopyConfig(b.config)
+	config.Impersonate = rest.ImpersonationConfig{
+		UserName: b.ownerName(i),
+		Groups:   []string{b.opts.capsuleGroup},
+	}
+
+	return kubernetes.NewForConfig(config)
+}
+
+func (b *benchmark) run(ctx context.Context) (*report, error) {
+	r := &report{
+		Tenants:             b.opts.tenants,
+		NamespacesPerTenant: b.opts.namespacesPerTenant,
+		AdmissionRequests:   b.opts.admissionRequests,
+		Concurrency:         b.opts.concurrency,
+	}
+
+	before, scrapeErr := b.scrape(ctx)
+	if scrapeErr != nil {
+		fmt.Fprintf(os.Stderr, "warning: cannot scrape the Capsule metrics, the server side measures are skipped: %s\n", scrapeErr)
+	}
+
+	owners := make([]kubernetes.Interface, b.opts.tenants)
+
+	tenantLatencies := &bench.Latencies{}
+
+	if err := parallel(ctx, b.opts.tenants, b.opts.concurrency, func(i int) error {
+		clientset, err := b.ownerClientset(i)
+		if err != nil {
+			return err
+		}
+
+		owners[i] = clientset
+
+		tnt := &capsulev1beta2.Tenant{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   b.tenantName(i),
+				Labels: map[string]string{benchLabel: b.runID},
+			},
+			Spec: capsulev1beta2.TenantSpec{
+				Owners: capsulev1beta2.OwnerListSpec{{Kind: capsulev1beta2.UserOwner, Name: b.ownerName(i)}},
+			},
+		}
+
+		start := time.Now()
+		err = b.client.Create(ctx, tnt)
+		tenantLatencies.Observe(time.Since(start), err)
+
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("cannot create the Tenants: %w", err)
+	}
+
+	r.TenantCreation = tenantLatencies.Summary()
+
+	namespaceLatencies := &bench.Latencies{}
+	reconcileStart := time.Now()
+
+	if err := parallel(ctx, b.opts.tenants*b.opts.namespacesPerTenant, b.opts.concurrency, func(n int) error {
+		i, j := n/b.opts.namespacesPerTenant, n%b.opts.namespacesPerTenant
+
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: b.namespaceName(i, j)}}
+
+		start := time.Now()
+		// Retrying, since the Tenant could be not yet available in the Capsule cache.
+		err := wait.PollUntilContextTimeout(ctx, 500*time.Millisecond, time.Minute, true, func(ctx context.Context) (bool, error) {
+			_, createErr := owners[i].CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
+
+			return createErr == nil || apierrors.IsAlreadyExists(createErr), nil
+		})
+		namespaceLatencies.Observe(time.Since(start), err)
+
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("cannot create the Namespaces: %w", err)
+	}
+
+	r.NamespaceCreation = namespaceLatencies.Summary()
+
+	if err := b.waitReconciled(ctx); err != nil {
+		return nil, fmt.Errorf("the Tenants have not been reconciled: %w", err)
+	}
+
+	r.Reconcile.Duration = time.Since(reconcileStart)
+	r.Reconcile.NamespacesPerSecond = float64(b.opts.tenants*b.opts.namespacesPerTenant) / r.Reconcile.Duration.Seconds()
+
+	admissionLatencies := &bench.Latencies{}
+
+	if err := parallel(ctx, b.opts.admissionRequests, b.opts.concurrency, func(n int) error {
+		i, j := n%b.opts.tenants, n%b.opts.namespacesPerTenant
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("bench-%d", n)},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "bench", Image: "busybox:1.36"}},
+			},
+		}
+
+		start := time.Now()
+		// The denials are accounted as errors, rather than interrupting the load.
+		_, err := owners[i].CoreV1().Pods(b.namespaceName(i, j)).Create(ctx, pod, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+		admissionLatencies.Observe(time.Since(start), err)
+
+		return ctx.Err()
+	}); err != nil {
+		return nil, fmt.Errorf("cannot generate the admission load: %w", err)
+	}
+
+	r.Admission = admissionLatencies.Summary()
+
+	if scrapeErr != nil {
+		return r, nil
+	}
+
+	after, err := b.scrape(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: cannot scrape the Capsule metrics, the server side measures are skipped: %s\n", err)
+
+		return r, nil
+	}
+
+	r.Server = &serverReport{
+		TenantReconciles:    after.Sum("controller_runtime_reconcile_total", map[string]string{"controller": "tenant"}) - before.Sum("controller_runtime_reconcile_total", map[string]string{"controller": "tenant"}),
+		ResidentMemoryBytes: after.Sum("process_resident_memory_bytes", nil),
+		HeapBytes:           after.Sum("go_memstats_heap_alloc_bytes", nil),
+	}
+
+	if p99 := bench.HistogramQuantile(0.99, before, after, "controller_runtime_webhook_latency_seconds", nil); !math.IsNaN(p99) {
+		r.Server.WebhookP99 = time.Duration(p99 * float64(time.Second))
+	}
+
+	r.Server.TenantReconcilesPerSecond = r.Server.TenantReconciles / r.Reconcile.Duration.Seconds()
+
+	return r, nil
+}
+
+// waitReconciled waits for all the Tenants to report their Namespaces in the status.
+func (b *benchmark) waitReconciled(ctx context.Context) error {
+	return wait.PollUntilContextCancel(ctx, time.Second, true, func(ctx context.Context) (bool, error) {
+		tntList := &capsulev1beta2.TenantList{}
+		if err := b.client.List(ctx, tntList, client.MatchingLabels{benchLabel: b.runID}); err != nil {
+			return false, err
+		}
+
+		for _, tnt := range tntList.Items {
+			if int(tnt.Status.Size) < b.opts.namespacesPerTenant {
+				return false, nil
+			}
+		}
+
+		return len(tntList.Items) == b.opts.tenants, nil
+	})
+}
+
+// scrape returns the Capsule metrics, reached through the API server proxy to the metrics Service:
+// with more replicas, the metrics of a single one are returned.
+func (b *benchmark) scrape(ctx context.Context) (bench.Metrics, error) {
+	if len(b.opts.metricsService) == 0 {
+		return nil, fmt.Errorf("no metrics Service")
+	}
+
+	raw, err := b.clientset.CoreV1().Services(b.opts.capsuleNamespace).ProxyGet("http", b.opts.metricsService, b.opts.metricsPort, "metrics", nil).DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return bench.ParseMetrics(bytes.NewReader(raw))
+}
+
+// cleanup deletes the synthetic Tenants: their Namespaces are deleted along with them.
+func (b *benchmark) cleanup() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if err := b.client.DeleteAllOf(ctx, &capsulev1beta2.Tenant{}, client.MatchingLabels{benchLabel: b.runID}); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: cannot delete the synthetic Tenants labeled %s=%s: %s\n", benchLabel, b.runID, err)
+	}
+}
+
+// parallel runs the function for each index with the given concurrency, returning the first error.
+func parallel(ctx context.Context, count, concurrency int, fn func(i int) error) error {
+	indexes := make(chan int)
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				if err := fn(i); err != nil {
+					once.Do(func() {
+						firstErr = err
+					})
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < count && ctx.Err() == nil; i++ {
+		indexes <- i
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return ctx.Err()
+}
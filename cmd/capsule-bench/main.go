@@ -0,0 +1,127 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Command capsule-bench generates synthetic Tenants, Namespaces, and admission requests against a test cluster
+// running Capsule, reporting the reconcile throughput, the webhook latencies, and the memory footprint of Capsule:
+// the reports of different releases, generated with the same options, measure the performance regressions.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	goflag "flag"
+	"fmt"
+	"os"
+	"time"
+
+	flag "github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+type options struct {
+	tenants             int
+	namespacesPerTenant int
+	admissionRequests   int
+	concurrency         int
+	capsuleGroup        string
+	capsuleNamespace    string
+	metricsService      string
+	metricsPort         string
+	timeout             time.Duration
+	keep                bool
+	output              string
+}
+
+func main() {
+	opts := options{}
+
+	flag.IntVar(&opts.tenants, "tenants", 10, "Number of the synthetic Tenants")
+	flag.IntVar(&opts.namespacesPerTenant, "namespaces-per-tenant", 5, "Number of the Namespaces created by the owner of each Tenant")
+	flag.IntVar(&opts.admissionRequests, "admission-requests", 500, "Number of the dry-run Pod creations, evaluated by the Capsule webhooks without persisting the Pods")
+	flag.IntVar(&opts.concurrency, "concurrency", 10, "Number of the concurrent requests")
+	flag.StringVar(&opts.capsuleGroup, "capsule-group", "projectcapsule.dev", "Capsule user group of the Tenant owners")
+	flag.StringVar(&opts.capsuleNamespace, "capsule-namespace", "capsule-system", "Namespace of the Capsule installation")
+	flag.StringVar(&opts.metricsService, "metrics-service", "capsule-controller-manager-metrics-service", "Service exposing the Capsule metrics, scraped through the API server proxy: the scrape is skipped when empty")
+	flag.StringVar(&opts.metricsPort, "metrics-port", "8080", "Port of the Service exposing the Capsule metrics")
+	flag.DurationVar(&opts.timeout, "timeout", 10*time.Minute, "Timeout of the benchmark")
+	flag.BoolVar(&opts.keep, "keep", false, "Keep the synthetic Tenants, and their Namespaces, once completed")
+	flag.StringVarP(&opts.output, "output", "o", "text", "Output format, one of text or json")
+
+	flag.CommandLine.AddGoFlagSet(goflag.CommandLine)
+	flag.Parse()
+
+	if err := run(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(opts options) error {
+	if opts.output != "text" && opts.output != "json" {
+		return fmt.Errorf("unsupported output format %s", opts.output)
+	}
+
+	if opts.tenants < 1 || opts.namespacesPerTenant < 1 || opts.concurrency < 1 {
+		return fmt.Errorf("the tenants, namespaces-per-tenant, and concurrency flags must be positive")
+	}
+
+	config, err := ctrl.GetConfig()
+	if err != nil {
+		return err
+	}
+	// The client side rate limiting would measure the benchmark rather than Capsule.
+	config.QPS, config.Burst = 1000, 2000
+
+	scheme := runtime.NewScheme()
+
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(capsulev1beta2.AddToScheme(scheme))
+
+	c, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return err
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
+	defer cancel()
+
+	b := &benchmark{
+		opts:      opts,
+		config:    config,
+		client:    c,
+		clientset: clientset,
+		runID:     time.Now().UTC().Format("20060102150405"),
+	}
+
+	if !opts.keep {
+		defer b.cleanup()
+	}
+
+	report, err := b.run(ctx)
+	if err != nil {
+		return err
+	}
+
+	if opts.output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(report)
+	}
+
+	report.print(os.Stdout)
+
+	return nil
+}
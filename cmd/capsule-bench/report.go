@@ -0,0 +1,62 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/projectcapsule/capsule/pkg/bench"
+)
+
+type report struct {
+	Tenants             int `json:"tenants"`
+	NamespacesPerTenant int `json:"namespacesPerTenant"`
+	AdmissionRequests   int `json:"admissionRequests"`
+	Concurrency         int `json:"concurrency"`
+	// TenantCreation and NamespaceCreation latencies, including the Capsule webhooks evaluation.
+	TenantCreation    bench.LatencySummary `json:"tenantCreation"`
+	NamespaceCreation bench.LatencySummary `json:"namespaceCreation"`
+	// Reconcile measures the time required for all the Namespaces to be reported in the Tenant status.
+	Reconcile struct {
+		Duration            time.Duration `json:"duration"`
+		NamespacesPerSecond float64       `json:"namespacesPerSecond"`
+	} `json:"reconcile"`
+	// Admission latencies of the dry-run Pod creations, as observed by the client.
+	Admission bench.LatencySummary `json:"admission"`
+	// Server side measures, from the Capsule metrics.
+	Server *serverReport `json:"server,omitempty"`
+}
+
+type serverReport struct {
+	TenantReconciles          float64       `json:"tenantReconciles"`
+	TenantReconcilesPerSecond float64       `json:"tenantReconcilesPerSecond"`
+	WebhookP99                time.Duration `json:"webhookP99"`
+	ResidentMemoryBytes       float64       `json:"residentMemoryBytes"`
+	HeapBytes                 float64       `json:"heapBytes"`
+}
+
+func (r *report) print(w io.Writer) {
+	fmt.Fprintf(w, "Tenants: %d, Namespaces per Tenant: %d, admission requests: %d, concurrency: %d\n\n", r.Tenants, r.NamespacesPerTenant, r.AdmissionRequests, r.Concurrency)
+
+	printLatencies(w, "Tenant creation", r.TenantCreation)
+	printLatencies(w, "Namespace creation", r.NamespaceCreation)
+	printLatencies(w, "Pod admission", r.Admission)
+
+	fmt.Fprintf(w, "\nReconcile: %s, %.2f Namespaces/s\n", r.Reconcile.Duration.Round(time.Millisecond), r.Reconcile.NamespacesPerSecond)
+
+	if r.Server == nil {
+		return
+	}
+
+	fmt.Fprintf(w, "Tenant reconciles: %.0f, %.2f/s\n", r.Server.TenantReconciles, r.Server.TenantReconcilesPerSecond)
+	fmt.Fprintf(w, "Webhook P99 latency: %s\n", r.Server.WebhookP99.Round(time.Microsecond))
+	fmt.Fprintf(w, "Memory: %.1f MiB resident, %.1f MiB heap\n", r.Server.ResidentMemoryBytes/(1<<20), r.Server.HeapBytes/(1<<20))
+}
+
+func printLatencies(w io.Writer, name string, summary bench.LatencySummary) {
+	fmt.Fprintf(w, "%-20s count=%d errors=%d p50=%s p90=%s p99=%s max=%s\n", name, summary.Count, summary.Errors,
+		summary.P50.Round(time.Microsecond), summary.P90.Round(time.Microsecond), summary.P99.Round(time.Microsecond), summary.Max.Round(time.Microsecond))
+}
@@ -0,0 +1,132 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/conformance"
+)
+
+func conformanceRun(args []string) error {
+	fs := newFlagSet("conformance")
+
+	configurationName := fs.String("configuration-name", "default", "Name of the CapsuleConfiguration of the installation")
+	capsuleNamespace := fs.String("capsule-namespace", "capsule-system", "Namespace of the Capsule installation")
+	capsuleGroup := fs.String("capsule-group", "", "Capsule user group of the Tenant owners, the first one of the CapsuleConfiguration when empty")
+	focus := fs.String("focus", "", "Regular expression selecting the checks to run by name, all of them when empty")
+	disruptive := fs.Bool("disruptive", false, "Run the disruptive checks too, such as the certificate rotation and the webhook failover, restarting the Capsule Pods")
+	timeout := fs.Duration("timeout", 2*time.Minute, "Timeout of each check")
+	keep := fs.Bool("keep", false, "Keep the Tenants, and their Namespaces, created by the checks")
+	output := fs.StringP("output", "o", "text", "Output format, one of text, json, or junit")
+	reportFile := fs.String("report", "", "Path of the file the report is written to, the standard output when empty")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var write func(*conformance.Report, io.Writer) error
+
+	switch *output {
+	case "text":
+		write = (*conformance.Report).WriteText
+	case "json":
+		write = (*conformance.Report).WriteJSON
+	case "junit":
+		write = (*conformance.Report).WriteJUnit
+	default:
+		return fmt.Errorf("unsupported output format %s", *output)
+	}
+
+	opts := conformance.Options{Disruptive: *disruptive, Timeout: *timeout}
+
+	if len(*focus) > 0 {
+		expr, err := regexp.Compile(*focus)
+		if err != nil {
+			return fmt.Errorf("invalid focus: %w", err)
+		}
+
+		opts.Focus = expr
+	}
+
+	config, err := ctrl.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	cfg := &capsulev1beta2.CapsuleConfiguration{}
+	if err = c.Get(ctx, types.NamespacedName{Name: *configurationName}, cfg); err != nil {
+		return fmt.Errorf("cannot retrieve the CapsuleConfiguration %s: %w", *configurationName, err)
+	}
+
+	group := *capsuleGroup
+	if len(group) == 0 {
+		if len(cfg.Spec.UserGroups) == 0 {
+			return fmt.Errorf("the CapsuleConfiguration %s defines no user groups, the --capsule-group flag is required", *configurationName)
+		}
+
+		group = cfg.Spec.UserGroups[0]
+	}
+
+	suite := &conformanceSuite{
+		config:        config,
+		client:        c,
+		clientset:     clientset,
+		configuration: cfg,
+		namespace:     *capsuleNamespace,
+		group:         group,
+		runID:         time.Now().UTC().Format("20060102150405"),
+	}
+
+	if !*keep {
+		defer suite.cleanup()
+	}
+
+	report := conformance.Run(ctx, suite.checks(), opts)
+
+	w := io.Writer(os.Stdout)
+
+	if len(*reportFile) > 0 {
+		f, fileErr := os.Create(*reportFile)
+		if fileErr != nil {
+			return fileErr
+		}
+
+		defer f.Close()
+
+		w = f
+	}
+
+	if err = write(report, w); err != nil {
+		return err
+	}
+
+	if report.Failed > 0 {
+		return fmt.Errorf("%d of %d conformance checks failed", report.Failed, len(report.Results))
+	}
+
+	return nil
+}
@@ -0,0 +1,649 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+	"github.com/projectcapsule/capsule/pkg/cert"
+	"github.com/projectcapsule/capsule/pkg/conformance"
+)
+
+// conformanceLabel marks the Tenants created by the conformance checks with the identifier of the run.
+const conformanceLabel = "capsule.clastix.io/conformance"
+
+const conformancePollInterval = time.Second
+
+type conformanceSuite struct {
+	config        *rest.Config
+	client        client.Client
+	clientset     kubernetes.Interface
+	configuration *capsulev1beta2.CapsuleConfiguration
+	namespace     string
+	group         string
+	runID         string
+}
+
+func (s *conformanceSuite) checks() []conformance.Check {
+	return []conformance.Check{
+		{
+			Name:        "tenant-creation",
+			Description: "A Tenant owner can create a Namespace, assigned to the Tenant and administered by the owner",
+			Run:         s.checkTenantCreation,
+		},
+		{
+			Name:        "namespace-quota",
+			Description: "The Namespaces exceeding the Tenant quota are denied",
+			Run:         s.checkNamespaceQuota,
+		},
+		{
+			Name:        "tenant-isolation",
+			Description: "A Tenant owner cannot access the Namespaces, nor the definition, of another Tenant",
+			Run:         s.checkTenantIsolation,
+		},
+		{
+			Name:        "network-policies",
+			Description: "The Tenant NetworkPolicies are replicated in its Namespaces, and cannot be deleted by the owners",
+			Run:         s.checkNetworkPolicies,
+		},
+		{
+			Name:        "resource-quota-enforcement",
+			Description: "The Tenant ResourceQuotas are replicated in its Namespaces, and enforced",
+			Run:         s.checkResourceQuotaEnforcement,
+		},
+		{
+			Name:        "storage-class-enforcement",
+			Description: "The PersistentVolumeClaims using a StorageClass not allowed for the Tenant are denied",
+			Run:         s.checkStorageClassEnforcement,
+		},
+		{
+			Name:        "webhook-availability",
+			Description: "The Capsule webhooks fail closed, and are served by ready endpoints",
+			Run:         s.checkWebhookAvailability,
+		},
+		{
+			Name:        "certificate-validity",
+			Description: "The webhook certificate is valid, and trusted by the webhook configurations",
+			Run:         s.checkCertificateValidity,
+		},
+		{
+			Name:        "certificate-rotation",
+			Description: "The TLS reconciler rotates the webhook certificate, and the webhooks keep serving",
+			Disruptive:  true,
+			Run:         s.checkCertificateRotation,
+		},
+		{
+			Name:        "webhook-failover",
+			Description: "The policies are enforced again once a Capsule Pod is deleted",
+			Disruptive:  true,
+			Run:         s.checkWebhookFailover,
+		},
+	}
+}
+
+// newTenant creates a Tenant owned by a dedicated user, returning the clientset impersonating the owner.
+func (s *conformanceSuite) newTenant(ctx context.Context, suffix string, spec capsulev1beta2.TenantSpec) (*capsulev1beta2.Tenant, kubernetes.Interface, error) {
+	name := fmt.Sprintf("conformance-%s-%s", s.runID, suffix)
+	owner := name + "-owner"
+
+	spec.Owners = capsulev1beta2.OwnerListSpec{{Kind: capsulev1beta2.UserOwner, Name: owner}}
+
+	tnt := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{conformanceLabel: s.runID},
+		},
+		Spec: spec,
+	}
+
+	if err := s.client.Create(ctx, tnt); err != nil {
+		return nil, nil, fmt.Errorf("cannot create the Tenant %s: %w", name, err)
+	}
+
+	config := rest.CopyConfig(s.config)
+	config.Impersonate = rest.ImpersonationConfig{
+		UserName: owner,
+		Groups:   []string{s.group},
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tnt, clientset, nil
+}
+
+// createNamespace creates the Namespace as the Tenant owner, retrying since the Tenant could be not yet cached by Capsule,
+// and waits for the Namespace to be reported in the Tenant status.
+func (s *conformanceSuite) createNamespace(ctx context.Context, tnt *capsulev1beta2.Tenant, owner kubernetes.Interface, name string) error {
+	var lastErr error
+
+	if err := poll(ctx, func(ctx context.Context) (bool, error) {
+		_, lastErr = owner.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}, metav1.CreateOptions{})
+
+		return lastErr == nil || apierrors.IsAlreadyExists(lastErr), nil
+	}); err != nil {
+		return fmt.Errorf("the owner cannot create the Namespace %s: %w", name, lastErr)
+	}
+
+	if err := poll(ctx, func(ctx context.Context) (bool, error) {
+		found := &capsulev1beta2.Tenant{}
+		if err := s.client.Get(ctx, types.NamespacedName{Name: tnt.GetName()}, found); err != nil {
+			return false, nil //nolint:nilerr
+		}
+
+		for _, ns := range found.Status.Namespaces {
+			if ns == name {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}); err != nil {
+		return fmt.Errorf("the Namespace %s is not reported in the Tenant status", name)
+	}
+
+	return nil
+}
+
+// allowed checks whether the impersonated user can perform the verb on the resource.
+func allowed(ctx context.Context, cs kubernetes.Interface, attributes authorizationv1.ResourceAttributes) (bool, error) {
+	review, err := cs.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: &attributes},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+
+	return review.Status.Allowed, nil
+}
+
+func poll(ctx context.Context, condition wait.ConditionWithContextFunc) error {
+	return wait.PollUntilContextCancel(ctx, conformancePollInterval, true, condition)
+}
+
+func (s *conformanceSuite) checkTenantCreation(ctx context.Context) error {
+	tnt, owner, err := s.newTenant(ctx, "creation", capsulev1beta2.TenantSpec{})
+	if err != nil {
+		return err
+	}
+
+	name := tnt.GetName() + "-ns"
+
+	if err = s.createNamespace(ctx, tnt, owner, name); err != nil {
+		return err
+	}
+
+	ns := &corev1.Namespace{}
+	if err = s.client.Get(ctx, types.NamespacedName{Name: name}, ns); err != nil {
+		return err
+	}
+
+	if ns.GetLabels()["capsule.clastix.io/tenant"] != tnt.GetName() {
+		return fmt.Errorf("the Namespace %s is not labeled with the Tenant name", name)
+	}
+
+	if err = poll(ctx, func(ctx context.Context) (bool, error) {
+		return allowed(ctx, owner, authorizationv1.ResourceAttributes{Namespace: name, Verb: "create", Resource: "pods"})
+	}); err != nil {
+		return fmt.Errorf("the owner cannot administer the Namespace %s", name)
+	}
+
+	return nil
+}
+
+func (s *conformanceSuite) checkNamespaceQuota(ctx context.Context) error {
+	tnt, owner, err := s.newTenant(ctx, "quota", capsulev1beta2.TenantSpec{
+		NamespaceOptions: &capsulev1beta2.NamespaceOptions{Quota: ptr.To[int32](1)},
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = s.createNamespace(ctx, tnt, owner, tnt.GetName()+"-first"); err != nil {
+		return err
+	}
+
+	_, err = owner.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: tnt.GetName() + "-second"}}, metav1.CreateOptions{})
+	if err == nil {
+		return fmt.Errorf("the Namespace exceeding the Tenant quota has been created")
+	}
+
+	return nil
+}
+
+func (s *conformanceSuite) checkTenantIsolation(ctx context.Context) error {
+	first, firstOwner, err := s.newTenant(ctx, "isolation-a", capsulev1beta2.TenantSpec{})
+	if err != nil {
+		return err
+	}
+
+	second, secondOwner, err := s.newTenant(ctx, "isolation-b", capsulev1beta2.TenantSpec{})
+	if err != nil {
+		return err
+	}
+
+	if err = s.createNamespace(ctx, first, firstOwner, first.GetName()+"-ns"); err != nil {
+		return err
+	}
+
+	if err = s.createNamespace(ctx, second, secondOwner, second.GetName()+"-ns"); err != nil {
+		return err
+	}
+
+	for _, attributes := range []authorizationv1.ResourceAttributes{
+		{Namespace: second.GetName() + "-ns", Verb: "list", Resource: "pods"},
+		{Namespace: second.GetName() + "-ns", Verb: "get", Resource: "secrets"},
+		{Verb: "get", Group: capsulev1beta2.GroupVersion.Group, Resource: "tenants", Name: second.GetName()},
+	} {
+		ok, reviewErr := allowed(ctx, firstOwner, attributes)
+		if reviewErr != nil {
+			return reviewErr
+		}
+
+		if ok {
+			return fmt.Errorf("the owner of the Tenant %s can %s the %s of the Tenant %s", first.GetName(), attributes.Verb, attributes.Resource, second.GetName())
+		}
+	}
+
+	_, err = firstOwner.CoreV1().ConfigMaps(second.GetName()+"-ns").Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "conformance"}}, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+	if err == nil {
+		return fmt.Errorf("the owner of the Tenant %s can create objects in the Namespaces of the Tenant %s", first.GetName(), second.GetName())
+	}
+
+	return nil
+}
+
+func (s *conformanceSuite) checkNetworkPolicies(ctx context.Context) error {
+	tnt, owner, err := s.newTenant(ctx, "network", capsulev1beta2.TenantSpec{
+		NetworkPolicies: api.NetworkPolicySpec{
+			Items: []networkingv1.NetworkPolicySpec{
+				{
+					PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+					Ingress: []networkingv1.NetworkPolicyIngressRule{
+						{
+							From: []networkingv1.NetworkPolicyPeer{
+								{
+									NamespaceSelector: &metav1.LabelSelector{
+										MatchLabels: map[string]string{"capsule.clastix.io/tenant": fmt.Sprintf("conformance-%s-network", s.runID)},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	name := tnt.GetName() + "-ns"
+
+	if err = s.createNamespace(ctx, tnt, owner, name); err != nil {
+		return err
+	}
+
+	policy := fmt.Sprintf("capsule-%s-0", tnt.GetName())
+
+	if err = poll(ctx, func(ctx context.Context) (bool, error) {
+		getErr := s.client.Get(ctx, types.NamespacedName{Namespace: name, Name: policy}, &networkingv1.NetworkPolicy{})
+
+		return getErr == nil, nil
+	}); err != nil {
+		return fmt.Errorf("the NetworkPolicy %s is not replicated in the Namespace %s", policy, name)
+	}
+
+	if err = owner.NetworkingV1().NetworkPolicies(name).Delete(ctx, policy, metav1.DeleteOptions{DryRun: []string{metav1.DryRunAll}}); err == nil {
+		return fmt.Errorf("the owner can delete the NetworkPolicy %s", policy)
+	}
+
+	return nil
+}
+
+func (s *conformanceSuite) checkResourceQuotaEnforcement(ctx context.Context) error {
+	tnt, owner, err := s.newTenant(ctx, "resources", capsulev1beta2.TenantSpec{
+		ResourceQuota: api.ResourceQuotaSpec{
+			Scope: api.ResourceQuotaScopeTenant,
+			Items: []corev1.ResourceQuotaSpec{
+				{Hard: corev1.ResourceList{corev1.ResourceServices: resource.MustParse("1")}},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	name := tnt.GetName() + "-ns"
+
+	if err = s.createNamespace(ctx, tnt, owner, name); err != nil {
+		return err
+	}
+
+	service := func(name string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{{Port: 80}},
+			},
+		}
+	}
+
+	var lastErr error
+	// Retrying until the ResourceQuota is replicated, and its usage computed.
+	if err = poll(ctx, func(ctx context.Context) (bool, error) {
+		quotaList := &corev1.ResourceQuotaList{}
+		if listErr := s.client.List(ctx, quotaList, client.InNamespace(name), client.HasLabels{"capsule.clastix.io/resource-quota"}); listErr != nil || len(quotaList.Items) == 0 {
+			return false, nil //nolint:nilerr
+		}
+
+		if quotaList.Items[0].Status.Hard == nil {
+			return false, nil
+		}
+
+		_, lastErr = owner.CoreV1().Services(name).Create(ctx, service("first"), metav1.CreateOptions{})
+
+		return lastErr == nil || apierrors.IsAlreadyExists(lastErr), nil
+	}); err != nil {
+		return fmt.Errorf("the Service within the Tenant quota cannot be created: %v", lastErr)
+	}
+
+	if _, err = owner.CoreV1().Services(name).Create(ctx, service("second"), metav1.CreateOptions{}); err == nil {
+		return fmt.Errorf("the Service exceeding the Tenant quota has been created")
+	}
+
+	return nil
+}
+
+func (s *conformanceSuite) checkStorageClassEnforcement(ctx context.Context) error {
+	tnt, owner, err := s.newTenant(ctx, "storage", capsulev1beta2.TenantSpec{
+		StorageClasses: &api.DefaultAllowedListSpec{
+			SelectorAllowedListSpec: api.SelectorAllowedListSpec{
+				AllowedListSpec: api.AllowedListSpec{Exact: []string{"conformance-allowed"}},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	name := tnt.GetName() + "-ns"
+
+	if err = s.createNamespace(ctx, tnt, owner, name); err != nil {
+		return err
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "conformance"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: ptr.To("conformance-denied"),
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+		},
+	}
+
+	if _, err = owner.CoreV1().PersistentVolumeClaims(name).Create(ctx, pvc, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}}); err == nil {
+		return fmt.Errorf("the PersistentVolumeClaim using a StorageClass not allowed has been created")
+	}
+
+	return nil
+}
+
+// webhookService returns the Service serving the Capsule validating webhooks.
+func (s *conformanceSuite) webhookService(ctx context.Context) (*admissionregistrationv1.ValidatingWebhookConfiguration, *corev1.Service, error) {
+	vwc := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	if err := s.client.Get(ctx, types.NamespacedName{Name: s.configuration.Spec.CapsuleResources.ValidatingWebhookConfigurationName}, vwc); err != nil {
+		return nil, nil, err
+	}
+
+	for _, webhook := range vwc.Webhooks {
+		if ref := webhook.ClientConfig.Service; ref != nil {
+			service := &corev1.Service{}
+			if err := s.client.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, service); err != nil {
+				return nil, nil, err
+			}
+
+			return vwc, service, nil
+		}
+	}
+
+	return vwc, nil, nil
+}
+
+// readyEndpoints returns the number of the ready endpoints of the Service.
+func (s *conformanceSuite) readyEndpoints(ctx context.Context, service *corev1.Service) (ready int, err error) {
+	sliceList := &discoveryv1.EndpointSliceList{}
+	if err = s.client.List(ctx, sliceList, client.InNamespace(service.GetNamespace()), client.MatchingLabels{discoveryv1.LabelServiceName: service.GetName()}); err != nil {
+		return 0, err
+	}
+
+	for _, slice := range sliceList.Items {
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+				ready++
+			}
+		}
+	}
+
+	return ready, nil
+}
+
+func (s *conformanceSuite) checkWebhookAvailability(ctx context.Context) error {
+	vwc, service, err := s.webhookService(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range vwc.Webhooks {
+		switch webhook.Name {
+		case "namespaces.projectcapsule.dev", "tenants.projectcapsule.dev":
+			if webhook.FailurePolicy != nil && *webhook.FailurePolicy == admissionregistrationv1.Ignore {
+				return fmt.Errorf("the %s webhook ignores the failures, the policies are not enforced while Capsule is unavailable", webhook.Name)
+			}
+		}
+	}
+
+	if service == nil {
+		return conformance.Skip("the webhooks are not served by an in-cluster Service")
+	}
+
+	ready, err := s.readyEndpoints(ctx, service)
+	if err != nil {
+		return err
+	}
+
+	if ready == 0 {
+		return fmt.Errorf("the webhook Service %s has no ready endpoints", service.GetName())
+	}
+
+	return nil
+}
+
+func (s *conformanceSuite) tlsSecret(ctx context.Context) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := s.client.Get(ctx, types.NamespacedName{Namespace: s.namespace, Name: s.configuration.Spec.CapsuleResources.TLSSecretName}, secret); err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+// verifyCertificate checks the webhook certificate is valid, and trusted by the CA bundle of all the webhooks.
+func (s *conformanceSuite) verifyCertificate(ctx context.Context, secret *corev1.Secret) error {
+	certificate, err := cert.GetCertificateFromBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return fmt.Errorf("cannot parse the webhook certificate: %w", err)
+	}
+
+	if now := time.Now(); now.After(certificate.NotAfter) || now.Before(certificate.NotBefore) {
+		return fmt.Errorf("the webhook certificate is valid from %s to %s", certificate.NotBefore, certificate.NotAfter)
+	}
+
+	vwc, _, err := s.webhookService(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range vwc.Webhooks {
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(webhook.ClientConfig.CABundle) {
+			return fmt.Errorf("the %s webhook has no valid CA bundle", webhook.Name)
+		}
+
+		if _, err = certificate.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}}); err != nil {
+			return fmt.Errorf("the webhook certificate is not trusted by the %s webhook: %w", webhook.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *conformanceSuite) checkCertificateValidity(ctx context.Context) error {
+	secret, err := s.tlsSecret(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.verifyCertificate(ctx, secret)
+}
+
+// admissionServed checks the Capsule webhooks are serving, creating a Namespace in dry-run as a Tenant owner.
+func (s *conformanceSuite) admissionServed(ctx context.Context, owner kubernetes.Interface, name string) error {
+	var lastErr error
+
+	if err := poll(ctx, func(ctx context.Context) (bool, error) {
+		_, lastErr = owner.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+
+		return lastErr == nil, nil
+	}); err != nil {
+		return fmt.Errorf("the webhooks are not serving: %v", lastErr)
+	}
+
+	return nil
+}
+
+func (s *conformanceSuite) checkCertificateRotation(ctx context.Context) error {
+	if !s.configuration.Spec.EnableTLSReconciler {
+		return conformance.Skip("the TLS reconciler is disabled, the certificate is managed externally")
+	}
+
+	_, owner, err := s.newTenant(ctx, "rotation", capsulev1beta2.TenantSpec{})
+	if err != nil {
+		return err
+	}
+
+	secret, err := s.tlsSecret(ctx)
+	if err != nil {
+		return err
+	}
+
+	previous := secret.Data[corev1.TLSCertKey]
+	// Emptying the Secret, the TLS reconciler generates a new CA and certificate.
+	secret.Data = nil
+
+	if err = s.client.Update(ctx, secret); err != nil {
+		return err
+	}
+
+	if err = poll(ctx, func(ctx context.Context) (bool, error) {
+		if secret, err = s.tlsSecret(ctx); err != nil {
+			return false, nil //nolint:nilerr
+		}
+
+		current := secret.Data[corev1.TLSCertKey]
+
+		return len(current) > 0 && !bytes.Equal(current, previous), nil
+	}); err != nil {
+		return fmt.Errorf("the webhook certificate has not been rotated")
+	}
+
+	if err = poll(ctx, func(ctx context.Context) (bool, error) {
+		return s.verifyCertificate(ctx, secret) == nil, nil
+	}); err != nil {
+		return fmt.Errorf("the rotated certificate is not trusted by the webhooks: %w", s.verifyCertificate(ctx, secret))
+	}
+
+	return s.admissionServed(ctx, owner, fmt.Sprintf("conformance-%s-rotation-ns", s.runID))
+}
+
+func (s *conformanceSuite) checkWebhookFailover(ctx context.Context) error {
+	_, service, err := s.webhookService(ctx)
+	if err != nil {
+		return err
+	}
+
+	if service == nil || len(service.Spec.Selector) == 0 {
+		return conformance.Skip("the webhooks are not served by in-cluster Pods")
+	}
+
+	_, owner, err := s.newTenant(ctx, "failover", capsulev1beta2.TenantSpec{})
+	if err != nil {
+		return err
+	}
+
+	podList := &corev1.PodList{}
+	if err = s.client.List(ctx, podList, client.InNamespace(service.GetNamespace()), client.MatchingLabels(service.Spec.Selector)); err != nil {
+		return err
+	}
+
+	if len(podList.Items) == 0 {
+		return fmt.Errorf("no Pods are selected by the webhook Service %s", service.GetName())
+	}
+
+	pod := podList.Items[0]
+	if err = s.client.Delete(ctx, &pod); err != nil {
+		return err
+	}
+
+	start := time.Now()
+
+	if err = s.admissionServed(ctx, owner, fmt.Sprintf("conformance-%s-failover-ns", s.runID)); err != nil {
+		return err
+	}
+
+	if err = poll(ctx, func(ctx context.Context) (bool, error) {
+		ready, readyErr := s.readyEndpoints(ctx, service)
+
+		return readyErr == nil && ready >= len(podList.Items), nil
+	}); err != nil {
+		return fmt.Errorf("the Capsule Pods are not ready again, %d expected", len(podList.Items))
+	}
+
+	fmt.Fprintf(os.Stderr, "webhook-failover: the webhooks served again %s after the deletion of the Pod %s\n", time.Since(start).Round(time.Millisecond), pod.GetName())
+
+	return nil
+}
+
+// cleanup deletes the Tenants created by the checks, along with their Namespaces.
+func (s *conformanceSuite) cleanup() {
+	if err := s.client.DeleteAllOf(context.Background(), &capsulev1beta2.Tenant{}, client.MatchingLabels{conformanceLabel: s.runID}); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: cannot delete the conformance Tenants: %s\n", err)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/projectcapsule/capsule/pkg/history"
+)
+
+func historyList(args []string) error {
+	if len(args) == 0 || args[0] != "tenant" {
+		return fmt.Errorf("usage: history tenant [flags]")
+	}
+
+	fs := newFlagSet("history tenant")
+
+	name := fs.String("name", "", "Name of the Tenant, all the records are listed when empty")
+	kind := fs.String("kind", string(history.KindAudit), "Kind of the records, one of audit or usage")
+	backend := fs.String("backend", string(history.BackendConfigMap), "Storage backend of the history, one of configmap, sqlite, or postgres")
+	dsn := fs.String("dsn", os.Getenv("HISTORY_DSN"), "Database file path of the sqlite backend, or connection string of the postgres one")
+	namespace := fs.String("namespace", "capsule-system", "Namespace of the ConfigMaps of the configmap backend")
+	since := fs.Duration("since", 24*time.Hour, "Age of the oldest record listed")
+	limit := fs.Int("limit", 100, "Number of the most recent records listed, all the records are listed when zero")
+	output := fs.StringP("output", "o", "text", "Output format, one of text or json")
+
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	opts := history.Options{DSN: *dsn, Namespace: *namespace}
+
+	if history.Backend(*backend) == history.BackendConfigMap {
+		c, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		opts.Client = c
+	}
+
+	store, err := history.Open(history.Backend(*backend), opts)
+	if err != nil {
+		return err
+	}
+
+	if store == nil {
+		return fmt.Errorf("unsupported history backend %s", *backend)
+	}
+
+	defer store.Close()
+
+	records, err := store.List(context.Background(), history.Query{
+		Kind:   history.Kind(*kind),
+		Tenant: *name,
+		Since:  time.Now().Add(-*since),
+		Limit:  *limit,
+	})
+	if err != nil {
+		return err
+	}
+
+	switch *output {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(records)
+	case "text":
+		for _, record := range records {
+			timestamp := record.Time.Format(time.RFC3339)
+
+			if record.Kind == history.KindUsage {
+				fmt.Fprintf(os.Stdout, "%s %s %s\n", timestamp, record.Tenant, formatUsage(record))
+
+				continue
+			}
+
+			fmt.Fprintf(os.Stdout, "%s %s %s %s %s/%s by %s: %s\n", timestamp, record.Tenant, record.Code, record.Operation, record.Namespace, record.Name, record.User, record.Message)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format %s", *output)
+	}
+}
+
+func formatUsage(record history.Record) string {
+	usage := make([]string, 0, len(record.Usage))
+
+	for name, quantity := range record.Usage {
+		usage = append(usage, fmt.Sprintf("%s=%s", name, quantity.String()))
+	}
+
+	sort.Strings(usage)
+
+	return strings.Join(usage, ",")
+}
@@ -0,0 +1,90 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/impact"
+)
+
+func impactPreview(args []string) error {
+	if len(args) == 0 || args[0] != "tenant" {
+		return fmt.Errorf("usage: impact tenant [flags]")
+	}
+
+	fs := newFlagSet("impact tenant")
+
+	name := fs.String("name", "", "Name of the Tenant")
+	configurationName := fs.String("configuration-name", "default", "Name of the CapsuleConfiguration defining the deletion impact thresholds")
+	output := fs.StringP("output", "o", "text", "Output format, one of text or json")
+
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	if len(*name) == 0 {
+		return fmt.Errorf("the --name flag is required")
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	tnt := &capsulev1beta2.Tenant{}
+	if err = c.Get(ctx, types.NamespacedName{Name: *name}, tnt); err != nil {
+		return err
+	}
+
+	summary, err := impact.Compute(ctx, c, tnt)
+	if err != nil {
+		return err
+	}
+
+	cfg := &capsulev1beta2.CapsuleConfiguration{}
+	if err = c.Get(ctx, types.NamespacedName{Name: *configurationName}, cfg); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	exceeded := summary.Exceeded(cfg.Spec.DeletionImpactThresholds)
+
+	switch *output {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(struct {
+			*impact.Summary
+			ExceededThresholds []string `json:"exceededThresholds,omitempty"`
+		}{summary, exceeded})
+	case "text":
+		fmt.Fprintf(os.Stdout, "Namespaces: %s\n", strings.Join(summary.Namespaces, ", "))
+
+		for _, kind := range []string{"Deployment", "StatefulSet", "DaemonSet", "CronJob", "Job", "Pod"} {
+			fmt.Fprintf(os.Stdout, "%s objects: %d\n", kind, summary.Workloads[kind])
+		}
+
+		fmt.Fprintf(os.Stdout, "PersistentVolumeClaims: %d, requesting %s\n", summary.PersistentVolumeClaims, summary.Storage.String())
+		fmt.Fprintf(os.Stdout, "LoadBalancer Services: %s\n", strings.Join(summary.LoadBalancers, ", "))
+
+		if len(exceeded) > 0 {
+			fmt.Fprintf(os.Stdout, "\nThe deletion requires a confirmation, since %s: annotate the Tenant with %s=%s to confirm it.\n", strings.Join(exceeded, ", "), impact.ConfirmDeletionAnnotation, tnt.GetName())
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format %s", *output)
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/projectcapsule/capsule/pkg/scaffold"
+)
+
+func initResource(args []string) error {
+	if len(args) == 0 || args[0] != "tenant" {
+		return fmt.Errorf("usage: init tenant [flags]")
+	}
+
+	fs := newFlagSet("init tenant")
+
+	name := fs.String("name", "", "Name of the Tenant")
+	owner := fs.String("owner", "", "Name of the Tenant owner")
+	ownerKind := fs.String("owner-kind", "User", "Kind of the Tenant owner, one of User, Group or ServiceAccount")
+	registryRegex := fs.String("registry-regex", "^docker\\.io/.+$", "Regular expression of the allowed container registries")
+	profile := fs.String("profile", scaffold.ProfileStandard, fmt.Sprintf("Policy profile of the Tenant, one of %s", strings.Join(scaffold.Profiles(), ", ")))
+
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	if len(*name) == 0 || len(*owner) == 0 {
+		return fmt.Errorf("the --name and --owner flags are required")
+	}
+
+	manifest, err := scaffold.Tenant(scaffold.Options{
+		Name:          *name,
+		Owner:         *owner,
+		OwnerKind:     *ownerKind,
+		RegistryRegex: *registryRegex,
+		Profile:       *profile,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprint(os.Stdout, manifest)
+
+	return err
+}
@@ -0,0 +1,109 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Command capsule provides the administrative tasks of a Capsule installation.
+package main
+
+import (
+	goflag "flag"
+	"fmt"
+	"os"
+	"sort"
+
+	flag "github.com/spf13/pflag"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capsulev1beta1 "github.com/projectcapsule/capsule/api/v1beta1"
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+type command struct {
+	description string
+	run         func(args []string) error
+}
+
+//nolint:gochecknoglobals
+var commands = map[string]command{
+	"conformance": {
+		description: "Run the conformance checks against the Capsule installation, such as conformance -o junit --report report.xml",
+		run:         conformanceRun,
+	},
+	"init": {
+		description: "Generate a commented Tenant manifest from a policy profile, such as init tenant --profile strict",
+		run:         initResource,
+	},
+	"history": {
+		description: "List the audit records, or the usage history, of a Tenant, such as history tenant --name oil --kind usage",
+		run:         historyList,
+	},
+	"impact": {
+		description: "Preview the resources deleted along with a Tenant, such as impact tenant --name oil",
+		run:         impactPreview,
+	},
+	"migrate": {
+		description: "Migrate the stored Tenant objects to the CustomResourceDefinition storage version",
+		run:         migrate,
+	},
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s <command> [flags]\n\nCommands:\n", os.Args[0])
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-16s %s\n", name, commands[name].description)
+	}
+}
+
+// newFlagSet returns the flags of a command, including the ones required to connect to the cluster, such as --kubeconfig.
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.AddGoFlagSet(goflag.CommandLine)
+
+	return fs
+}
+
+func newClient() (client.Client, error) {
+	scheme := runtime.NewScheme()
+
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(capsulev1beta1.AddToScheme(scheme))
+	utilruntime.Must(capsulev1beta2.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
+
+	config, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.New(config, client.Options{Scheme: scheme})
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+
+	if err := cmd.run(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
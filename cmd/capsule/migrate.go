@@ -0,0 +1,60 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/projectcapsule/capsule/pkg/configuration"
+	"github.com/projectcapsule/capsule/pkg/migration"
+)
+
+func migrate(args []string) error {
+	fs := newFlagSet("migrate")
+
+	crdName := fs.String("crd", configuration.TenantCRDName, "Name of the CustomResourceDefinition to migrate")
+	batchSize := fs.Int64("batch-size", migration.DefaultBatchSize, "Number of objects listed and rewritten per batch")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	migrator := &migration.Migrator{
+		Client:    c,
+		CRDName:   *crdName,
+		BatchSize: *batchSize,
+		Progress: func(migrated int, remaining *int64) {
+			if remaining != nil {
+				fmt.Fprintf(os.Stdout, "migrated %d objects, %d remaining\n", migrated, *remaining)
+
+				return
+			}
+
+			fmt.Fprintf(os.Stdout, "migrated %d objects\n", migrated)
+		},
+	}
+
+	result, err := migrator.Migrate(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if len(result.MigratedVersions) == 0 {
+		fmt.Fprintf(os.Stdout, "%s: all objects are stored with version %s, no migration required\n", *crdName, result.StorageVersion)
+
+		return nil
+	}
+
+	fmt.Fprintf(os.Stdout, "%s: migrated %d objects from %s to %s\n", *crdName, result.Migrated, strings.Join(result.MigratedVersions, ", "), result.StorageVersion)
+
+	return nil
+}
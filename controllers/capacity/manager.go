@@ -0,0 +1,277 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package capacity
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/metrics"
+	webhookutils "github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+const (
+	// PendingPodsAnnotation reports the number of Pending Pods in the Tenant Namespaces.
+	PendingPodsAnnotation = "capacity.capsule.clastix.io/pending-pods"
+	// UnschedulablePodsAnnotation reports the number of Pods the scheduler cannot place in the Tenant Namespaces.
+	UnschedulablePodsAnnotation = "capacity.capsule.clastix.io/unschedulable-pods"
+	// UnschedulableRequestsAnnotation reports the sum of the resource requests of the unschedulable Pods,
+	// in the <resource>=<quantity> comma separated format.
+	UnschedulableRequestsAnnotation = "capacity.capsule.clastix.io/unschedulable-requests"
+	// AnnotationsPrefix is shared by the capacity annotations, ignored by the controllers watching the Tenants.
+	AnnotationsPrefix = "capacity.capsule.clastix.io/"
+)
+
+// hintsInterval is the minimum interval between two updates of the capacity annotations of a Tenant:
+// the Pods changes in between are coalesced, publishing the latest counts only.
+const hintsInterval = 10 * time.Second
+
+// Manager exposes the per-Tenant Pending and unschedulable Pods, allowing cluster-autoscaler decisions to be
+// attributed per Tenant: as metrics, and as Tenant annotations keyed by the external automation managing the
+// node groups dedicated to the Tenants. It watches all the Pods, thus it must be registered only when either is enabled.
+type Manager struct {
+	Client client.Client
+	Log    logr.Logger
+	// Metrics enables the capacity metrics.
+	Metrics bool
+	// Hints enables the capacity annotations on the Tenant objects.
+	Hints bool
+	// published tracks the last update of the capacity annotations per Tenant.
+	published sync.Map
+}
+
+func (r *Manager) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("capacity").
+		For(&capsulev1beta2.Tenant{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.enqueuePodTenant), builder.WithPredicates(predicate.Funcs{
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				oldPod, oldOk := e.ObjectOld.(*corev1.Pod)
+				newPod, newOk := e.ObjectNew.(*corev1.Pod)
+
+				if !oldOk || !newOk {
+					return false
+				}
+
+				return isPending(oldPod) || isPending(newPod)
+			},
+		})).
+		Complete(r)
+}
+
+func (r *Manager) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.Log.WithValues("Tenant", request.Name)
+
+	tnt := &capsulev1beta2.Tenant{}
+	if err := r.Client.Get(ctx, request.NamespacedName, tnt); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.published.Delete(request.Name)
+			metrics.TenantPendingPods.DeleteLabelValues(request.Name)
+			metrics.TenantUnschedulablePods.DeleteLabelValues(request.Name)
+			metrics.TenantUnschedulableRequests.DeletePartialMatch(map[string]string{"tenant": request.Name})
+
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	var pending, unschedulable int
+
+	requests := corev1.ResourceList{}
+
+	for _, ns := range tnt.Status.Namespaces {
+		podList := &corev1.PodList{}
+		if err := r.Client.List(ctx, podList, client.InNamespace(ns)); err != nil {
+			log.Error(err, "cannot list Pods", "namespace", ns)
+
+			return reconcile.Result{}, err
+		}
+
+		for i := range podList.Items {
+			pod := podList.Items[i]
+
+			if !isPending(&pod) {
+				continue
+			}
+
+			pending++
+
+			if !isUnschedulable(&pod) {
+				continue
+			}
+
+			unschedulable++
+
+			for name, quantity := range podRequests(&pod) {
+				total := requests[name]
+				total.Add(quantity)
+				requests[name] = total
+			}
+		}
+	}
+
+	if r.Metrics {
+		metrics.TenantPendingPods.WithLabelValues(tnt.Name).Set(float64(pending))
+		metrics.TenantUnschedulablePods.WithLabelValues(tnt.Name).Set(float64(unschedulable))
+		metrics.TenantUnschedulableRequests.DeletePartialMatch(map[string]string{"tenant": tnt.Name})
+
+		for name, quantity := range requests {
+			metrics.TenantUnschedulableRequests.WithLabelValues(tnt.Name, name.String()).Set(quantity.AsApproximateFloat64())
+		}
+	}
+
+	if last, ok := r.published.Load(tnt.Name); ok && r.Hints {
+		if wait := hintsInterval - time.Since(last.(time.Time)); wait > 0 { //nolint:forcetypeassert
+			return reconcile.Result{RequeueAfter: wait}, nil
+		}
+	}
+
+	updated := false
+
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		found := &capsulev1beta2.Tenant{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: tnt.Name}, found); err != nil {
+			return err
+		}
+
+		annotations := found.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+
+		desired := map[string]string{}
+
+		if r.Hints {
+			desired[PendingPodsAnnotation] = strconv.Itoa(pending)
+			desired[UnschedulablePodsAnnotation] = strconv.Itoa(unschedulable)
+			desired[UnschedulableRequestsAnnotation] = formatRequests(requests)
+		}
+
+		changed := false
+
+		for _, key := range []string{PendingPodsAnnotation, UnschedulablePodsAnnotation, UnschedulableRequestsAnnotation} {
+			value, ok := desired[key]
+
+			switch {
+			case ok && annotations[key] != value:
+				annotations[key] = value
+				changed = true
+			case !ok:
+				if _, exists := annotations[key]; exists {
+					delete(annotations, key)
+
+					changed = true
+				}
+			}
+		}
+
+		if !changed {
+			return nil
+		}
+
+		found.SetAnnotations(annotations)
+
+		if err := r.Client.Update(ctx, found); err != nil {
+			return err
+		}
+
+		updated = true
+
+		return nil
+	})
+	if err != nil {
+		log.Error(err, "cannot update Tenant capacity hints")
+	}
+
+	if updated {
+		r.published.Store(tnt.Name, time.Now())
+	}
+
+	return reconcile.Result{}, err
+}
+
+func (r *Manager) enqueuePodTenant(ctx context.Context, obj client.Object) []reconcile.Request {
+	tnt, err := webhookutils.TenantByStatusNamespace(ctx, r.Client, obj.GetNamespace())
+	if err != nil || tnt == nil || len(tnt.GetName()) == 0 {
+		return nil
+	}
+
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: tnt.GetName()}}}
+}
+
+func isPending(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodPending && pod.DeletionTimestamp == nil
+}
+
+// isUnschedulable returns true when the scheduler failed to place the Pod, the condition triggering a cluster-autoscaler scale up.
+func isUnschedulable(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse && condition.Reason == corev1.PodReasonUnschedulable {
+			return true
+		}
+	}
+
+	return false
+}
+
+// podRequests returns the resources requested by the Pod: the sum of the containers requests,
+// or the highest init container request if greater, plus the Pod overhead.
+func podRequests(pod *corev1.Pod) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+
+	for _, container := range pod.Spec.Containers {
+		for name, quantity := range container.Resources.Requests {
+			total := requests[name]
+			total.Add(quantity)
+			requests[name] = total
+		}
+	}
+
+	for _, container := range pod.Spec.InitContainers {
+		for name, quantity := range container.Resources.Requests {
+			if total, ok := requests[name]; !ok || quantity.Cmp(total) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+
+	for name, quantity := range pod.Spec.Overhead {
+		total := requests[name]
+		total.Add(quantity)
+		requests[name] = total
+	}
+
+	return requests
+}
+
+func formatRequests(requests corev1.ResourceList) string {
+	items := make([]string, 0, len(requests))
+
+	for name, quantity := range requests {
+		items = append(items, name.String()+"="+quantity.String())
+	}
+
+	sort.Strings(items)
+
+	return strings.Join(items, ",")
+}
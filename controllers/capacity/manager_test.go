@@ -0,0 +1,182 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package capacity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+func requirements(cpu, memory string) corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{Requests: corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}}
+}
+
+func unschedulablePod(name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "oil-production"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app", Resources: requirements("500m", "1Gi")}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{{
+				Type:   corev1.PodScheduled,
+				Status: corev1.ConditionFalse,
+				Reason: corev1.PodReasonUnschedulable,
+			}},
+		},
+	}
+}
+
+func TestPodRequests(t *testing.T) {
+	testCases := []struct {
+		name     string
+		spec     corev1.PodSpec
+		expected string
+	}{
+		{
+			name:     "no requests",
+			spec:     corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+			expected: "",
+		},
+		{
+			name: "containers are summed",
+			spec: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "app", Resources: requirements("500m", "1Gi")},
+				{Name: "sidecar", Resources: requirements("100m", "128Mi")},
+			}},
+			expected: "cpu=600m,memory=1152Mi",
+		},
+		{
+			name: "greater init container wins per resource",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "migrate", Resources: requirements("1", "256Mi")}},
+				Containers:     []corev1.Container{{Name: "app", Resources: requirements("500m", "1Gi")}},
+			},
+			expected: "cpu=1,memory=1Gi",
+		},
+		{
+			name: "overhead is added",
+			spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "app", Resources: requirements("500m", "1Gi")}},
+				Overhead: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("250m"),
+					corev1.ResourceMemory: resource.MustParse("120Mi"),
+				},
+			},
+			expected: "cpu=750m,memory=1144Mi",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, formatRequests(podRequests(&corev1.Pod{Spec: tc.spec})))
+		})
+	}
+}
+
+func TestIsUnschedulable(t *testing.T) {
+	testCases := []struct {
+		name       string
+		conditions []corev1.PodCondition
+		expected   bool
+	}{
+		{
+			name:     "no conditions",
+			expected: false,
+		},
+		{
+			name:       "scheduled",
+			conditions: []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionTrue}},
+			expected:   false,
+		},
+		{
+			name:       "scheduling gated",
+			conditions: []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonSchedulingGated}},
+			expected:   false,
+		},
+		{
+			name:       "unschedulable",
+			conditions: []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonUnschedulable}},
+			expected:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isUnschedulable(&corev1.Pod{Status: corev1.PodStatus{Conditions: tc.conditions}}))
+		})
+	}
+}
+
+func TestReconcileCoalescesHints(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, capsulev1beta2.AddToScheme(scheme))
+
+	tnt := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "oil"},
+		Status:     capsulev1beta2.TenantStatus{Namespaces: []string{"oil-production"}},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tnt, unschedulablePod("web-1")).Build()
+
+	r := &Manager{Client: c, Log: logr.Discard(), Hints: true}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: "oil"}}
+
+	result, err := r.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+
+	annotations := tenantAnnotations(t, c)
+	assert.Equal(t, "1", annotations[PendingPodsAnnotation])
+	assert.Equal(t, "1", annotations[UnschedulablePodsAnnotation])
+	assert.Equal(t, "cpu=500m,memory=1Gi", annotations[UnschedulableRequestsAnnotation])
+
+	// A Pod change within the interval is coalesced, requeueing the update at the interval end.
+	require.NoError(t, c.Create(context.Background(), unschedulablePod("web-2")))
+
+	result, err = r.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+	assert.Positive(t, result.RequeueAfter)
+	assert.LessOrEqual(t, result.RequeueAfter, hintsInterval)
+	assert.Equal(t, "1", tenantAnnotations(t, c)[UnschedulablePodsAnnotation])
+
+	// Once the interval is elapsed, the latest counts are published.
+	r.published.Store("oil", time.Now().Add(-hintsInterval))
+
+	result, err = r.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+	assert.Equal(t, "2", tenantAnnotations(t, c)[UnschedulablePodsAnnotation])
+	assert.Equal(t, "cpu=1,memory=2Gi", tenantAnnotations(t, c)[UnschedulableRequestsAnnotation])
+}
+
+func tenantAnnotations(t *testing.T, c client.Client) map[string]string {
+	t.Helper()
+
+	tnt := &capsulev1beta2.Tenant{}
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: "oil"}, tnt))
+
+	return tnt.GetAnnotations()
+}
@@ -0,0 +1,147 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package ingressquota
+
+import (
+	"context"
+	"slices"
+
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/metrics"
+	"github.com/projectcapsule/capsule/pkg/utils"
+	webhookutils "github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+// Manager keeps the number of Ingress and cert-manager Certificate resources in the Tenant status, exposing them
+// as metrics along with the quotas: only the creations and deletions change the counts, thus the updates are ignored,
+// without triggering the full Tenant reconciliation.
+type Manager struct {
+	Client client.Client
+	Log    logr.Logger
+}
+
+func (r *Manager) SetupWithManager(mgr ctrl.Manager) error {
+	createOrDelete := builder.WithPredicates(predicate.Funcs{
+		UpdateFunc: func(event.UpdateEvent) bool { return false },
+	})
+
+	controller := ctrl.NewControllerManagedBy(mgr).
+		Named("ingressquota").
+		For(&capsulev1beta2.Tenant{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, namespacesChanged()))).
+		Watches(&networkingv1.Ingress{}, handler.EnqueueRequestsFromMapFunc(r.enqueueNamespaceTenant), createOrDelete)
+	// cert-manager is optional: Certificate resources are counted only when installed.
+	if _, err := mgr.GetRESTMapper().RESTMapping(utils.CertificateGroupVersionKind.GroupKind(), utils.CertificateGroupVersionKind.Version); err == nil {
+		certificate := &unstructured.Unstructured{}
+		certificate.SetGroupVersionKind(utils.CertificateGroupVersionKind)
+
+		controller = controller.Watches(certificate, handler.EnqueueRequestsFromMapFunc(r.enqueueNamespaceTenant), createOrDelete)
+	}
+
+	return controller.Complete(r)
+}
+
+func (r *Manager) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.Log.WithValues("Tenant", request.Name)
+
+	tenant := &capsulev1beta2.Tenant{}
+	if err := r.Client.Get(ctx, request.NamespacedName, tenant); err != nil {
+		if apierrors.IsNotFound(err) {
+			for _, resource := range []string{"ingresses", "certificates"} {
+				metrics.TenantResourceUsage.DeleteLabelValues(request.Name, resource, "")
+				metrics.TenantResourceLimit.DeleteLabelValues(request.Name, resource, "")
+			}
+
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	ingresses, err := utils.CountTenantObjects(ctx, r.Client, tenant, utils.NewIngressList)
+	if err != nil {
+		log.Error(err, "cannot count Ingress resources")
+
+		return reconcile.Result{}, err
+	}
+
+	certificates, err := utils.CountTenantObjects(ctx, r.Client, tenant, utils.NewCertificateList)
+	if err != nil && !meta.IsNoMatchError(err) {
+		log.Error(err, "cannot count Certificate resources")
+
+		return reconcile.Result{}, err
+	}
+
+	metrics.TenantResourceUsage.WithLabelValues(tenant.Name, "ingresses", "").Set(float64(ingresses))
+	metrics.TenantResourceUsage.WithLabelValues(tenant.Name, "certificates", "").Set(float64(certificates))
+
+	if quota := tenant.Spec.IngressOptions.Quota; quota != nil {
+		metrics.TenantResourceLimit.WithLabelValues(tenant.Name, "ingresses", "").Set(float64(*quota))
+	}
+
+	if quota := tenant.Spec.IngressOptions.CertificateQuota; quota != nil {
+		metrics.TenantResourceLimit.WithLabelValues(tenant.Name, "certificates", "").Set(float64(*quota))
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		found := &capsulev1beta2.Tenant{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: tenant.GetName()}, found); err != nil {
+			return err
+		}
+
+		if found.Status.Ingresses == uint(ingresses) && found.Status.Certificates == uint(certificates) {
+			return nil
+		}
+
+		found.Status.Ingresses = uint(ingresses)
+		found.Status.Certificates = uint(certificates)
+
+		return r.Client.Status().Update(ctx, found, &client.SubResourceUpdateOptions{})
+	})
+	if err != nil {
+		log.Error(err, "cannot update Tenant Ingress and Certificate count")
+	}
+
+	return reconcile.Result{}, err
+}
+
+// enqueueNamespaceTenant maps a namespaced object to the Tenant owning its Namespace.
+func (r *Manager) enqueueNamespaceTenant(ctx context.Context, obj client.Object) []reconcile.Request {
+	tnt, err := webhookutils.TenantByStatusNamespace(ctx, r.Client, obj.GetNamespace())
+	if err != nil || tnt == nil || len(tnt.GetName()) == 0 {
+		return nil
+	}
+
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: tnt.GetName()}}}
+}
+
+// namespacesChanged accepts the Tenant updates changing its Namespaces, and thus the counted resources.
+func namespacesChanged() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldTnt, oldOk := e.ObjectOld.(*capsulev1beta2.Tenant)
+			newTnt, newOk := e.ObjectNew.(*capsulev1beta2.Tenant)
+
+			if !oldOk || !newOk {
+				return false
+			}
+
+			return !slices.Equal(oldTnt.Status.Namespaces, newTnt.Status.Namespaces)
+		},
+	}
+}
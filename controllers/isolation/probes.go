@@ -0,0 +1,170 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package isolation
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+const (
+	// ProbeLabel marks the resources created for the isolation verification, the value is the Tenant name.
+	ProbeLabel = "capsule.clastix.io/isolation-probe"
+
+	probeRoleLabel = "capsule.clastix.io/isolation-probe-role"
+	probePort      = 8080
+)
+
+func probeMeta(tenant, namespace, role string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		GenerateName: "capsule-isolation-" + role + "-",
+		Namespace:    namespace,
+		Labels: map[string]string{
+			ProbeLabel:     tenant,
+			probeRoleLabel: role,
+		},
+	}
+}
+
+// probeContainer returns a container compliant with the restricted Pod Security Standard,
+// with minimal resources to fit in the Tenant quotas.
+func probeContainer(image string, command ...string) corev1.Container {
+	resources := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("10m"),
+		corev1.ResourceMemory: resource.MustParse("16Mi"),
+	}
+
+	return corev1.Container{
+		Name:    "probe",
+		Image:   image,
+		Command: command,
+		Resources: corev1.ResourceRequirements{
+			Requests: resources,
+			Limits:   resources,
+		},
+		SecurityContext: &corev1.SecurityContext{
+			RunAsNonRoot:             ptr.To(true),
+			RunAsUser:                ptr.To[int64](65534),
+			AllowPrivilegeEscalation: ptr.To(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+			SeccompProfile: &corev1.SeccompProfile{
+				Type: corev1.SeccompProfileTypeRuntimeDefault,
+			},
+		},
+	}
+}
+
+func serverPod(tenant, namespace, image string) *corev1.Pod {
+	container := probeContainer(image, "sh", "-c", fmt.Sprintf("mkdir -p /tmp/www && echo ok > /tmp/www/index.html && exec httpd -f -p %d -h /tmp/www", probePort))
+	container.Ports = []corev1.ContainerPort{{ContainerPort: probePort, Protocol: corev1.ProtocolTCP}}
+	container.ReadinessProbe = &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(probePort)},
+		},
+		PeriodSeconds: 1,
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: probeMeta(tenant, namespace, "server"),
+		Spec: corev1.PodSpec{
+			Containers:                    []corev1.Container{container},
+			RestartPolicy:                 corev1.RestartPolicyNever,
+			AutomountServiceAccountToken:  ptr.To(false),
+			TerminationGracePeriodSeconds: ptr.To[int64](0),
+		},
+	}
+}
+
+func serverService(tenant string, pod *corev1.Pod) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: probeMeta(tenant, pod.GetNamespace(), "server"),
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{
+				ProbeLabel:     tenant,
+				probeRoleLabel: "server",
+			},
+			Ports: []corev1.ServicePort{{
+				Port:       probePort,
+				TargetPort: intstr.FromInt32(probePort),
+				Protocol:   corev1.ProtocolTCP,
+			}},
+		},
+	}
+}
+
+// clientPod returns a Pod trying to reach the given address, succeeding only if the connection is established:
+// otherwise, the wget error is reported as termination message.
+func clientPod(tenant, namespace, role, image, address string, timeoutSeconds int) *corev1.Pod {
+	container := probeContainer(image, "wget", "-q", "-O", "/dev/null", "-T", fmt.Sprintf("%d", timeoutSeconds), fmt.Sprintf("http://%s:%d", address, probePort))
+	container.TerminationMessagePolicy = corev1.TerminationMessageFallbackToLogsOnError
+
+	return &corev1.Pod{
+		ObjectMeta: probeMeta(tenant, namespace, role),
+		Spec: corev1.PodSpec{
+			Containers:                    []corev1.Container{container},
+			RestartPolicy:                 corev1.RestartPolicyNever,
+			AutomountServiceAccountToken:  ptr.To(false),
+			TerminationGracePeriodSeconds: ptr.To[int64](0),
+		},
+	}
+}
+
+type reachability int
+
+const (
+	reachable reachability = iota
+	unreachable
+	undetermined
+)
+
+// reachabilityOf classifies the outcome of a completed client probe Pod: only a connection timeout, or a refused
+// connection, proves the server is not reachable, while any other failure, such as a name resolution error,
+// or a missing command, leaves the reachability undetermined. The termination message is returned too.
+func reachabilityOf(pod *corev1.Pod) (reachability, string) {
+	if pod.Status.Phase == corev1.PodSucceeded {
+		return reachable, ""
+	}
+
+	var message string
+
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Terminated != nil {
+			message = strings.TrimSpace(status.State.Terminated.Message)
+		}
+	}
+
+	for _, reason := range []string{"timed out", "Connection refused"} {
+		if strings.Contains(message, reason) {
+			return unreachable, message
+		}
+	}
+
+	return undetermined, message
+}
+
+// probeResult compares the reachability of the address from the Namespace with the expected one.
+func probeResult(address, namespace string, actual reachability, message string, expectReachable bool) capsulev1beta2.IsolationProbe {
+	switch {
+	case actual == undetermined:
+		return capsulev1beta2.IsolationProbe{Result: capsulev1beta2.IsolationProbeError, Message: fmt.Sprintf("cannot determine whether %s is reachable from Namespace %s: %s", address, namespace, message)}
+	case actual == reachable && expectReachable:
+		return capsulev1beta2.IsolationProbe{Result: capsulev1beta2.IsolationProbePassed, Message: fmt.Sprintf("%s is reachable from Namespace %s", address, namespace)}
+	case actual == unreachable && !expectReachable:
+		return capsulev1beta2.IsolationProbe{Result: capsulev1beta2.IsolationProbePassed, Message: fmt.Sprintf("%s is not reachable from Namespace %s", address, namespace)}
+	case actual == reachable:
+		return capsulev1beta2.IsolationProbe{Result: capsulev1beta2.IsolationProbeFailed, Message: fmt.Sprintf("%s is reachable from Namespace %s outside of the Tenant", address, namespace)}
+	default:
+		return capsulev1beta2.IsolationProbe{Result: capsulev1beta2.IsolationProbeFailed, Message: fmt.Sprintf("%s is not reachable from Namespace %s: %s", address, namespace, message)}
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package isolation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+func completedPod(phase corev1.PodPhase, message string) *corev1.Pod {
+	return &corev1.Pod{
+		Status: corev1.PodStatus{
+			Phase: phase,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{Message: message},
+				},
+			}},
+		},
+	}
+}
+
+func TestReachabilityOf(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pod      *corev1.Pod
+		expected reachability
+	}{
+		{
+			name:     "succeeded",
+			pod:      completedPod(corev1.PodSucceeded, ""),
+			expected: reachable,
+		},
+		{
+			name:     "download timed out",
+			pod:      completedPod(corev1.PodFailed, "wget: download timed out\n"),
+			expected: unreachable,
+		},
+		{
+			name:     "connection timed out",
+			pod:      completedPod(corev1.PodFailed, "wget: can't connect to remote host (10.244.0.12): Connection timed out"),
+			expected: unreachable,
+		},
+		{
+			name:     "connection refused",
+			pod:      completedPod(corev1.PodFailed, "wget: can't connect to remote host (10.244.0.12): Connection refused"),
+			expected: unreachable,
+		},
+		{
+			name:     "name resolution failure",
+			pod:      completedPod(corev1.PodFailed, "wget: bad address 'capsule-isolation-server-x7k2p.oil-production.svc'"),
+			expected: undetermined,
+		},
+		{
+			name:     "missing command",
+			pod:      completedPod(corev1.PodFailed, `exec: "wget": executable file not found in $PATH`),
+			expected: undetermined,
+		},
+		{
+			name:     "no termination message",
+			pod:      &corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodFailed}},
+			expected: undetermined,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, _ := reachabilityOf(tc.pod)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestProbeResult(t *testing.T) {
+	testCases := []struct {
+		name            string
+		actual          reachability
+		expectReachable bool
+		expected        capsulev1beta2.IsolationProbeResult
+	}{
+		{"intra reachable", reachable, true, capsulev1beta2.IsolationProbePassed},
+		{"intra unreachable", unreachable, true, capsulev1beta2.IsolationProbeFailed},
+		{"intra undetermined", undetermined, true, capsulev1beta2.IsolationProbeError},
+		{"cross reachable", reachable, false, capsulev1beta2.IsolationProbeFailed},
+		{"cross unreachable", unreachable, false, capsulev1beta2.IsolationProbePassed},
+		{"cross undetermined", undetermined, false, capsulev1beta2.IsolationProbeError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, probeResult("10.244.0.12", "gas-production", tc.actual, "", tc.expectReachable).Result)
+		})
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package isolation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+// Verifier periodically checks the isolation of each Tenant by deploying short-lived probe Pods:
+// a server Pod in a Tenant Namespace must be reachable through its Service DNS name from another Namespace of the same Tenant,
+// and must not be reachable from a Namespace of another Tenant.
+// Results are recorded in the Tenant status.
+type Verifier struct {
+	Client   client.Client
+	Log      logr.Logger
+	Recorder record.EventRecorder
+	// Image used by the probe Pods, it must provide the busybox httpd and wget applets.
+	Image string
+	// Interval between two verifications of all the Tenants.
+	Interval time.Duration
+	// Timeout for the probe Pods to be ready or completed.
+	Timeout time.Duration
+	// Concurrency is the number of Tenants verified in parallel.
+	Concurrency int
+}
+
+func (v *Verifier) NeedLeaderElection() bool {
+	return true
+}
+
+func (v *Verifier) Start(ctx context.Context) error {
+	ticker := time.NewTicker(v.Interval)
+	defer ticker.Stop()
+
+	for {
+		v.verifyAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (v *Verifier) verifyAll(ctx context.Context) {
+	tntList := &capsulev1beta2.TenantList{}
+	if err := v.Client.List(ctx, tntList); err != nil {
+		v.Log.Error(err, "cannot list Tenants")
+
+		return
+	}
+
+	tenants := make([]capsulev1beta2.Tenant, 0, len(tntList.Items))
+
+	for _, tnt := range tntList.Items {
+		if len(tnt.Status.Namespaces) > 0 {
+			tenants = append(tenants, tnt)
+		}
+	}
+
+	group := new(errgroup.Group)
+	group.SetLimit(max(v.Concurrency, 1))
+
+	for i := range tenants {
+		// Probing the cross-Tenant traffic from the next Tenant, none when the Tenant is the only one.
+		var peer *capsulev1beta2.Tenant
+		if len(tenants) > 1 {
+			peer = &tenants[(i+1)%len(tenants)]
+		}
+
+		group.Go(func() error {
+			v.verifyTenant(ctx, &tenants[i], peer)
+
+			return nil
+		})
+	}
+
+	_ = group.Wait()
+}
+
+func (v *Verifier) verifyTenant(ctx context.Context, tnt, peer *capsulev1beta2.Tenant) {
+	// Removing leftovers of an interrupted verification before starting a new one.
+	if err := v.cleanup(ctx, tnt.GetName()); err != nil {
+		v.Log.Error(err, "cannot clean up isolation probes", "tenant", tnt.GetName())
+
+		return
+	}
+
+	status := v.verify(ctx, tnt, peer)
+
+	if err := v.cleanup(ctx, tnt.GetName()); err != nil {
+		v.Log.Error(err, "cannot clean up isolation probes", "tenant", tnt.GetName())
+	}
+
+	if err := v.updateStatus(ctx, tnt.GetName(), status); err != nil {
+		v.Log.Error(err, "cannot update isolation status", "tenant", tnt.GetName())
+
+		return
+	}
+
+	if status.IntraTenant.Result != capsulev1beta2.IsolationProbePassed && status.IntraTenant.Result != capsulev1beta2.IsolationProbeSkipped {
+		v.Recorder.Eventf(tnt, corev1.EventTypeWarning, "IsolationVerificationFailed", "Intra-Tenant probe: %s", status.IntraTenant.Message)
+	}
+
+	if status.CrossTenant.Result != capsulev1beta2.IsolationProbePassed && status.CrossTenant.Result != capsulev1beta2.IsolationProbeSkipped {
+		v.Recorder.Eventf(tnt, corev1.EventTypeWarning, "IsolationVerificationFailed", "Cross-Tenant probe: %s", status.CrossTenant.Message)
+	}
+}
+
+func (v *Verifier) verify(ctx context.Context, tnt, peer *capsulev1beta2.Tenant) (status capsulev1beta2.IsolationStatus) {
+	namespace := tnt.Status.Namespaces[0]
+
+	server := serverPod(tnt.GetName(), namespace, v.Image)
+	if err := v.Client.Create(ctx, server); err != nil {
+		return erroredStatus(fmt.Errorf("cannot create server probe: %w", err))
+	}
+
+	svc := serverService(tnt.GetName(), server)
+	if err := v.Client.Create(ctx, svc); err != nil {
+		return erroredStatus(fmt.Errorf("cannot create server probe Service: %w", err))
+	}
+
+	if err := v.waitForPod(ctx, server, func(pod *corev1.Pod) bool {
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+				return true
+			}
+		}
+
+		return false
+	}); err != nil {
+		return erroredStatus(fmt.Errorf("server probe is not ready: %w", err))
+	}
+
+	// Using a different Namespace of the Tenant, if any, to verify the Tenant NetworkPolicies allow the traffic across its Namespaces.
+	source := namespace
+	if len(tnt.Status.Namespaces) > 1 {
+		source = tnt.Status.Namespaces[1]
+	}
+
+	status.IntraTenant = v.probe(ctx, tnt.GetName(), source, "intra", fmt.Sprintf("%s.%s.svc", svc.GetName(), namespace), true)
+
+	// The Pod IP is used to rule out name resolution failures hiding a missing NetworkPolicy.
+	switch err := v.Client.Get(ctx, client.ObjectKeyFromObject(server), server); {
+	case err != nil:
+		status.CrossTenant = capsulev1beta2.IsolationProbe{Result: capsulev1beta2.IsolationProbeError, Message: err.Error()}
+	case peer == nil:
+		status.CrossTenant = capsulev1beta2.IsolationProbe{Result: capsulev1beta2.IsolationProbeSkipped, Message: "no other Tenant to probe from"}
+	default:
+		status.CrossTenant = v.probe(ctx, tnt.GetName(), peer.Status.Namespaces[0], "cross", server.Status.PodIP, false)
+	}
+
+	status.LastVerification = metav1.Now()
+
+	return status
+}
+
+// probe runs a client Pod in the given Namespace, comparing the reachability of the server with the expected one.
+func (v *Verifier) probe(ctx context.Context, tenant, namespace, role, address string, expectReachable bool) capsulev1beta2.IsolationProbe {
+	pod := clientPod(tenant, namespace, role, v.Image, address, int(v.Timeout.Seconds()/2))
+	if err := v.Client.Create(ctx, pod); err != nil {
+		return capsulev1beta2.IsolationProbe{Result: capsulev1beta2.IsolationProbeError, Message: fmt.Sprintf("cannot create probe in Namespace %s: %s", namespace, err.Error())}
+	}
+
+	if err := v.waitForPod(ctx, pod, func(pod *corev1.Pod) bool {
+		return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+	}); err != nil {
+		return capsulev1beta2.IsolationProbe{Result: capsulev1beta2.IsolationProbeError, Message: fmt.Sprintf("probe in Namespace %s did not complete: %s", namespace, err.Error())}
+	}
+
+	actual, message := reachabilityOf(pod)
+
+	return probeResult(address, namespace, actual, message, expectReachable)
+}
+
+func (v *Verifier) waitForPod(ctx context.Context, pod *corev1.Pod, condition func(pod *corev1.Pod) bool) error {
+	return wait.PollUntilContextTimeout(ctx, time.Second, v.Timeout, true, func(ctx context.Context) (bool, error) {
+		if err := v.Client.Get(ctx, client.ObjectKeyFromObject(pod), pod); err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		return condition(pod), nil
+	})
+}
+
+func (v *Verifier) cleanup(ctx context.Context, tenant string) error {
+	selector := client.MatchingLabels{ProbeLabel: tenant}
+
+	podList := &corev1.PodList{}
+	if err := v.Client.List(ctx, podList, selector); err != nil {
+		return err
+	}
+
+	for i := range podList.Items {
+		if err := v.Client.Delete(ctx, &podList.Items[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	svcList := &corev1.ServiceList{}
+	if err := v.Client.List(ctx, svcList, selector); err != nil {
+		return err
+	}
+
+	for i := range svcList.Items {
+		if err := v.Client.Delete(ctx, &svcList.Items[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *Verifier) updateStatus(ctx context.Context, name string, status capsulev1beta2.IsolationStatus) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		tnt := &capsulev1beta2.Tenant{}
+		if err := v.Client.Get(ctx, types.NamespacedName{Name: name}, tnt); err != nil {
+			return err
+		}
+
+		tnt.Status.Isolation = &status
+
+		return v.Client.Status().Update(ctx, tnt)
+	})
+}
+
+func erroredStatus(err error) capsulev1beta2.IsolationStatus {
+	probe := capsulev1beta2.IsolationProbe{
+		Result:  capsulev1beta2.IsolationProbeError,
+		Message: err.Error(),
+	}
+
+	return capsulev1beta2.IsolationStatus{
+		LastVerification: metav1.Now(),
+		IntraTenant:      probe,
+		CrossTenant:      probe,
+	}
+}
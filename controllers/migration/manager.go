@@ -0,0 +1,62 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package migration
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/projectcapsule/capsule/pkg/migration"
+)
+
+// Manager migrates the stored Tenant objects to the storage version of the CustomResourceDefinition at startup,
+// when objects stored with former versions could still exist.
+type Manager struct {
+	// Client must not be backed by a cache, since objects are listed in pages.
+	Client    client.Client
+	Log       logr.Logger
+	CRDName   string
+	BatchSize int64
+}
+
+func (m *Manager) NeedLeaderElection() bool {
+	return true
+}
+
+func (m *Manager) Start(ctx context.Context) error {
+	migrator := &migration.Migrator{
+		Client:    m.Client,
+		CRDName:   m.CRDName,
+		BatchSize: m.BatchSize,
+		Progress: func(migrated int, remaining *int64) {
+			if remaining != nil {
+				m.Log.Info("migrating objects to the storage version", "migrated", migrated, "remaining", *remaining)
+
+				return
+			}
+
+			m.Log.Info("migrating objects to the storage version", "migrated", migrated)
+		},
+	}
+
+	result, err := migrator.Migrate(ctx)
+	if err != nil {
+		// The migration is not blocking: objects are still served by the API server, and it will be retried at the next start.
+		m.Log.Error(err, "cannot migrate objects to the storage version", "crd", m.CRDName)
+
+		return nil
+	}
+
+	if len(result.MigratedVersions) == 0 {
+		m.Log.Info("no storage version migration required", "crd", m.CRDName, "version", result.StorageVersion)
+
+		return nil
+	}
+
+	m.Log.Info("storage version migration completed", "crd", m.CRDName, "version", result.StorageVersion, "from", result.MigratedVersions, "migrated", result.Migrated)
+
+	return nil
+}
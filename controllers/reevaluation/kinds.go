@@ -0,0 +1,90 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package reevaluation
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/projectcapsule/capsule/pkg/configuration"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/ingress"
+	"github.com/projectcapsule/capsule/pkg/webhook/pod"
+	"github.com/projectcapsule/capsule/pkg/webhook/pvc"
+	"github.com/projectcapsule/capsule/pkg/webhook/service"
+	"github.com/projectcapsule/capsule/pkg/webhook/workload"
+)
+
+// Kind is a kind of objects re-evaluated against the policies enforced by the given admission handlers.
+type Kind struct {
+	GroupVersionKind schema.GroupVersionKind
+	Resource         metav1.GroupVersionResource
+	NewList          func() client.ObjectList
+	Handlers         []capsulewebhook.Handler
+}
+
+// DefaultKinds returns the kinds subject to the Tenant policies not depending on the other objects,
+// such as the quotas or the hostname collisions, which cannot be violated by the existing objects alone.
+func DefaultKinds(cfg configuration.Configuration, kubeVersion *version.Version) []Kind {
+	workloadKind := func(gvk schema.GroupVersionKind, resource string, newList func() client.ObjectList) Kind {
+		return Kind{
+			GroupVersionKind: gvk,
+			Resource:         metav1.GroupVersionResource{Group: gvk.Group, Version: gvk.Version, Resource: resource},
+			NewList:          newList,
+			Handlers:         []capsulewebhook.Handler{workload.HygieneHandler()},
+		}
+	}
+
+	return []Kind{
+		{
+			GroupVersionKind: corev1.SchemeGroupVersion.WithKind("Pod"),
+			Resource:         metav1.GroupVersionResource{Version: "v1", Resource: "pods"},
+			NewList:          func() client.ObjectList { return &corev1.PodList{} },
+			Handlers:         []capsulewebhook.Handler{pod.ImagePullPolicy(), pod.ContainerRegistry(), pod.PriorityClass(), pod.RuntimeClass(), pod.SecretsStore(), workload.HygieneHandler()},
+		},
+		{
+			GroupVersionKind: corev1.SchemeGroupVersion.WithKind("Service"),
+			Resource:         metav1.GroupVersionResource{Version: "v1", Resource: "services"},
+			NewList:          func() client.ObjectList { return &corev1.ServiceList{} },
+			Handlers:         []capsulewebhook.Handler{service.Handler()},
+		},
+		{
+			GroupVersionKind: corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"),
+			Resource:         metav1.GroupVersionResource{Version: "v1", Resource: "persistentvolumeclaims"},
+			NewList:          func() client.ObjectList { return &corev1.PersistentVolumeClaimList{} },
+			Handlers:         []capsulewebhook.Handler{pvc.Validating()},
+		},
+		{
+			GroupVersionKind: networkingv1.SchemeGroupVersion.WithKind("Ingress"),
+			Resource:         metav1.GroupVersionResource{Group: networkingv1.GroupName, Version: "v1", Resource: "ingresses"},
+			NewList:          func() client.ObjectList { return &networkingv1.IngressList{} },
+			Handlers:         []capsulewebhook.Handler{ingress.Class(cfg, kubeVersion), ingress.Hostnames(cfg), ingress.Wildcard()},
+		},
+		workloadKind(appsv1.SchemeGroupVersion.WithKind("Deployment"), "deployments", func() client.ObjectList { return &appsv1.DeploymentList{} }),
+		workloadKind(appsv1.SchemeGroupVersion.WithKind("StatefulSet"), "statefulsets", func() client.ObjectList { return &appsv1.StatefulSetList{} }),
+		workloadKind(appsv1.SchemeGroupVersion.WithKind("DaemonSet"), "daemonsets", func() client.ObjectList { return &appsv1.DaemonSetList{} }),
+		workloadKind(batchv1.SchemeGroupVersion.WithKind("Job"), "jobs", func() client.ObjectList { return &batchv1.JobList{} }),
+		workloadKind(batchv1.SchemeGroupVersion.WithKind("CronJob"), "cronjobs", func() client.ObjectList { return &batchv1.CronJobList{} }),
+	}
+}
+
+// discardRecorder drops the Events of the admission handlers, emitted on the Tenant for the denied requests.
+type discardRecorder struct{}
+
+func (discardRecorder) Event(runtime.Object, string, string, string) {}
+
+func (discardRecorder) Eventf(runtime.Object, string, string, string, ...interface{}) {}
+
+func (discardRecorder) AnnotatedEventf(runtime.Object, map[string]string, string, string, string, ...interface{}) {
+}
+
+var _ record.EventRecorder = discardRecorder{}
@@ -0,0 +1,248 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package reevaluation
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"sort"
+
+	"github.com/go-logr/logr"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/metrics"
+	"github.com/projectcapsule/capsule/pkg/policy"
+)
+
+// warningCode extracts the policy code prefixing the warnings, such as the workload hygiene ones.
+var warningCode = regexp.MustCompile(`^\[(CAPS-[A-Z]+-[0-9]+)\] `)
+
+// Manager re-evaluates the existing objects of a Tenant against its policies, upon the changes of its specification:
+// the admission enforcement only applies to the objects created or updated after a policy change, leaving the
+// pre-existing violations invisible. The objects are replayed as creation requests through the same admission
+// handlers enforcing the policies, and the violations are reported in the Tenant status and metrics.
+type Manager struct {
+	Client client.Client
+	// Reader lists the evaluated objects, which are not required to be cached by Capsule.
+	Reader   client.Reader
+	Decoder  admission.Decoder
+	Log      logr.Logger
+	Recorder record.EventRecorder
+	// Events enables the Events on the offending objects.
+	Events bool
+	Kinds  []Kind
+}
+
+func (r *Manager) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("reevaluation").
+		For(&capsulev1beta2.Tenant{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Re-evaluations are performed in background, one Tenant at a time, since all the Tenant objects are listed.
+		WithOptions(controller.Options{MaxConcurrentReconciles: 1}).
+		Complete(r)
+}
+
+func (r *Manager) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.Log.WithValues("Tenant", request.Name)
+
+	tnt := &capsulev1beta2.Tenant{}
+	if err := r.Client.Get(ctx, request.NamespacedName, tnt); err != nil {
+		if apierrors.IsNotFound(err) {
+			metrics.TenantPolicyViolations.DeletePartialMatch(map[string]string{"tenant": request.Name})
+
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+	// The current generation has already been evaluated, such as before a restart: only the metrics are restored.
+	if status := tnt.Status.Violations; status != nil && status.TenantGeneration == tnt.GetGeneration() {
+		r.setMetrics(tnt.GetName(), status.Items, status.Count)
+
+		return reconcile.Result{}, nil
+	}
+
+	log.Info("re-evaluating the Tenant objects against the policies")
+
+	var violations []capsulev1beta2.TenantViolation
+
+	for _, ns := range tnt.GetNamespaces() {
+		for _, kind := range r.Kinds {
+			found, err := r.evaluate(ctx, ns, kind)
+			if err != nil {
+				log.Error(err, "cannot re-evaluate the Tenant objects", "namespace", ns, "kind", kind.GroupVersionKind.Kind)
+
+				return reconcile.Result{}, err
+			}
+
+			violations = append(violations, found...)
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].Namespace != violations[j].Namespace {
+			return violations[i].Namespace < violations[j].Namespace
+		}
+
+		if violations[i].Kind != violations[j].Kind {
+			return violations[i].Kind < violations[j].Kind
+		}
+
+		return violations[i].Name < violations[j].Name
+	})
+
+	r.setMetrics(tnt.GetName(), violations, len(violations))
+
+	status := &capsulev1beta2.TenantViolations{
+		LastEvaluation:   metav1.Now(),
+		TenantGeneration: tnt.GetGeneration(),
+		Count:            len(violations),
+		Items:            violations,
+	}
+
+	if len(status.Items) > capsulev1beta2.MaxTenantViolations {
+		status.Items = status.Items[:capsulev1beta2.MaxTenantViolations]
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		found := &capsulev1beta2.Tenant{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: tnt.GetName()}, found); err != nil {
+			return err
+		}
+
+		found.Status.Violations = status
+
+		return r.Client.Status().Update(ctx, found, &client.SubResourceUpdateOptions{})
+	})
+	if err != nil {
+		log.Error(err, "cannot update the Tenant violations")
+	}
+
+	return reconcile.Result{}, err
+}
+
+// evaluate replays the objects of the given kind in the Namespace through the admission handlers.
+func (r *Manager) evaluate(ctx context.Context, namespace string, kind Kind) (violations []capsulev1beta2.TenantViolation, err error) {
+	list := kind.NewList()
+	if err = r.Reader.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok || obj.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		raw, mErr := json.Marshal(obj)
+		if mErr != nil {
+			return nil, mErr
+		}
+
+		req := admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				UID:       obj.GetUID(),
+				Kind:      metav1.GroupVersionKind{Group: kind.GroupVersionKind.Group, Version: kind.GroupVersionKind.Version, Kind: kind.GroupVersionKind.Kind},
+				Resource:  kind.Resource,
+				Name:      obj.GetName(),
+				Namespace: obj.GetNamespace(),
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: raw},
+			},
+		}
+
+		for _, h := range kind.Handlers {
+			// The handlers Events are discarded, the offending objects are the subject of the re-evaluation ones.
+			response := h.OnCreate(r.Client, r.Decoder, discardRecorder{})(ctx, req)
+			if response == nil {
+				continue
+			}
+
+			for _, found := range violationsOf(*response) {
+				found.Kind = kind.GroupVersionKind.Kind
+				found.Namespace = obj.GetNamespace()
+				found.Name = obj.GetName()
+
+				violations = append(violations, found)
+
+				if r.Events {
+					policy.Eventf(r.Recorder, obj, policy.Code(found.Code), corev1.EventTypeWarning, "PolicyViolation", "%s %s/%s violates a policy of the Tenant: %s", found.Kind, found.Namespace, found.Name, found.Message)
+				}
+			}
+		}
+	}
+
+	return violations, nil
+}
+
+// violationsOf returns the violations reported by an admission response, either as denial or as warnings.
+func violationsOf(response admission.Response) (violations []capsulev1beta2.TenantViolation) {
+	if !response.Allowed {
+		violation := capsulev1beta2.TenantViolation{}
+
+		if code, ok := policy.CodeOf(response); ok {
+			violation.Code = string(code)
+		}
+
+		if response.Result != nil {
+			violation.Message = response.Result.Message
+		}
+
+		violations = append(violations, violation)
+	}
+
+	for _, warning := range response.Warnings {
+		violation := capsulev1beta2.TenantViolation{Message: warning}
+
+		if match := warningCode.FindStringSubmatch(warning); match != nil {
+			violation.Code = match[1]
+		}
+
+		violations = append(violations, violation)
+	}
+
+	return violations
+}
+
+// setMetrics publishes the number of violations per code: when only the retained items are known,
+// the violations exceeding them are accounted with no code.
+func (r *Manager) setMetrics(tenant string, violations []capsulev1beta2.TenantViolation, count int) {
+	metrics.TenantPolicyViolations.DeletePartialMatch(map[string]string{"tenant": tenant})
+
+	codes := map[string]int{}
+
+	for _, violation := range violations {
+		codes[violation.Code]++
+	}
+
+	if remaining := count - len(violations); remaining > 0 {
+		codes[""] += remaining
+	}
+
+	for code, c := range codes {
+		metrics.TenantPolicyViolations.WithLabelValues(tenant, code).Set(float64(c))
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+const (
+	changeReasonSpec      = "Tenant specification"
+	changeReasonBalancing = "Tenant scoped ResourceQuota balancing"
+)
+
+// changeLog collects the changes applied during a reconciliation, since resources are processed concurrently.
+type changeLog struct {
+	mu      sync.Mutex
+	changes []capsulev1beta2.TenantChange
+}
+
+// recordChange tracks the creation or the update of the given resource, to be stored in the Tenant status.
+func (r *Manager) recordChange(tnt *capsulev1beta2.Tenant, target client.Object, res controllerutil.OperationResult, reason string) {
+	if r.changes == nil {
+		return
+	}
+
+	var operation capsulev1beta2.TenantChangeOperation
+
+	switch res {
+	case controllerutil.OperationResultCreated:
+		operation = capsulev1beta2.TenantChangeCreated
+	case controllerutil.OperationResultUpdated:
+		operation = capsulev1beta2.TenantChangeUpdated
+	default:
+		return
+	}
+
+	change := capsulev1beta2.TenantChange{
+		Time:             metav1.Now(),
+		Namespace:        target.GetNamespace(),
+		Name:             target.GetName(),
+		Operation:        operation,
+		Reason:           reason,
+		TenantGeneration: tnt.GetGeneration(),
+	}
+	// The balancing is caused by the usage of the Tenant Namespaces, rather than by a user.
+	if reason == changeReasonSpec {
+		change.Author = tnt.GetAnnotations()[capsulev1beta2.TenantSpecAuthorAnnotation]
+	}
+
+	if gvk, err := apiutil.GVKForObject(target, r.Client.Scheme()); err == nil {
+		change.Kind = gvk.Kind
+	}
+	// Namespaces are the changed resource themselves.
+	if _, ok := target.(*corev1.Namespace); ok {
+		change.Namespace = target.GetName()
+	}
+
+	r.changes.mu.Lock()
+	defer r.changes.mu.Unlock()
+
+	r.changes.changes = append(r.changes.changes, change)
+}
+
+// flushChanges stores the changes collected during the reconciliation in the Tenant status.
+func (r *Manager) flushChanges(ctx context.Context, tnt *capsulev1beta2.Tenant) error {
+	if r.changes == nil {
+		return nil
+	}
+
+	r.changes.mu.Lock()
+	defer r.changes.mu.Unlock()
+
+	if len(r.changes.changes) == 0 {
+		return nil
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		found := &capsulev1beta2.Tenant{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: tnt.GetName()}, found); err != nil {
+			return err
+		}
+
+		found.RecordChanges(r.changes.changes...)
+
+		return r.Client.Status().Update(ctx, found, &client.SubResourceUpdateOptions{})
+	})
+	if err == nil {
+		r.changes.changes = nil
+	}
+
+	return err
+}
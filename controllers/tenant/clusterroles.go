@@ -0,0 +1,215 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/utils"
+)
+
+// TenantViewerRoleName returns the name of the ClusterRole, and of its ClusterRoleBinding,
+// granting the Tenant owners the read access to their own Tenant.
+func TenantViewerRoleName(tenant string) string {
+	return fmt.Sprintf("capsule-tenant-viewer-%s", tenant)
+}
+
+// TenantClusterAccessRoleName returns the name of the ClusterRole, and of its ClusterRoleBinding,
+// granting the Tenant owners the read access to the cluster scoped resources of the Tenant cluster access.
+func TenantClusterAccessRoleName(tenant string) string {
+	return fmt.Sprintf("capsule-tenant-cluster-access-%s", tenant)
+}
+
+// syncTenantViewer grants the Tenant owners the read access to their Tenant only, restricted by resource name:
+// the Tenant is cluster scoped, and the owners cannot be allowed to read the other Tenants definitions.
+func (r *Manager) syncTenantViewer(ctx context.Context, tenant *capsulev1beta2.Tenant) error {
+	return r.syncOwnersClusterRole(ctx, tenant, TenantViewerRoleName(tenant.GetName()), []rbacv1.PolicyRule{
+		{
+			APIGroups:     []string{capsulev1beta2.GroupVersion.Group},
+			Resources:     []string{"tenants"},
+			ResourceNames: []string{tenant.GetName()},
+			Verbs:         []string{"get", "list", "watch"},
+		},
+	})
+}
+
+// syncClusterAccess grants the Tenant owners the read access to the cluster scoped resources of the Tenant cluster access,
+// such as the nodes of the Tenant node pool: all the rules are restricted by resource name.
+func (r *Manager) syncClusterAccess(ctx context.Context, tenant *capsulev1beta2.Tenant) error {
+	name := TenantClusterAccessRoleName(tenant.GetName())
+
+	access := tenant.Spec.ClusterAccess
+	if access == nil {
+		for _, obj := range []client.Object{&rbacv1.ClusterRoleBinding{}, &rbacv1.ClusterRole{}} {
+			obj.SetName(name)
+
+			if err := r.Client.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	var rules []rbacv1.PolicyRule
+
+	if (access.Nodes || access.NodeMetrics) && len(tenant.Spec.NodeSelector) > 0 {
+		nodes, err := r.tenantNodes(ctx, tenant)
+		if err != nil {
+			return err
+		}
+
+		if len(nodes) > 0 && access.Nodes {
+			rules = append(rules, rbacv1.PolicyRule{
+				APIGroups:     []string{""},
+				Resources:     []string{"nodes"},
+				ResourceNames: nodes,
+				Verbs:         []string{"get", "list", "watch"},
+			})
+		}
+
+		if len(nodes) > 0 && access.NodeMetrics {
+			rules = append(rules, rbacv1.PolicyRule{
+				APIGroups:     []string{"metrics.k8s.io"},
+				Resources:     []string{"nodes"},
+				ResourceNames: nodes,
+				Verbs:         []string{"get", "list", "watch"},
+			})
+		}
+	}
+
+	for _, resource := range access.Resources {
+		// The access is granted by a ClusterRoleBinding: a namespaced resource would be readable in every Namespace.
+		clusterScoped, err := utils.IsClusterScopedResource(r.Client.RESTMapper(), resource.APIGroup, resource.Resource)
+		if err != nil && !meta.IsNoMatchError(err) {
+			return err
+		}
+
+		if !clusterScoped {
+			r.Recorder.Eventf(tenant, corev1.EventTypeWarning, "ClusterAccessSkipped", "The clusterAccess resource %s of the %q API group is not a known cluster scoped resource, the access is not granted", resource.Resource, resource.APIGroup)
+
+			continue
+		}
+
+		rules = append(rules, rbacv1.PolicyRule{
+			APIGroups:     []string{resource.APIGroup},
+			Resources:     []string{resource.Resource},
+			ResourceNames: resource.ResourceNames,
+			Verbs:         []string{"get", "list", "watch"},
+		})
+	}
+
+	return r.syncOwnersClusterRole(ctx, tenant, name, rules)
+}
+
+// tenantNodes returns the sorted names of the nodes selected by the Tenant node selector.
+func (r *Manager) tenantNodes(ctx context.Context, tenant *capsulev1beta2.Tenant) ([]string, error) {
+	nodeList := &corev1.NodeList{}
+	if err := r.Client.List(ctx, nodeList, client.MatchingLabels(tenant.Spec.NodeSelector)); err != nil {
+		return nil, err
+	}
+
+	nodes := make([]string, 0, len(nodeList.Items))
+
+	for _, node := range nodeList.Items {
+		nodes = append(nodes, node.GetName())
+	}
+
+	sort.Strings(nodes)
+
+	return nodes, nil
+}
+
+// syncOwnersClusterRole ensures the ClusterRole with the given rules, and its ClusterRoleBinding to the Tenant owners.
+func (r *Manager) syncOwnersClusterRole(ctx context.Context, tenant *capsulev1beta2.Tenant, name string, rules []rbacv1.PolicyRule) (err error) {
+	role := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+
+	var res controllerutil.OperationResult
+
+	err = retry.RetryOnConflict(retry.DefaultBackoff, func() (retryErr error) {
+		res, retryErr = controllerutil.CreateOrUpdate(ctx, r.Client, role, func() error {
+			role.Rules = rules
+
+			return controllerutil.SetControllerReference(tenant, role, r.Client.Scheme())
+		})
+
+		return retryErr
+	})
+
+	r.emitEvent(tenant, name, res, fmt.Sprintf("Ensuring ClusterRole %s", name), err)
+	r.recordChange(tenant, role, res, changeReasonSpec)
+
+	if err != nil {
+		return err
+	}
+
+	binding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultBackoff, func() (retryErr error) {
+		res, retryErr = controllerutil.CreateOrUpdate(ctx, r.Client, binding, func() error {
+			binding.RoleRef = rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     name,
+			}
+
+			binding.Subjects = make([]rbacv1.Subject, 0, len(tenant.Spec.Owners))
+
+			for _, owner := range tenant.Spec.Owners {
+				binding.Subjects = append(binding.Subjects, ownerSubject(owner))
+			}
+
+			return controllerutil.SetControllerReference(tenant, binding, r.Client.Scheme())
+		})
+
+		return retryErr
+	})
+
+	r.emitEvent(tenant, name, res, fmt.Sprintf("Ensuring ClusterRoleBinding %s", name), err)
+	r.recordChange(tenant, binding, res, changeReasonSpec)
+
+	return err
+}
+
+// enqueueNodeTenants enqueues the Tenants granting the access to their nodes, since the node pool members can change.
+func (r *Manager) enqueueNodeTenants(ctx context.Context, _ client.Object) (requests []reconcile.Request) {
+	tntList := &capsulev1beta2.TenantList{}
+	if err := r.Client.List(ctx, tntList); err != nil {
+		return nil
+	}
+
+	for _, tnt := range tntList.Items {
+		access := tnt.Spec.ClusterAccess
+
+		if access == nil || (!access.Nodes && !access.NodeMetrics) || len(tnt.Spec.NodeSelector) == 0 {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: tnt.GetName()}})
+	}
+
+	return requests
+}
@@ -0,0 +1,364 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/utils"
+)
+
+const ingressClassAnnotation = "kubernetes.io/ingress.class"
+
+// IngressNetworkPolicyName returns the name of the NetworkPolicy allowing the traffic
+// from the Ingress controllers to the Pods of the given Service.
+func IngressNetworkPolicyName(service string) string {
+	return fmt.Sprintf("capsule-ingress-%s", service)
+}
+
+// ingressBackend collects the Ingress controllers and the Service ports a Service is exposed with.
+type ingressBackend struct {
+	controllers map[int]struct{}
+	ports       map[string]networkingv1.ServiceBackendPort
+}
+
+// syncIngressNetworkPolicies ensures, in each Namespace handled by the Tenant, the NetworkPolicies allowing the traffic
+// from the Ingress controllers to the backends of the Ingress resources with an allowed IngressClass and hostname.
+func (r *Manager) syncIngressNetworkPolicies(ctx context.Context, tenant *capsulev1beta2.Tenant) error {
+	controllers := r.Configuration.IngressControllers()
+
+	group := new(errgroup.Group)
+
+	for _, ns := range tenant.Status.Namespaces {
+		namespace := ns
+
+		group.Go(func() error {
+			if !tenant.Spec.IngressOptions.GenerateNetworkPolicies || len(controllers) == 0 {
+				return r.pruningResourcesByLabel(ctx, namespace, utils.IngressNetworkPolicyLabel, nil, &networkingv1.NetworkPolicy{})
+			}
+
+			return r.syncIngressNetworkPolicy(ctx, tenant, namespace, controllers)
+		})
+	}
+
+	return group.Wait()
+}
+
+func (r *Manager) syncIngressNetworkPolicy(ctx context.Context, tenant *capsulev1beta2.Tenant, namespace string, controllers []capsulev1beta2.IngressControllerSpec) error {
+	backends, err := r.ingressBackends(ctx, tenant, namespace, controllers)
+	if err != nil {
+		return err
+	}
+
+	tenantLabel, err := utils.GetTypeLabel(&capsulev1beta2.Tenant{})
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(backends))
+
+	for name, backend := range backends {
+		service := &corev1.Service{}
+		if err = r.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, service); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+
+			return err
+		}
+		// Services without selector, such as the ExternalName ones, are not backed by the Tenant Pods.
+		if len(service.Spec.Selector) == 0 {
+			continue
+		}
+
+		ports := ingressPolicyPorts(service, backend.ports)
+		if len(ports) == 0 {
+			continue
+		}
+
+		keys = append(keys, name)
+
+		target := &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      IngressNetworkPolicyName(name),
+				Namespace: namespace,
+			},
+		}
+
+		labels := map[string]string{
+			tenantLabel:                     tenant.Name,
+			utils.IngressNetworkPolicyLabel: name,
+		}
+
+		spec := networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: service.Spec.Selector},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					From:  ingressPolicyPeers(controllers, backend.controllers),
+					Ports: ports,
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		}
+
+		var res controllerutil.OperationResult
+		res, err = r.writeNetworkPolicy(ctx, tenant, target, labels, spec)
+
+		r.emitEvent(tenant, target.GetNamespace(), res, fmt.Sprintf("Ensuring Ingress NetworkPolicy %s", target.GetName()), err)
+		r.recordChange(tenant, target, res, changeReasonSpec)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.pruningResourcesByLabel(ctx, namespace, utils.IngressNetworkPolicyLabel, keys, &networkingv1.NetworkPolicy{})
+}
+
+// ingressBackends returns, by Service name, the backends of the Ingress resources in the given Namespace using an
+// allowed IngressClass served by one of the Ingress controllers: only the rules with an allowed hostname are considered.
+func (r *Manager) ingressBackends(ctx context.Context, tenant *capsulev1beta2.Tenant, namespace string, controllers []capsulev1beta2.IngressControllerSpec) (map[string]*ingressBackend, error) {
+	ingressList := &networkingv1.IngressList{}
+	if err := r.Client.List(ctx, ingressList, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+
+	backends := map[string]*ingressBackend{}
+
+	add := func(controller int, backend *networkingv1.IngressBackend) {
+		if backend == nil || backend.Service == nil {
+			return
+		}
+
+		b, ok := backends[backend.Service.Name]
+		if !ok {
+			b = &ingressBackend{controllers: map[int]struct{}{}, ports: map[string]networkingv1.ServiceBackendPort{}}
+			backends[backend.Service.Name] = b
+		}
+
+		b.controllers[controller] = struct{}{}
+		b.ports[fmt.Sprintf("%s/%d", backend.Service.Port.Name, backend.Service.Port.Number)] = backend.Service.Port
+	}
+
+	for _, ingress := range ingressList.Items {
+		class := ingress.Spec.IngressClassName
+		if class == nil {
+			if v, ok := ingress.GetAnnotations()[ingressClassAnnotation]; ok {
+				class = &v
+			}
+		}
+
+		if class == nil {
+			continue
+		}
+
+		allowed, err := r.ingressClassAllowed(ctx, tenant, *class)
+		if err != nil {
+			return nil, err
+		}
+
+		if !allowed {
+			continue
+		}
+
+		for i, controller := range controllers {
+			if !containsString(controller.IngressClasses, *class) {
+				continue
+			}
+			// The default backend is not bound to any hostname, thus allowed only when hostnames are not restricted.
+			if tenant.Spec.IngressOptions.AllowedHostnames == nil {
+				add(i, ingress.Spec.DefaultBackend)
+			}
+
+			for _, rule := range ingress.Spec.Rules {
+				if rule.HTTP == nil {
+					continue
+				}
+
+				if hostnames := tenant.Spec.IngressOptions.AllowedHostnames; hostnames != nil && !hostnames.Match(rule.Host) {
+					continue
+				}
+
+				for _, path := range rule.HTTP.Paths {
+					add(i, &path.Backend)
+				}
+			}
+		}
+	}
+
+	return backends, nil
+}
+
+// ingressClassAllowed checks the IngressClass against the Tenant allowed IngressClasses, if any.
+func (r *Manager) ingressClassAllowed(ctx context.Context, tenant *capsulev1beta2.Tenant, class string) (bool, error) {
+	allowed := tenant.Spec.IngressOptions.AllowedClasses
+	if allowed == nil {
+		return true, nil
+	}
+
+	if allowed.MatchDefault(class) || allowed.Match(class) {
+		return true, nil
+	}
+
+	ingressClass := &networkingv1.IngressClass{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: class}, ingressClass); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return allowed.SelectorMatch(ingressClass), nil
+}
+
+// ingressPolicyPorts resolves the Service ports referenced by the Ingress backends to the Pod ports,
+// since the NetworkPolicies are applied to the traffic reaching the Pods.
+func ingressPolicyPorts(service *corev1.Service, backendPorts map[string]networkingv1.ServiceBackendPort) (ports []networkingv1.NetworkPolicyPort) {
+	seen := map[string]struct{}{}
+
+	for _, servicePort := range service.Spec.Ports {
+		referenced := false
+
+		for _, backendPort := range backendPorts {
+			if (backendPort.Name != "" && backendPort.Name == servicePort.Name) || (backendPort.Number != 0 && backendPort.Number == servicePort.Port) {
+				referenced = true
+
+				break
+			}
+		}
+
+		if !referenced {
+			continue
+		}
+
+		target := servicePort.TargetPort
+		if target.Type == intstr.Int && target.IntVal == 0 {
+			target = intstr.FromInt32(servicePort.Port)
+		}
+
+		protocol := servicePort.Protocol
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+
+		key := fmt.Sprintf("%s/%s", protocol, target.String())
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		seen[key] = struct{}{}
+
+		ports = append(ports, networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &target})
+	}
+
+	sort.SliceStable(ports, func(i, j int) bool {
+		return ports[i].Port.String() < ports[j].Port.String()
+	})
+
+	return ports
+}
+
+// ingressPolicyPeers returns the peers matching the Pods of the given Ingress controllers.
+func ingressPolicyPeers(controllers []capsulev1beta2.IngressControllerSpec, indexes map[int]struct{}) (peers []networkingv1.NetworkPolicyPeer) {
+	for i, controller := range controllers {
+		if _, ok := indexes[i]; !ok {
+			continue
+		}
+
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: controller.NamespaceSelector.DeepCopy(),
+			PodSelector:       controller.PodSelector.DeepCopy(),
+		})
+	}
+
+	return peers
+}
+
+func containsString(items []string, value string) bool {
+	for _, item := range items {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// enqueueIngressNetworkPolicyTenants enqueues the Tenants generating the Ingress NetworkPolicies,
+// since the Ingress controllers are defined in the CapsuleConfiguration.
+func (r *Manager) enqueueIngressNetworkPolicyTenants(ctx context.Context, _ client.Object) (requests []reconcile.Request) {
+	tntList := &capsulev1beta2.TenantList{}
+	if err := r.Client.List(ctx, tntList); err != nil {
+		return nil
+	}
+
+	for _, tnt := range tntList.Items {
+		if !tnt.Spec.IngressOptions.GenerateNetworkPolicies {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: tnt.GetName()}})
+	}
+
+	return requests
+}
+
+// enqueueServiceTenant maps a Service, or an Ingress, to the Tenant owning its Namespace, when generating the Ingress NetworkPolicies.
+func (r *Manager) enqueueServiceTenant(ctx context.Context, obj client.Object) (requests []reconcile.Request) {
+	tntList := &capsulev1beta2.TenantList{}
+	if err := r.Client.List(ctx, tntList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(".status.namespaces", obj.GetNamespace()),
+	}); err != nil {
+		return nil
+	}
+
+	for _, tnt := range tntList.Items {
+		if !tnt.Spec.IngressOptions.GenerateNetworkPolicies {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: tnt.GetName()}})
+	}
+
+	return requests
+}
+
+// serviceBackendChanged filters the Service updates not affecting the Ingress NetworkPolicies.
+func serviceBackendChanged() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldService, ok := e.ObjectOld.(*corev1.Service)
+			if !ok {
+				return false
+			}
+
+			newService, ok := e.ObjectNew.(*corev1.Service)
+			if !ok {
+				return false
+			}
+
+			return !equality.Semantic.DeepEqual(oldService.Spec.Selector, newService.Spec.Selector) ||
+				!equality.Semantic.DeepEqual(oldService.Spec.Ports, newService.Spec.Ports)
+		},
+	}
+}
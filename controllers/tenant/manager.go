@@ -10,16 +10,23 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/controllers/capacity"
+	controllerutils "github.com/projectcapsule/capsule/controllers/utils"
+	"github.com/projectcapsule/capsule/pkg/configuration"
 	"github.com/projectcapsule/capsule/pkg/metrics"
 )
 
@@ -28,16 +35,33 @@ type Manager struct {
 	Log        logr.Logger
 	Recorder   record.EventRecorder
 	RESTConfig *rest.Config
+	// Configuration provides the Ingress controllers the Ingress NetworkPolicies are generated for.
+	Configuration configuration.Configuration
+	// changes collects the changes applied during a reconciliation, set on each Reconcile call.
+	changes *changeLog
 }
 
 func (r *Manager) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&capsulev1beta2.Tenant{}).
+		For(&capsulev1beta2.Tenant{}, builder.WithPredicates(controllerutils.AnnotationsIgnoringPredicate(capacity.AnnotationsPrefix))).
 		Owns(&networkingv1.NetworkPolicy{}).
 		Owns(&corev1.LimitRange{}).
 		Owns(&corev1.ResourceQuota{}).
 		Owns(&rbacv1.RoleBinding{}).
+		Owns(&rbacv1.ClusterRole{}).
+		Owns(&rbacv1.ClusterRoleBinding{}).
 		Watches(&corev1.Namespace{}, handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &capsulev1beta2.Tenant{})).
+		Watches(&networkingv1.Ingress{}, handler.EnqueueRequestsFromMapFunc(r.enqueueServiceTenant), builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
+		Watches(&corev1.Service{}, handler.EnqueueRequestsFromMapFunc(r.enqueueServiceTenant), builder.WithPredicates(serviceBackendChanged())).
+		Watches(&capsulev1beta2.CapsuleConfiguration{}, handler.EnqueueRequestsFromMapFunc(r.enqueueIngressNetworkPolicyTenants)).
+		Watches(&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(r.enqueueNodeTenants), builder.WithPredicates(predicate.Funcs{
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				return !equality.Semantic.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+			},
+		})).
+		Watches(&corev1.ServiceAccount{}, handler.EnqueueRequestsFromMapFunc(r.enqueueNamespaceTenant), builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+			return object.GetName() == defaultServiceAccountName
+		}))).
 		Complete(r)
 }
 
@@ -61,6 +85,18 @@ func (r Manager) Reconcile(ctx context.Context, request ctrl.Request) (result ct
 
 		return
 	}
+	// Storing the applied changes, even when the reconciliation is failing halfway
+	r.changes = &changeLog{}
+
+	defer func() {
+		if changesErr := r.flushChanges(ctx, instance); changesErr != nil {
+			r.Log.Error(changesErr, "Cannot store Tenant changes")
+
+			if err == nil {
+				err = changesErr
+			}
+		}
+	}()
 	// Ensuring the Tenant Status
 	if err = r.updateTenantStatus(ctx, instance); err != nil {
 		r.Log.Error(err, "Cannot update Tenant status")
@@ -98,6 +134,14 @@ func (r Manager) Reconcile(ctx context.Context, request ctrl.Request) (result ct
 
 		return
 	}
+	// Ensuring the default ServiceAccount policy
+	r.Log.Info("Starting processing of default ServiceAccounts")
+
+	if err = r.syncDefaultServiceAccounts(ctx, instance); err != nil {
+		r.Log.Error(err, "Cannot sync default ServiceAccounts")
+
+		return
+	}
 	// Ensuring NetworkPolicy resources
 	r.Log.Info("Starting processing of Network Policies")
 
@@ -106,6 +150,14 @@ func (r Manager) Reconcile(ctx context.Context, request ctrl.Request) (result ct
 
 		return
 	}
+	// Ensuring the NetworkPolicy resources generated from the Ingress resources
+	r.Log.Info("Starting processing of Ingress Network Policies")
+
+	if err = r.syncIngressNetworkPolicies(ctx, instance); err != nil {
+		r.Log.Error(err, "Cannot sync Ingress NetworkPolicy items")
+
+		return
+	}
 	// Ensuring LimitRange resources
 	r.Log.Info("Starting processing of Limit Ranges", "items", len(instance.Spec.LimitRanges.Items))
 
@@ -130,6 +182,22 @@ func (r Manager) Reconcile(ctx context.Context, request ctrl.Request) (result ct
 
 		return
 	}
+	// Ensuring the Tenant read access for its owners
+	r.Log.Info("Ensuring Tenant viewer ClusterRole for Owners")
+
+	if err = r.syncTenantViewer(ctx, instance); err != nil {
+		r.Log.Error(err, "Cannot sync Tenant viewer ClusterRole")
+
+		return
+	}
+	// Ensuring the cluster scoped resources read access for its owners
+	r.Log.Info("Ensuring Tenant cluster access ClusterRole for Owners")
+
+	if err = r.syncClusterAccess(ctx, instance); err != nil {
+		r.Log.Error(err, "Cannot sync Tenant cluster access ClusterRole")
+
+		return
+	}
 	// Ensuring Namespace count
 	r.Log.Info("Ensuring Namespace count")
 
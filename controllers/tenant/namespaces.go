@@ -10,6 +10,7 @@ import (
 
 	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
@@ -146,6 +147,7 @@ func (r *Manager) syncNamespaceMetadata(ctx context.Context, namespace string, t
 	})
 
 	r.emitEvent(tnt, namespace, res, "Ensuring Namespace metadata", err)
+	r.recordChange(tnt, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}, res, changeReasonSpec)
 
 	return err
 }
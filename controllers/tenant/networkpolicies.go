@@ -11,9 +11,11 @@ import (
 	"golang.org/x/sync/errgroup"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/managedfields"
 	"github.com/projectcapsule/capsule/pkg/utils"
 )
 
@@ -62,23 +64,16 @@ func (r *Manager) syncNetworkPolicy(ctx context.Context, tenant *capsulev1beta2.
 			},
 		}
 
-		var res controllerutil.OperationResult
-		res, err = controllerutil.CreateOrUpdate(ctx, r.Client, target, func() (err error) {
-			labels := target.GetLabels()
-			if labels == nil {
-				labels = map[string]string{}
-			}
-
-			labels[tenantLabel] = tenant.Name
-			labels[networkPolicyLabel] = strconv.Itoa(i)
-
-			target.SetLabels(labels)
-			target.Spec = spec
+		labels := map[string]string{
+			tenantLabel:        tenant.Name,
+			networkPolicyLabel: strconv.Itoa(i),
+		}
 
-			return controllerutil.SetControllerReference(tenant, target, r.Client.Scheme())
-		})
+		var res controllerutil.OperationResult
+		res, err = r.writeNetworkPolicy(ctx, tenant, target, labels, spec)
 
 		r.emitEvent(tenant, target.GetNamespace(), res, fmt.Sprintf("Ensuring NetworkPolicy %s", target.GetName()), err)
+		r.recordChange(tenant, target, res, changeReasonSpec)
 
 		r.Log.Info("Network Policy sync result: "+string(res), "name", target.Name, "namespace", target.Namespace)
 
@@ -89,3 +84,62 @@ func (r *Manager) syncNetworkPolicy(ctx context.Context, tenant *capsulev1beta2.
 
 	return nil
 }
+
+// writeNetworkPolicy writes the NetworkPolicy with the Capsule field manager. When the Tenant protects the NetworkPolicies
+// at field level, the rules appended by the Tenant owners are kept after the generated ones, whose number is recorded in
+// an annotation: the rules lists are atomic, and cannot be shared with the owners by the field managers.
+func (r *Manager) writeNetworkPolicy(ctx context.Context, tenant *capsulev1beta2.Tenant, target *networkingv1.NetworkPolicy, labels map[string]string, spec networkingv1.NetworkPolicySpec) (controllerutil.OperationResult, error) {
+	return controllerutil.CreateOrUpdate(ctx, client.WithFieldOwner(r.Client, managedfields.Manager), target, func() error {
+		current := target.GetLabels()
+		if current == nil {
+			current = map[string]string{}
+		}
+
+		for k, v := range labels {
+			current[k] = v
+		}
+
+		target.SetLabels(current)
+
+		annotations := target.GetAnnotations()
+
+		if tenant.Spec.NetworkPolicies.FieldsProtection() {
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+
+			generated := utils.GeneratedRules{Ingress: len(spec.Ingress), Egress: len(spec.Egress)}
+			// The appended rules follow the generated ones recorded by the current annotation, overwritten afterwards.
+			spec = appendOwnerRules(spec, target)
+			annotations[utils.GeneratedRulesAnnotation] = generated.String()
+		} else {
+			delete(annotations, utils.GeneratedRulesAnnotation)
+		}
+
+		target.SetAnnotations(annotations)
+		target.Spec = spec
+
+		return controllerutil.SetControllerReference(tenant, target, r.Client.Scheme())
+	})
+}
+
+// appendOwnerRules appends to the generated rules the ones appended by the Tenant owners to the current NetworkPolicy,
+// following the generated rules it records: these are replaced, even when changed in the Tenant.
+func appendOwnerRules(generated networkingv1.NetworkPolicySpec, current *networkingv1.NetworkPolicy) networkingv1.NetworkPolicySpec {
+	spec := *generated.DeepCopy()
+
+	recorded, ok := utils.GetGeneratedRules(current)
+	if !ok {
+		return spec
+	}
+
+	if len(current.Spec.Ingress) > recorded.Ingress {
+		spec.Ingress = append(spec.Ingress, current.Spec.Ingress[recorded.Ingress:]...)
+	}
+
+	if len(current.Spec.Egress) > recorded.Egress {
+		spec.Egress = append(spec.Egress, current.Spec.Egress[recorded.Egress:]...)
+	}
+
+	return spec
+}
@@ -0,0 +1,47 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/projectcapsule/capsule/pkg/utils"
+)
+
+func ingressFrom(namespace string) networkingv1.NetworkPolicyIngressRule {
+	return networkingv1.NetworkPolicyIngressRule{
+		From: []networkingv1.NetworkPolicyPeer{{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": namespace}},
+		}},
+	}
+}
+
+func TestAppendOwnerRules(t *testing.T) {
+	generated := networkingv1.NetworkPolicySpec{
+		Ingress: []networkingv1.NetworkPolicyIngressRule{ingressFrom("oil-production"), ingressFrom("oil-development")},
+		Egress:  []networkingv1.NetworkPolicyEgressRule{{}},
+	}
+
+	current := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{utils.GeneratedRulesAnnotation: utils.GeneratedRules{Ingress: 1}.String()},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			Ingress: []networkingv1.NetworkPolicyIngressRule{ingressFrom("oil-production"), ingressFrom("monitoring")},
+		},
+	}
+	// The generated rules changed in the Tenant replace the recorded ones, keeping the rules appended by the owners.
+	spec := appendOwnerRules(generated, current)
+	assert.Equal(t, []networkingv1.NetworkPolicyIngressRule{ingressFrom("oil-production"), ingressFrom("oil-development"), ingressFrom("monitoring")}, spec.Ingress)
+	assert.Equal(t, generated.Egress, spec.Egress)
+	assert.Len(t, generated.Ingress, 2, "the generated rules must not be changed")
+	// The NetworkPolicies not recording the generated rules were protected as a whole, with no appended rules.
+	current.Annotations = nil
+
+	assert.Equal(t, generated, appendOwnerRules(generated, current))
+}
@@ -22,29 +22,30 @@ import (
 // ownerClusterRoleBindings generates a Capsule AdditionalRoleBinding object for the Owner dynamic clusterrole in order
 // to take advantage of the additional role binding feature.
 func (r *Manager) ownerClusterRoleBindings(owner capsulev1beta2.OwnerSpec, clusterRole string) api.AdditionalRoleBindingsSpec {
-	var subject rbacv1.Subject
+	return api.AdditionalRoleBindingsSpec{
+		ClusterRoleName: clusterRole,
+		Subjects: []rbacv1.Subject{
+			ownerSubject(owner),
+		},
+	}
+}
 
+// ownerSubject returns the RBAC subject of the given Tenant Owner.
+func ownerSubject(owner capsulev1beta2.OwnerSpec) rbacv1.Subject {
 	if owner.Kind == "ServiceAccount" {
 		splitName := strings.Split(owner.Name, ":")
 
-		subject = rbacv1.Subject{
+		return rbacv1.Subject{
 			Kind:      owner.Kind.String(),
 			Name:      splitName[len(splitName)-1],
 			Namespace: splitName[len(splitName)-2],
 		}
-	} else {
-		subject = rbacv1.Subject{
-			APIGroup: rbacv1.GroupName,
-			Kind:     owner.Kind.String(),
-			Name:     owner.Name,
-		}
 	}
 
-	return api.AdditionalRoleBindingsSpec{
-		ClusterRoleName: clusterRole,
-		Subjects: []rbacv1.Subject{
-			subject,
-		},
+	return rbacv1.Subject{
+		APIGroup: rbacv1.GroupName,
+		Kind:     owner.Kind.String(),
+		Name:     owner.Name,
 	}
 }
 
@@ -146,6 +147,7 @@ func (r *Manager) syncAdditionalRoleBinding(ctx context.Context, tenant *capsule
 		})
 
 		r.emitEvent(tenant, target.GetNamespace(), res, fmt.Sprintf("Ensuring RoleBinding %s", target.GetName()), err)
+		r.recordChange(tenant, target, res, changeReasonSpec)
 
 		if err != nil {
 			r.Log.Error(err, "Cannot sync RoleBinding")
@@ -0,0 +1,171 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+const (
+	defaultServiceAccountName = "default"
+	// managedPullSecretsAnnotation tracks the image pull Secrets added by Capsule to the default ServiceAccount,
+	// allowing their removal once dropped from the Tenant, without touching the ones added by other parties.
+	managedPullSecretsAnnotation = "capsule.clastix.io/managed-image-pull-secrets"
+)
+
+// syncDefaultServiceAccounts applies the Tenant policy to the default ServiceAccount of each Tenant Namespace.
+// The ServiceAccount is created asynchronously by the Kubernetes controller manager:
+// when missing, the Tenant is reconciled again upon its creation.
+func (r *Manager) syncDefaultServiceAccounts(ctx context.Context, tenant *capsulev1beta2.Tenant) (err error) {
+	var spec *api.DefaultServiceAccountSpec
+	if tenant.Spec.NamespaceOptions != nil {
+		spec = tenant.Spec.NamespaceOptions.DefaultServiceAccount
+	}
+
+	group := new(errgroup.Group)
+
+	for _, item := range tenant.Status.Namespaces {
+		namespace := item
+
+		group.Go(func() error {
+			return r.syncDefaultServiceAccount(ctx, namespace, spec)
+		})
+	}
+
+	if err = group.Wait(); err != nil {
+		err = fmt.Errorf("cannot sync default ServiceAccounts: %w", err)
+	}
+
+	return
+}
+
+func (r *Manager) syncDefaultServiceAccount(ctx context.Context, namespace string, spec *api.DefaultServiceAccountSpec) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		sa := &corev1.ServiceAccount{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: defaultServiceAccountName}, sa); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		if !applyDefaultServiceAccount(sa, spec) {
+			return nil
+		}
+
+		return r.Client.Update(ctx, sa)
+	})
+}
+
+// applyDefaultServiceAccount mutates the ServiceAccount according to the given policy, returning true if it has been changed.
+// With no policy, the previously managed image pull Secrets are removed, while the token automount is left untouched.
+func applyDefaultServiceAccount(sa *corev1.ServiceAccount, spec *api.DefaultServiceAccountSpec) (changed bool) {
+	desired := make(map[string]struct{})
+
+	if spec != nil {
+		for _, name := range spec.ImagePullSecrets {
+			desired[name] = struct{}{}
+		}
+	}
+
+	previous := make(map[string]struct{})
+
+	if value := sa.GetAnnotations()[managedPullSecretsAnnotation]; len(value) > 0 {
+		for _, name := range strings.Split(value, ",") {
+			previous[name] = struct{}{}
+		}
+	}
+
+	secrets := make([]corev1.LocalObjectReference, 0, len(sa.ImagePullSecrets)+len(desired))
+	present := make(map[string]struct{}, len(sa.ImagePullSecrets))
+
+	for _, secret := range sa.ImagePullSecrets {
+		_, managed := previous[secret.Name]
+		_, wanted := desired[secret.Name]
+
+		if managed && !wanted {
+			changed = true
+
+			continue
+		}
+
+		present[secret.Name] = struct{}{}
+		secrets = append(secrets, secret)
+	}
+
+	managed := make([]string, 0, len(desired))
+
+	for name := range desired {
+		managed = append(managed, name)
+
+		if _, ok := present[name]; ok {
+			continue
+		}
+
+		changed = true
+
+		secrets = append(secrets, corev1.LocalObjectReference{Name: name})
+	}
+
+	sort.Strings(managed)
+
+	sa.ImagePullSecrets = secrets
+
+	annotations := sa.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+
+	if value := strings.Join(managed, ","); annotations[managedPullSecretsAnnotation] != value {
+		changed = true
+
+		if len(value) == 0 {
+			delete(annotations, managedPullSecretsAnnotation)
+		} else {
+			annotations[managedPullSecretsAnnotation] = value
+		}
+
+		sa.SetAnnotations(annotations)
+	}
+
+	if spec != nil && spec.AutomountServiceAccountToken != nil {
+		if sa.AutomountServiceAccountToken == nil || *sa.AutomountServiceAccountToken != *spec.AutomountServiceAccountToken {
+			changed = true
+
+			automount := *spec.AutomountServiceAccountToken
+			sa.AutomountServiceAccountToken = &automount
+		}
+	}
+
+	return changed
+}
+
+// enqueueNamespaceTenant maps a namespaced object to the Tenant owning its Namespace.
+func (r *Manager) enqueueNamespaceTenant(ctx context.Context, obj client.Object) []reconcile.Request {
+	tntList := &capsulev1beta2.TenantList{}
+	if err := r.Client.List(ctx, tntList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(".status.namespaces", obj.GetNamespace()),
+	}); err != nil {
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(tntList.Items))
+
+	for _, tnt := range tntList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: tnt.GetName()}})
+	}
+
+	return requests
+}
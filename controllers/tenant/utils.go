@@ -26,6 +26,11 @@ func (r *Manager) pruningResources(ctx context.Context, ns string, keys []string
 		return
 	}
 
+	return r.pruningResourcesByLabel(ctx, ns, capsuleLabel, keys, obj)
+}
+
+// pruningResourcesByLabel removes the objects having the given label, and whose value is not one of the given keys.
+func (r *Manager) pruningResourcesByLabel(ctx context.Context, ns string, capsuleLabel string, keys []string, obj client.Object) (err error) {
 	selector := labels.NewSelector()
 
 	var exists *labels.Requirement
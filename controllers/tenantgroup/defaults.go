@@ -0,0 +1,119 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantgroup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+const tenantCRDName = "tenants.capsule.clastix.io"
+
+// SpecDefaults returns the values the API server sets on the empty Tenant specification fields,
+// applying the defaults of the installed Tenant CustomResourceDefinition schema.
+func SpecDefaults(ctx context.Context, reader client.Reader) (capsulev1beta2.TenantSpecDefaults, error) {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := reader.Get(ctx, types.NamespacedName{Name: tenantCRDName}, crd); err != nil {
+		return nil, err
+	}
+
+	return specDefaults(crd)
+}
+
+func specDefaults(crd *apiextensionsv1.CustomResourceDefinition) (capsulev1beta2.TenantSpecDefaults, error) {
+	var props *apiextensionsv1.JSONSchemaProps
+
+	for _, version := range crd.Spec.Versions {
+		if version.Name == capsulev1beta2.GroupVersion.Version && version.Schema != nil {
+			props = version.Schema.OpenAPIV3Schema
+		}
+	}
+
+	if props == nil {
+		return nil, fmt.Errorf("the %s schema is missing", capsulev1beta2.GroupVersion.Version)
+	}
+
+	specSchema, ok := props.Properties["spec"]
+	if !ok {
+		return nil, fmt.Errorf("the %s schema has no spec", capsulev1beta2.GroupVersion.Version)
+	}
+	// The empty specification, as sent by the clients, carries the non-pointer structs too.
+	encoded, err := json.Marshal(capsulev1beta2.TenantSpec{})
+	if err != nil {
+		return nil, err
+	}
+
+	spec := map[string]interface{}{}
+	if err = json.Unmarshal(encoded, &spec); err != nil {
+		return nil, err
+	}
+
+	if err = applyDefaults(spec, &specSchema); err != nil {
+		return nil, err
+	}
+
+	defaults := capsulev1beta2.TenantSpecDefaults{}
+
+	for field, value := range spec {
+		if defaults[field], err = json.Marshal(value); err != nil {
+			return nil, err
+		}
+	}
+
+	return defaults, nil
+}
+
+// applyDefaults sets the schema defaults on the missing fields of the value, as the API server defaulting does.
+func applyDefaults(value interface{}, schema *apiextensionsv1.JSONSchemaProps) error {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for name, prop := range schema.Properties {
+			if _, ok := value[name]; ok || prop.Default == nil {
+				continue
+			}
+
+			var defaulted interface{}
+			if err := json.Unmarshal(prop.Default.Raw, &defaulted); err != nil {
+				return err
+			}
+
+			value[name] = defaulted
+		}
+
+		for name, field := range value {
+			prop, ok := schema.Properties[name]
+
+			switch {
+			case ok:
+			case schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil:
+				prop = *schema.AdditionalProperties.Schema
+			default:
+				continue
+			}
+
+			if err := applyDefaults(field, &prop); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		if schema.Items == nil || schema.Items.Schema == nil {
+			return nil
+		}
+
+		for _, item := range value {
+			if err := applyDefaults(item, schema.Items.Schema); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantgroup
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func TestSpecDefaults(t *testing.T) {
+	raw, err := os.ReadFile("../../charts/capsule/crds/capsule.clastix.io_tenants.yaml")
+	require.NoError(t, err)
+
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	require.NoError(t, yaml.Unmarshal(raw, crd))
+
+	defaults, err := specDefaults(crd)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"hostnameCollisionScope":"Disabled"}`, string(defaults["ingressOptions"]))
+	assert.JSONEq(t, `{"scope":"Tenant"}`, string(defaults["resourceQuotas"]))
+	assert.JSONEq(t, `false`, string(defaults["preventDeletion"]))
+	assert.NotContains(t, defaults, "nodeSelector")
+}
@@ -0,0 +1,217 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantgroup
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+// GlobalTenantResourceName returns the name of the GlobalTenantResource replicating the templates of the TenantGroup.
+func GlobalTenantResourceName(group string) string {
+	return fmt.Sprintf("tenantgroup-%s", group)
+}
+
+// Manager replicates the templates of each TenantGroup with a GlobalTenantResource selecting the same Tenants,
+// and reports the member Tenants in the TenantGroup status.
+type Manager struct {
+	Client client.Client
+	Log    logr.Logger
+	// Defaults of the Tenant specification fields, see SpecDefaults.
+	Defaults capsulev1beta2.TenantSpecDefaults
+}
+
+func (r *Manager) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&capsulev1beta2.TenantGroup{}).
+		Owns(&capsulev1beta2.GlobalTenantResource{}).
+		Watches(
+			&capsulev1beta2.Tenant{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueGroups),
+			// The membership depends on the Tenant labels, and the overridden policies on the Tenant specification:
+			// the status, and the annotations, written by the other controllers are filtered out.
+			builder.WithPredicates(predicate.Or(predicate.LabelChangedPredicate{}, predicate.GenerationChangedPredicate{})),
+		).
+		Complete(r)
+}
+
+func (r *Manager) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.Log.WithValues("TenantGroup", request.Name)
+
+	group := &capsulev1beta2.TenantGroup{}
+	if err := r.Client.Get(ctx, request.NamespacedName, group); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("Request object not found, could have been deleted after reconcile request")
+
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	if err := r.syncTemplates(ctx, group); err != nil {
+		log.Error(err, "cannot sync the TenantGroup templates")
+
+		return reconcile.Result{}, err
+	}
+
+	if err := r.updateStatus(ctx, group); err != nil {
+		log.Error(err, "cannot update the TenantGroup status")
+
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, nil
+}
+
+func (r *Manager) syncTemplates(ctx context.Context, group *capsulev1beta2.TenantGroup) error {
+	gtr := &capsulev1beta2.GlobalTenantResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: GlobalTenantResourceName(group.GetName()),
+		},
+	}
+
+	if group.Spec.Templates == nil {
+		if err := r.Client.Delete(ctx, gtr); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		return nil
+	}
+
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		_, err := controllerutil.CreateOrUpdate(ctx, r.Client, gtr, func() error {
+			gtr.Spec.TenantSelector = group.Spec.TenantSelector
+			gtr.Spec.TenantResourceSpec = *group.Spec.Templates
+
+			return controllerutil.SetControllerReference(group, gtr, r.Client.Scheme())
+		})
+
+		return err
+	})
+}
+
+func (r *Manager) updateStatus(ctx context.Context, group *capsulev1beta2.TenantGroup) error {
+	tntList := &capsulev1beta2.TenantList{}
+	if err := r.Client.List(ctx, tntList); err != nil {
+		return err
+	}
+
+	groupList := &capsulev1beta2.TenantGroupList{}
+	if err := r.Client.List(ctx, groupList); err != nil {
+		return err
+	}
+
+	status := capsulev1beta2.TenantGroupStatus{}
+
+	for _, tnt := range tntList.Items {
+		selected, err := group.Selects(&tnt)
+		if err != nil {
+			return err
+		}
+
+		if !selected {
+			continue
+		}
+
+		status.Tenants = append(status.Tenants, tnt.GetName())
+
+		groups, err := memberOf(&tnt, groupList.Items)
+		if err != nil {
+			return err
+		}
+		// Applying the groups to a copy of the Tenant, in order to report the overridden policies.
+		overridden, _, err := capsulev1beta2.ApplyTenantGroups(tnt.DeepCopy(), groups, r.Defaults)
+		if err != nil {
+			return err
+		}
+
+		for _, field := range overridden[group.GetName()] {
+			status.Overridden = append(status.Overridden, fmt.Sprintf("%s:%s", tnt.GetName(), field))
+		}
+	}
+
+	sort.Strings(status.Tenants)
+	status.Size = uint(len(status.Tenants))
+
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		found := &capsulev1beta2.TenantGroup{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: group.GetName()}, found); err != nil {
+			return err
+		}
+
+		if equality.Semantic.DeepEqual(found.Status, status) {
+			return nil
+		}
+
+		found.Status = status
+
+		return r.Client.Status().Update(ctx, found, &client.SubResourceUpdateOptions{})
+	})
+}
+
+// enqueueGroups enqueues the TenantGroups selecting the Tenant, and the ones reporting it as a member,
+// since the Tenant could have joined, or left, them.
+func (r *Manager) enqueueGroups(ctx context.Context, obj client.Object) (requests []reconcile.Request) {
+	tnt, ok := obj.(*capsulev1beta2.Tenant)
+	if !ok {
+		return nil
+	}
+
+	groupList := &capsulev1beta2.TenantGroupList{}
+	if err := r.Client.List(ctx, groupList); err != nil {
+		return nil
+	}
+
+	for _, group := range groupList.Items {
+		selected, err := group.Selects(tnt)
+		if err != nil {
+			continue
+		}
+
+		if !selected && !slices.Contains(group.Status.Tenants, tnt.GetName()) {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: group.GetName()}})
+	}
+
+	return requests
+}
+
+// memberOf returns the TenantGroups the Tenant is member of.
+func memberOf(tnt *capsulev1beta2.Tenant, groups []capsulev1beta2.TenantGroup) ([]capsulev1beta2.TenantGroup, error) {
+	var members []capsulev1beta2.TenantGroup
+
+	for _, group := range groups {
+		selected, err := group.Selects(tnt)
+		if err != nil {
+			return nil, err
+		}
+
+		if selected {
+			members = append(members, group)
+		}
+	}
+
+	return members, nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantgroup
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+// Members sets the policies of the TenantGroups in the specification of their member Tenants,
+// removing them once the Tenant leaves the group, or the group is deleted.
+// The policies are persisted with an update of the Tenant specification: any other tool owning the Tenant
+// specification, such as a GitOps one, must ignore the fields tracked by the TenantGroupFieldsAnnotation.
+type Members struct {
+	Client client.Client
+	Log    logr.Logger
+	// Defaults of the Tenant specification fields, see SpecDefaults.
+	Defaults capsulev1beta2.TenantSpecDefaults
+}
+
+func (r *Members) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("tenantgroup-members").
+		// The status, and the annotations, written by the other controllers do not affect the applied policies.
+		For(&capsulev1beta2.Tenant{}, builder.WithPredicates(predicate.Or(predicate.LabelChangedPredicate{}, predicate.GenerationChangedPredicate{}))).
+		Watches(&capsulev1beta2.TenantGroup{}, handler.EnqueueRequestsFromMapFunc(r.enqueueTenants), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+func (r *Members) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.Log.WithValues("Tenant", request.Name)
+
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		tnt := &capsulev1beta2.Tenant{}
+		if err := r.Client.Get(ctx, request.NamespacedName, tnt); err != nil {
+			return err
+		}
+
+		groupList := &capsulev1beta2.TenantGroupList{}
+		if err := r.Client.List(ctx, groupList); err != nil {
+			return err
+		}
+
+		groups, err := memberOf(tnt, groupList.Items)
+		if err != nil {
+			return err
+		}
+
+		_, changed, err := capsulev1beta2.ApplyTenantGroups(tnt, groups, r.Defaults)
+		if err != nil || !changed {
+			return err
+		}
+
+		log.Info("applying the TenantGroup policies")
+
+		return r.Client.Update(ctx, tnt)
+	})
+	if apierrors.IsNotFound(err) {
+		return reconcile.Result{}, nil
+	}
+
+	if err != nil {
+		log.Error(err, "cannot apply the TenantGroup policies")
+	}
+
+	return reconcile.Result{}, err
+}
+
+// enqueueTenants enqueues all the Tenants, since the TenantGroup could have selected, or stopped selecting, any of them.
+func (r *Members) enqueueTenants(ctx context.Context, _ client.Object) (requests []reconcile.Request) {
+	tntList := &capsulev1beta2.TenantList{}
+	if err := r.Client.List(ctx, tntList); err != nil {
+		return nil
+	}
+
+	for _, tnt := range tntList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: tnt.GetName()}})
+	}
+
+	return requests
+}
@@ -0,0 +1,169 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantgroup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+// Notification is the JSON object delivered to the notification targets of the TenantGroups for each Tenant Warning Event.
+type Notification struct {
+	Tenant      string      `json:"tenant"`
+	TenantGroup string      `json:"tenantGroup"`
+	Reason      string      `json:"reason"`
+	Message     string      `json:"message"`
+	Count       int32       `json:"count"`
+	Time        metav1.Time `json:"time"`
+}
+
+// Notifications delivers the Warning Events of the Tenants to the notification targets of the TenantGroups they are member of.
+// The Events are delivered again when their count increases, while the ones recorded before the start of the controller
+// are skipped, since already delivered by the former leader.
+type Notifications struct {
+	Client     client.Client
+	Log        logr.Logger
+	Recorder   record.EventRecorder
+	HTTPClient *http.Client
+
+	since time.Time
+	// delivered tracks the count of the delivered Events, pruned upon their expiration.
+	mu        sync.Mutex
+	delivered map[types.NamespacedName]int32
+}
+
+func (r *Notifications) SetupWithManager(mgr ctrl.Manager) error {
+	r.since = time.Now()
+	r.delivered = map[types.NamespacedName]int32{}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("tenantgroup-notifications").
+		For(&corev1.Event{}, builder.WithPredicates(predicate.NewPredicateFuncs(isTenantWarning))).
+		Complete(r)
+}
+
+func isTenantWarning(obj client.Object) bool {
+	ev, ok := obj.(*corev1.Event)
+
+	return ok && ev.Type == corev1.EventTypeWarning && ev.InvolvedObject.Kind == "Tenant" && ev.InvolvedObject.APIVersion == capsulev1beta2.GroupVersion.String()
+}
+
+func (r *Notifications) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ev := &corev1.Event{}
+	if err := r.Client.Get(ctx, request.NamespacedName, ev); err != nil {
+		if apierrors.IsNotFound(err) {
+			delete(r.delivered, request.NamespacedName)
+
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	count := max(ev.Count, 1)
+	if r.delivered[request.NamespacedName] >= count || lastTime(ev).Time.Before(r.since) {
+		return reconcile.Result{}, nil
+	}
+
+	tnt := &capsulev1beta2.Tenant{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: ev.InvolvedObject.Name}, tnt); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+
+	groupList := &capsulev1beta2.TenantGroupList{}
+	if err := r.Client.List(ctx, groupList); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	groups, err := memberOf(tnt, groupList.Items)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	for i := range groups {
+		for _, target := range groups[i].Spec.Notifications {
+			if len(target.Reasons) > 0 && !slices.Contains(target.Reasons, ev.Reason) {
+				continue
+			}
+
+			if err = r.notify(ctx, target.URL, Notification{
+				Tenant:      tnt.GetName(),
+				TenantGroup: groups[i].GetName(),
+				Reason:      ev.Reason,
+				Message:     ev.Message,
+				Count:       count,
+				Time:        lastTime(ev),
+			}); err != nil {
+				// Not retrying, since the other targets would be notified again.
+				r.Log.Error(err, "cannot notify the TenantGroup target", "TenantGroup", groups[i].GetName(), "target", target.Name)
+				r.Recorder.Eventf(&groups[i], corev1.EventTypeWarning, "NotificationFailed", "Cannot notify target %s: %s", target.Name, err.Error())
+			}
+		}
+	}
+
+	r.delivered[request.NamespacedName] = count
+
+	return reconcile.Result{}, nil
+}
+
+func (r *Notifications) notify(ctx context.Context, url string, notification Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := r.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+// lastTime returns when the Event has been recorded for the last time.
+func lastTime(ev *corev1.Event) metav1.Time {
+	switch {
+	case !ev.LastTimestamp.IsZero():
+		return ev.LastTimestamp
+	case !ev.EventTime.IsZero():
+		return metav1.NewTime(ev.EventTime.Time)
+	default:
+		return ev.GetCreationTimestamp()
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantgroup
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+func TestNotificationsReconcile(t *testing.T) {
+	var received []Notification
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		notification := Notification{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&notification))
+
+		received = append(received, notification)
+	}))
+	defer server.Close()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, capsulev1beta2.AddToScheme(scheme))
+
+	tnt := &capsulev1beta2.Tenant{ObjectMeta: metav1.ObjectMeta{Name: "oil", Labels: map[string]string{"profile": "gold"}}}
+	group := &capsulev1beta2.TenantGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "gold"},
+		Spec: capsulev1beta2.TenantGroupSpec{
+			TenantSelector: metav1.LabelSelector{MatchLabels: map[string]string{"profile": "gold"}},
+			Notifications: []capsulev1beta2.TenantGroupNotificationTarget{
+				{Name: "all", URL: server.URL},
+				{Name: "isolation", URL: server.URL, Reasons: []string{"IsolationVerificationFailed"}},
+			},
+		},
+	}
+	ev := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "oil.17a", Namespace: "default"},
+		InvolvedObject: corev1.ObjectReference{APIVersion: capsulev1beta2.GroupVersion.String(), Kind: "Tenant", Name: "oil"},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "ForbiddenContainerRegistry",
+		Message:        "denied",
+		Count:          1,
+		LastTimestamp:  metav1.Now(),
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tnt, group, ev).Build()
+
+	r := &Notifications{
+		Client:     c,
+		Log:        logr.Discard(),
+		Recorder:   record.NewFakeRecorder(1),
+		HTTPClient: server.Client(),
+		since:      time.Now().Add(-time.Minute),
+		delivered:  map[types.NamespacedName]int32{},
+	}
+
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "oil.17a"}}
+
+	_, err := r.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+	// The isolation target is not interested in the Event reason.
+	require.Len(t, received, 1)
+	assert.Equal(t, Notification{Tenant: "oil", TenantGroup: "gold", Reason: "ForbiddenContainerRegistry", Message: "denied", Count: 1, Time: received[0].Time}, received[0])
+
+	_, err = r.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+	assert.Len(t, received, 1, "the Event has been delivered again")
+
+	ev.Count = 2
+	require.NoError(t, c.Update(context.Background(), ev))
+
+	_, err = r.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, received, 2)
+	assert.Equal(t, int32(2), received[1].Count)
+
+	require.NoError(t, c.Delete(context.Background(), ev))
+
+	_, err = r.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+	assert.Empty(t, r.delivered)
+}
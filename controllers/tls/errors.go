@@ -6,5 +6,5 @@ package tls
 type RunningInOutOfClusterModeError struct{}
 
 func (r RunningInOutOfClusterModeError) Error() string {
-	return "cannot retrieve the leader Pod, probably running in out of the cluster mode"
+	return "cannot retrieve the operator Pods, the Pod name and selector are not set or the Pod does not exist: probably running in out of the cluster mode"
 }
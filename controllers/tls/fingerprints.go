@@ -0,0 +1,79 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tls
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/projectcapsule/capsule/pkg/cert"
+)
+
+const (
+	// FingerprintsConfigMapName is the well-known ConfigMap, in the Capsule Namespace, publishing the fingerprints
+	// of the certificates served by Capsule, for the external automation pinning them.
+	FingerprintsConfigMapName = "capsule-certificate-fingerprints"
+
+	CAFingerprintKey          = "ca.sha256"
+	PreviousCAFingerprintKey  = "previous-ca.sha256"
+	TLSFingerprintKey         = "tls.sha256"
+	PreviousTLSFingerprintKey = "previous-tls.sha256"
+	TLSNotAfterKey            = "tls.notAfter"
+)
+
+// publishFingerprints updates the fingerprints ConfigMap with the certificates of the TLS Secret: when these replace
+// the served ones, it must be called before the Secret update, retaining the fingerprints of the served certificates
+// as the previous ones, allowing the pinning automation to trust both until the new certificates are served.
+// With no served certificates, the previous fingerprints are the ones formerly published, if changed.
+func (r Reconciler) publishFingerprints(ctx context.Context, certSecret *corev1.Secret, served map[string][]byte) error {
+	fingerprints, err := cert.GetFingerprints(certSecret.Data[corev1.ServiceAccountRootCAKey], certSecret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return err
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      FingerprintsConfigMapName,
+			Namespace: r.Namespace,
+		},
+	}
+
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+
+		previousCA, previousTLS := cm.Data[CAFingerprintKey], cm.Data[TLSFingerprintKey]
+
+		if served != nil {
+			// The served certificates may be missing, or not valid, thus not worth retaining.
+			previous, previousErr := cert.GetFingerprints(served[corev1.ServiceAccountRootCAKey], served[corev1.TLSCertKey])
+			if previousErr != nil {
+				previous = cert.Fingerprints{}
+			}
+
+			previousCA, previousTLS = previous.CA, previous.Certificate
+		}
+
+		if len(previousCA) > 0 && previousCA != fingerprints.CA {
+			cm.Data[PreviousCAFingerprintKey] = previousCA
+		}
+
+		if len(previousTLS) > 0 && previousTLS != fingerprints.Certificate {
+			cm.Data[PreviousTLSFingerprintKey] = previousTLS
+		}
+
+		cm.Data[CAFingerprintKey] = fingerprints.CA
+		cm.Data[TLSFingerprintKey] = fingerprints.Certificate
+		cm.Data[TLSNotAfterKey] = fingerprints.NotAfter.UTC().Format(time.RFC3339)
+
+		return nil
+	})
+
+	return err
+}
@@ -0,0 +1,76 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tls
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/projectcapsule/capsule/pkg/cert"
+)
+
+func newCertificates(t *testing.T) map[string][]byte {
+	t.Helper()
+
+	ca, err := cert.GenerateCertificateAuthority()
+	require.NoError(t, err)
+
+	caCrt, err := ca.CACertificatePem()
+	require.NoError(t, err)
+
+	crt, _, err := ca.GenerateCertificate(cert.NewCertOpts(time.Now().AddDate(1, 0, 0), "capsule-webhook-service.capsule-system.svc"))
+	require.NoError(t, err)
+
+	return map[string][]byte{corev1.ServiceAccountRootCAKey: caCrt.Bytes(), corev1.TLSCertKey: crt.Bytes()}
+}
+
+func TestPublishFingerprints(t *testing.T) {
+	r := Reconciler{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(), Log: logr.Discard(), Namespace: "capsule-system"}
+
+	published := func() map[string]string {
+		cm := &corev1.ConfigMap{}
+		require.NoError(t, r.Client.Get(context.Background(), types.NamespacedName{Namespace: "capsule-system", Name: FingerprintsConfigMapName}, cm))
+
+		return cm.Data
+	}
+
+	served, rotated := newCertificates(t), newCertificates(t)
+
+	servedFingerprints, err := cert.GetFingerprints(served[corev1.ServiceAccountRootCAKey], served[corev1.TLSCertKey])
+	require.NoError(t, err)
+
+	rotatedFingerprints, err := cert.GetFingerprints(rotated[corev1.ServiceAccountRootCAKey], rotated[corev1.TLSCertKey])
+	require.NoError(t, err)
+
+	// First generation: there are no served certificates to retain.
+	require.NoError(t, r.publishFingerprints(context.Background(), &corev1.Secret{Data: served}, map[string][]byte{}))
+	assert.Equal(t, servedFingerprints.CA, published()[CAFingerprintKey])
+	assert.Equal(t, servedFingerprints.Certificate, published()[TLSFingerprintKey])
+	assert.NotContains(t, published(), PreviousCAFingerprintKey)
+	assert.NotContains(t, published(), PreviousTLSFingerprintKey)
+
+	// A failed Secret update leaves the served certificates unchanged: the next rotation retains them as previous,
+	// rather than the never served certificates published in between.
+	require.NoError(t, r.publishFingerprints(context.Background(), &corev1.Secret{Data: newCertificates(t)}, served))
+	require.NoError(t, r.publishFingerprints(context.Background(), &corev1.Secret{Data: rotated}, served))
+	assert.Equal(t, rotatedFingerprints.CA, published()[CAFingerprintKey])
+	assert.Equal(t, rotatedFingerprints.Certificate, published()[TLSFingerprintKey])
+	assert.Equal(t, servedFingerprints.CA, published()[PreviousCAFingerprintKey])
+	assert.Equal(t, servedFingerprints.Certificate, published()[PreviousTLSFingerprintKey])
+
+	// Once the rotated certificates are served, the fingerprints are left unchanged.
+	require.NoError(t, r.publishFingerprints(context.Background(), &corev1.Secret{Data: rotated}, nil))
+	assert.Equal(t, rotatedFingerprints.CA, published()[CAFingerprintKey])
+	assert.Equal(t, servedFingerprints.CA, published()[PreviousCAFingerprintKey])
+	assert.Equal(t, servedFingerprints.Certificate, published()[PreviousTLSFingerprintKey])
+}
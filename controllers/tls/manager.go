@@ -6,7 +6,6 @@ package tls
 import (
 	"context"
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -16,6 +15,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
@@ -36,6 +36,7 @@ import (
 const (
 	certificateExpirationThreshold = 3 * 24 * time.Hour
 	certificateValidity            = 6 * 30 * 24 * time.Hour
+	fingerprintsRetryInterval      = 30 * time.Second
 	PodUpdateAnnotationName        = "capsule.clastix.io/updated"
 )
 
@@ -45,6 +46,11 @@ type Reconciler struct {
 	Scheme        *runtime.Scheme
 	Namespace     string
 	Configuration configuration.Configuration
+	// PodName is the name of the Pod running the operator, as injected by the Downward API:
+	// when empty, and no PodSelector is given, the operator is considered running out of the cluster.
+	PodName string
+	// PodSelector selects the operator Pods in the Namespace: when nil, the labels of the Pod named PodName are used.
+	PodSelector labels.Selector
 }
 
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -93,11 +99,20 @@ func (r Reconciler) ReconcileCertificates(ctx context.Context, certSecret *corev
 
 		caCrt, _ := ca.CACertificatePem()
 
+		served := certSecret.Data
+
 		certSecret.Data = map[string][]byte{
 			corev1.TLSCertKey:              crt.Bytes(),
 			corev1.TLSPrivateKeyKey:        key.Bytes(),
 			corev1.ServiceAccountRootCAKey: caCrt.Bytes(),
 		}
+		// The fingerprints of the new certificates are published before serving them,
+		// thus the pinning automation trusts them as soon as the Secret is updated.
+		if err = r.publishFingerprints(ctx, certSecret, served); err != nil {
+			r.Log.Error(err, "cannot publish the certificates fingerprints")
+
+			return err
+		}
 
 		t := &corev1.Secret{ObjectMeta: certSecret.ObjectMeta}
 
@@ -178,6 +193,15 @@ func (r Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.R
 	if err := r.ReconcileCertificates(ctx, certSecret); err != nil {
 		return reconcile.Result{}, err
 	}
+	// The fingerprints of the rotated certificates are already published: this restores the ConfigMap if changed,
+	// or publishes the certificates not generated by Capsule, and it is best-effort since these are already served.
+	r.Log.Info("Publishing certificates fingerprints")
+
+	if err := r.publishFingerprints(ctx, certSecret, nil); err != nil {
+		r.Log.Error(err, "cannot publish the certificates fingerprints, retrying")
+
+		return reconcile.Result{RequeueAfter: fingerprintsRetryInterval}, nil
+	}
 
 	certificate, err := cert.GetCertificateFromBytes(certSecret.Data[corev1.TLSCertKey])
 	if err != nil {
@@ -325,16 +349,28 @@ func (r Reconciler) updateOperatorPod(ctx context.Context, pod corev1.Pod) error
 }
 
 func (r Reconciler) getOperatorPods(ctx context.Context) (*corev1.PodList, error) {
-	hostname, _ := os.Hostname()
+	selector := r.PodSelector
 
-	leaderPod := &corev1.Pod{}
+	if selector == nil {
+		if len(r.PodName) == 0 {
+			return nil, RunningInOutOfClusterModeError{}
+		}
+
+		leaderPod := &corev1.Pod{}
+
+		if err := r.Client.Get(ctx, types.NamespacedName{Namespace: r.Namespace, Name: r.PodName}, leaderPod); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, RunningInOutOfClusterModeError{}
+			}
+
+			return nil, err
+		}
 
-	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: os.Getenv("NAMESPACE"), Name: hostname}, leaderPod); err != nil {
-		return nil, RunningInOutOfClusterModeError{}
+		selector = labels.SelectorFromSet(leaderPod.GetLabels())
 	}
 
 	podList := &corev1.PodList{}
-	if err := r.Client.List(ctx, podList, client.MatchingLabels(leaderPod.ObjectMeta.Labels)); err != nil {
+	if err := r.Client.List(ctx, podList, client.InNamespace(r.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
 		r.Log.Error(err, "cannot retrieve list of Capsule pods")
 
 		return nil, err
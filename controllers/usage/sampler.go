@@ -0,0 +1,115 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package usage
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/history"
+)
+
+// NamespacesResource is the usage of a Tenant reporting the count of its Namespaces.
+const NamespacesResource corev1.ResourceName = "namespaces"
+
+// Sampler periodically stores the usage of each Tenant in the history: the count of its Namespaces,
+// and the resources used by the ResourceQuotas of its Namespaces. The records exceeding the retention,
+// including the audit ones, are pruned at each sample.
+type Sampler struct {
+	Client client.Client
+	Log    logr.Logger
+	Store  history.Store
+	// Interval between two samples of all the Tenants.
+	Interval time.Duration
+	// Retention of the history records, disabling the pruning when zero.
+	Retention time.Duration
+}
+
+func (s *Sampler) NeedLeaderElection() bool {
+	return true
+}
+
+func (s *Sampler) Start(ctx context.Context) error {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		s.sampleAll(ctx)
+
+		if s.Retention > 0 {
+			if err := s.Store.Prune(ctx, time.Now().Add(-s.Retention)); err != nil {
+				s.Log.Error(err, "cannot prune the history")
+			}
+		}
+	}
+}
+
+func (s *Sampler) sampleAll(ctx context.Context) {
+	tntList := &capsulev1beta2.TenantList{}
+	if err := s.Client.List(ctx, tntList); err != nil {
+		s.Log.Error(err, "cannot list Tenants")
+
+		return
+	}
+
+	now := time.Now()
+
+	records := make([]history.Record, 0, len(tntList.Items))
+
+	for _, tnt := range tntList.Items {
+		usage, err := s.usage(ctx, &tnt)
+		if err != nil {
+			s.Log.Error(err, "cannot sample the Tenant usage", "tenant", tnt.GetName())
+
+			continue
+		}
+
+		records = append(records, history.Record{
+			Time:   now,
+			Kind:   history.KindUsage,
+			Tenant: tnt.GetName(),
+			Usage:  usage,
+		})
+	}
+
+	if err := s.Store.Append(ctx, records...); err != nil {
+		s.Log.Error(err, "cannot store the Tenants usage")
+	}
+}
+
+func (s *Sampler) usage(ctx context.Context, tnt *capsulev1beta2.Tenant) (corev1.ResourceList, error) {
+	usage := corev1.ResourceList{
+		NamespacesResource: *resource.NewQuantity(int64(len(tnt.Status.Namespaces)), resource.DecimalSI),
+	}
+
+	for _, ns := range tnt.Status.Namespaces {
+		rqList := &corev1.ResourceQuotaList{}
+		if err := s.Client.List(ctx, rqList, client.InNamespace(ns)); err != nil {
+			return nil, err
+		}
+
+		for _, rq := range rqList.Items {
+			for name, quantity := range rq.Status.Used {
+				total := usage[name]
+				total.Add(quantity)
+
+				usage[name] = total
+			}
+		}
+	}
+
+	return usage, nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// AnnotationsIgnoringPredicate filters out the update events changing only the annotations with the given prefixes,
+// such as the ones published by Capsule itself on the watched objects.
+func AnnotationsIgnoringPredicate(prefixes ...string) predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return true
+			}
+
+			oldObj, newObj := stripAnnotations(e.ObjectOld, prefixes), stripAnnotations(e.ObjectNew, prefixes)
+
+			return !equality.Semantic.DeepEqual(oldObj, newObj)
+		},
+	}
+}
+
+// stripAnnotations returns a copy of the object without the annotations with the given prefixes,
+// and without the metadata changed by any write.
+func stripAnnotations(obj client.Object, prefixes []string) client.Object {
+	stripped, _ := obj.DeepCopyObject().(client.Object)
+
+	annotations := stripped.GetAnnotations()
+
+	for key := range annotations {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(key, prefix) {
+				delete(annotations, key)
+			}
+		}
+	}
+
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+
+	stripped.SetAnnotations(annotations)
+	stripped.SetResourceVersion("")
+	stripped.SetManagedFields(nil)
+
+	return stripped
+}
@@ -0,0 +1,44 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+func TestAnnotationsIgnoringPredicate(t *testing.T) {
+	p := AnnotationsIgnoringPredicate("capacity.capsule.clastix.io/")
+
+	oldTnt := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "oil",
+			ResourceVersion: "1",
+			Annotations:     map[string]string{"capacity.capsule.clastix.io/pending-pods": "1"},
+		},
+	}
+
+	newTnt := oldTnt.DeepCopy()
+	newTnt.SetResourceVersion("2")
+	newTnt.Annotations["capacity.capsule.clastix.io/pending-pods"] = "2"
+	assert.False(t, p.Update(event.UpdateEvent{ObjectOld: oldTnt, ObjectNew: newTnt}))
+
+	delete(newTnt.Annotations, "capacity.capsule.clastix.io/pending-pods")
+	assert.False(t, p.Update(event.UpdateEvent{ObjectOld: oldTnt, ObjectNew: newTnt}))
+
+	newTnt.Annotations["quota.resources.capsule.clastix.io/foos.example.com_v1"] = "10"
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: oldTnt, ObjectNew: newTnt}))
+
+	newTnt = oldTnt.DeepCopy()
+	newTnt.Status.Size = 1
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: oldTnt, ObjectNew: newTnt}))
+
+	assert.True(t, p.Create(event.CreateEvent{Object: oldTnt}))
+	assert.True(t, p.Delete(event.DeleteEvent{Object: oldTnt}))
+}
@@ -0,0 +1,76 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookrules
+
+import (
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+// feature returns true if the Tenant defines a policy enforced by the given webhook.
+type feature func(tnt *capsulev1beta2.Tenant) bool
+
+// features maps the name of the webhooks which can be trimmed to the Tenant policies requiring them:
+// webhooks not listed here, such as the Namespace or Tenant ones, are always required.
+// When a new Tenant policy is enforced by one of these webhooks, it must be added here too: the tests fail
+// when the handlers served by these webhooks read a Tenant field not requiring them.
+//
+//nolint:gochecknoglobals
+var features = map[string]feature{
+	"pods.projectcapsule.dev": func(tnt *capsulev1beta2.Tenant) bool {
+		return len(tnt.Spec.ImagePullPolicies) > 0 ||
+			tnt.Spec.ContainerRegistries != nil ||
+			tnt.Spec.PriorityClasses != nil ||
+			tnt.Spec.RuntimeClasses != nil ||
+			tnt.Spec.SecretsStore != nil ||
+			(tnt.Spec.PodOptions != nil && tnt.Spec.PodOptions.MaxPodsPerNode != nil)
+	},
+	"services.projectcapsule.dev": func(tnt *capsulev1beta2.Tenant) bool {
+		return tnt.Spec.ServiceOptions != nil
+	},
+	"ingress.projectcapsule.dev": func(tnt *capsulev1beta2.Tenant) bool {
+		options := tnt.Spec.IngressOptions
+
+		return options.AllowedClasses != nil ||
+			options.AllowedHostnames != nil ||
+			(len(options.HostnameCollisionScope) > 0 && options.HostnameCollisionScope != api.HostnameCollisionScopeDisabled) ||
+			!options.AllowWildcardHostnames ||
+			options.Quota != nil
+	},
+	"networkpolicies.projectcapsule.dev": func(tnt *capsulev1beta2.Tenant) bool {
+		return len(tnt.Spec.NetworkPolicies.Items) > 0 || len(tnt.Spec.NetworkPolicies.Protection) > 0 || tnt.Spec.IngressOptions.GenerateNetworkPolicies
+	},
+	"certificates.projectcapsule.dev": func(tnt *capsulev1beta2.Tenant) bool {
+		return tnt.Spec.IngressOptions.CertificateQuota != nil
+	},
+	"secretproviderclasses.projectcapsule.dev": func(tnt *capsulev1beta2.Tenant) bool {
+		return tnt.Spec.SecretsStore != nil
+	},
+	"pod.defaults.projectcapsule.dev": func(tnt *capsulev1beta2.Tenant) bool {
+		return (tnt.Spec.PriorityClasses != nil && len(tnt.Spec.PriorityClasses.Default) > 0) ||
+			(tnt.Spec.RuntimeClasses != nil && len(tnt.Spec.RuntimeClasses.Default) > 0) ||
+			(tnt.Spec.PodOptions != nil && tnt.Spec.PodOptions.MaxPodsPerNode != nil)
+	},
+	"storage.defaults.projectcapsule.dev": func(tnt *capsulev1beta2.Tenant) bool {
+		return tnt.Spec.StorageClasses != nil && len(tnt.Spec.StorageClasses.Default) > 0
+	},
+	"ingress.defaults.projectcapsule.dev": func(tnt *capsulev1beta2.Tenant) bool {
+		return tnt.Spec.IngressOptions.AllowedClasses != nil && len(tnt.Spec.IngressOptions.AllowedClasses.Default) > 0
+	},
+}
+
+// requiredWebhooks returns the names of the trimmable webhooks required by at least one of the given Tenants.
+func requiredWebhooks(tenants []capsulev1beta2.Tenant) map[string]bool {
+	required := make(map[string]bool, len(features))
+
+	for i := range tenants {
+		for name, fn := range features {
+			if !required[name] && fn(&tenants[i]) {
+				required[name] = true
+			}
+		}
+	}
+
+	return required
+}
@@ -0,0 +1,365 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookrules
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+// handlerSources maps the trimmable webhooks to the sources of the handlers served by their routes.
+//
+//nolint:gochecknoglobals
+var handlerSources = map[string][]string{
+	"pods.projectcapsule.dev":                  {"pkg/webhook/pod/*.go"},
+	"services.projectcapsule.dev":              {"pkg/webhook/service/*.go"},
+	"ingress.projectcapsule.dev":               {"pkg/webhook/ingress/*.go"},
+	"networkpolicies.projectcapsule.dev":       {"pkg/webhook/networkpolicy/*.go"},
+	"certificates.projectcapsule.dev":          {"pkg/webhook/certificate/*.go"},
+	"secretproviderclasses.projectcapsule.dev": {"pkg/webhook/secretproviderclass/*.go"},
+	"pod.defaults.projectcapsule.dev":          {"pkg/webhook/defaults/pods.go"},
+	"storage.defaults.projectcapsule.dev":      {"pkg/webhook/defaults/storage.go"},
+	"ingress.defaults.projectcapsule.dev":      {"pkg/webhook/defaults/ingress.go"},
+}
+
+// baseline returns a Tenant requiring none of the trimmable webhooks.
+func baseline() *capsulev1beta2.Tenant {
+	tnt := &capsulev1beta2.Tenant{}
+	tnt.Spec.IngressOptions.AllowWildcardHostnames = true
+
+	return tnt
+}
+
+func TestFeaturesBaseline(t *testing.T) {
+	for name, fn := range features {
+		assert.False(t, fn(baseline()), "webhook %s is required by the baseline Tenant", name)
+	}
+}
+
+// TestFeaturesCoverHandlers fails when a handler served by a trimmable webhook reads a Tenant spec field
+// not enabling the webhook in the features map: the webhook would be trimmed while that field is set.
+func TestFeaturesCoverHandlers(t *testing.T) {
+	names := make([]string, 0, len(features))
+	for name := range features {
+		names = append(names, name)
+	}
+
+	sources := make([]string, 0, len(handlerSources))
+	for name := range handlerSources {
+		sources = append(sources, name)
+	}
+
+	sort.Strings(names)
+	sort.Strings(sources)
+	require.Equal(t, names, sources, "the handler sources of each trimmable webhook must be listed")
+
+	for name, patterns := range handlerSources {
+		reads := readSpecPaths(t, patterns)
+
+		for _, path := range reads {
+			for _, leaf := range leaves(reflect.TypeOf(capsulev1beta2.TenantSpec{}), path) {
+				tnt := baseline()
+				setLeaf(reflect.ValueOf(&tnt.Spec).Elem(), leaf)
+
+				assert.True(t, features[name](tnt), "webhook %s handlers read spec.%s, not enabling it when spec.%s is set", name, strings.Join(path, "."), strings.Join(leaf, "."))
+			}
+		}
+	}
+}
+
+// readSpecPaths returns the paths of the Tenant spec fields read by the given sources, following the local variables
+// and the parameters of the functions of the same package they are assigned to, ignoring the nil checks.
+func readSpecPaths(t *testing.T, patterns []string) [][]string {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	reader := &specReader{funcs: map[string]*ast.FuncDecl{}, reads: map[string][]string{}, visited: map[string]bool{}}
+
+	var files []*ast.File
+
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join("..", "..", pattern))
+		require.NoError(t, err)
+		require.NotEmpty(t, matches, "no sources matching %s", pattern)
+
+		for _, match := range matches {
+			if strings.HasSuffix(match, "_test.go") {
+				continue
+			}
+
+			file, err := parser.ParseFile(fset, match, nil, 0)
+			require.NoError(t, err)
+
+			files = append(files, file)
+		}
+	}
+	// The functions of the whole package are resolved, since the handlers may pass the fields to them.
+	packages, err := parser.ParseDir(fset, filepath.Dir(filepath.Join("..", "..", patterns[0])), nil, 0)
+	require.NoError(t, err)
+
+	for _, pkg := range packages {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				if fn, ok := decl.(*ast.FuncDecl); ok {
+					reader.funcs[fn.Name.Name] = fn
+				}
+			}
+		}
+	}
+
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Body != nil {
+				reader.walk(fn.Body, map[string][]string{})
+			}
+		}
+	}
+
+	paths := make([][]string, 0, len(reader.reads))
+	for _, path := range reader.reads {
+		paths = append(paths, path)
+	}
+
+	return paths
+}
+
+type specReader struct {
+	funcs   map[string]*ast.FuncDecl
+	reads   map[string][]string
+	visited map[string]bool
+}
+
+func (r *specReader) record(path []string) {
+	r.reads[strings.Join(path, ".")] = path
+}
+
+// resolve returns the Tenant spec path of the expression, truncated to the fields, if any.
+func (r *specReader) resolve(expr ast.Expr, aliases map[string][]string) ([]string, bool) {
+	var names []string
+
+	for {
+		switch e := expr.(type) {
+		case *ast.ParenExpr:
+			expr = e.X
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.SelectorExpr:
+			names = append([]string{e.Sel.Name}, names...)
+			expr = e.X
+		case *ast.Ident:
+			var path []string
+
+			switch {
+			case (e.Name == "tnt" || e.Name == "tenant") && len(names) > 0 && names[0] == "Spec":
+				names = names[1:]
+			case aliases[e.Name] != nil:
+				path = aliases[e.Name]
+			default:
+				return nil, false
+			}
+
+			return fieldsPrefix(append(append([]string{}, path...), names...)), true
+		default:
+			return nil, false
+		}
+	}
+}
+
+func (r *specReader) walk(node ast.Node, aliases map[string][]string) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) == 1 && len(n.Rhs) == 1 {
+				if ident, ok := n.Lhs[0].(*ast.Ident); ok {
+					if path, ok := r.resolve(n.Rhs[0], aliases); ok {
+						aliases[ident.Name] = path
+
+						return false
+					}
+				}
+			}
+		case *ast.BinaryExpr:
+			if n.Op != token.EQL && n.Op != token.NEQ {
+				return true
+			}
+
+			for _, operands := range [][2]ast.Expr{{n.X, n.Y}, {n.Y, n.X}} {
+				if ident, ok := operands[1].(*ast.Ident); ok && ident.Name == "nil" {
+					if _, ok := r.resolve(operands[0], aliases); ok {
+						return false
+					}
+				}
+			}
+		case *ast.CallExpr:
+			callee := r.callee(n.Fun)
+
+			for i, arg := range n.Args {
+				path, ok := r.resolve(arg, aliases)
+				if !ok {
+					r.walk(arg, aliases)
+
+					continue
+				}
+
+				if param := parameter(callee, i); len(param) > 0 {
+					key := callee.Name.Name + "." + param + "=" + strings.Join(path, ".")
+					if !r.visited[key] {
+						r.visited[key] = true
+						r.walk(callee.Body, map[string][]string{param: path})
+					}
+
+					continue
+				}
+
+				r.record(path)
+			}
+
+			r.walk(n.Fun, aliases)
+
+			return false
+		case *ast.SelectorExpr, *ast.Ident, *ast.StarExpr:
+			if path, ok := r.resolve(n.(ast.Expr), aliases); ok {
+				r.record(path)
+
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+// callee returns the function, or method, of the package invoked by the call, if any.
+func (r *specReader) callee(fun ast.Expr) *ast.FuncDecl {
+	switch f := fun.(type) {
+	case *ast.Ident:
+		return r.funcs[f.Name]
+	case *ast.SelectorExpr:
+		if fn := r.funcs[f.Sel.Name]; fn != nil && fn.Recv != nil {
+			return fn
+		}
+	}
+
+	return nil
+}
+
+// parameter returns the name of the i-th parameter of the function.
+func parameter(fn *ast.FuncDecl, i int) string {
+	if fn == nil || fn.Body == nil {
+		return ""
+	}
+
+	for _, field := range fn.Type.Params.List {
+		for _, name := range field.Names {
+			if i == 0 {
+				return name.Name
+			}
+
+			i--
+		}
+	}
+
+	return ""
+}
+
+func indirect(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	return typ
+}
+
+// fieldsPrefix truncates the path to the Tenant spec fields, dropping the methods and their results.
+func fieldsPrefix(path []string) []string {
+	typ := reflect.TypeOf(capsulev1beta2.TenantSpec{})
+
+	for i, name := range path {
+		typ = indirect(typ)
+		if typ.Kind() != reflect.Struct {
+			return path[:i]
+		}
+
+		field, ok := typ.FieldByName(name)
+		if !ok {
+			return path[:i]
+		}
+
+		typ = field.Type
+	}
+
+	return path
+}
+
+// leaves returns the paths of the fields under the given path which cannot be further traversed.
+func leaves(typ reflect.Type, path []string) (paths [][]string) {
+	for _, name := range path {
+		field, _ := indirect(typ).FieldByName(name)
+		typ = field.Type
+	}
+
+	typ = indirect(typ)
+
+	if typ.Kind() == reflect.Struct {
+		for i := 0; i < typ.NumField(); i++ {
+			if field := typ.Field(i); field.IsExported() {
+				paths = append(paths, leaves(typ, []string{field.Name})...)
+			}
+		}
+	}
+
+	if len(paths) == 0 {
+		return [][]string{path}
+	}
+
+	for i := range paths {
+		paths[i] = append(append([]string{}, path...), paths[i]...)
+	}
+
+	return paths
+}
+
+// setLeaf sets the field at the given path to a value differing from the current one, allocating its parents.
+func setLeaf(value reflect.Value, path []string) {
+	for _, name := range path {
+		for value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				value.Set(reflect.New(value.Type().Elem()))
+			}
+
+			value = value.Elem()
+		}
+
+		value = value.FieldByName(name)
+	}
+
+	switch value.Kind() { //nolint:exhaustive
+	case reflect.Bool:
+		value.SetBool(!value.Bool())
+	case reflect.String:
+		value.SetString("leaf")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value.SetUint(1)
+	case reflect.Slice:
+		value.Set(reflect.MakeSlice(value.Type(), 1, 1))
+	case reflect.Map:
+		value.Set(reflect.MakeMap(value.Type()))
+		value.SetMapIndex(reflect.New(value.Type().Key()).Elem(), reflect.New(value.Type().Elem()).Elem())
+	case reflect.Ptr:
+		value.Set(reflect.New(value.Type().Elem()))
+	}
+}
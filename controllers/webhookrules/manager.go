@@ -0,0 +1,193 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookrules
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-logr/logr"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/controllers/capacity"
+	"github.com/projectcapsule/capsule/controllers/utils"
+	"github.com/projectcapsule/capsule/pkg/configuration"
+)
+
+// RulesAnnotation stores the original rules of the trimmed webhooks, keyed by the webhook name.
+const RulesAnnotation = "capsule.clastix.io/trimmed-webhook-rules"
+
+// Manager trims the rules of the webhooks not required by any Tenant from the Capsule webhook configurations,
+// restoring them as soon as a Tenant requires them, or when the minimization is disabled.
+type Manager struct {
+	Client        client.Client
+	Log           logr.Logger
+	Configuration configuration.Configuration
+}
+
+func (r *Manager) SetupWithManager(mgr ctrl.Manager) error {
+	// All the events are mapped to a single request, since the required webhooks depend on all the Tenants.
+	enqueue := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "webhook-rules"}}}
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("webhookrules").
+		Watches(&capsulev1beta2.Tenant{}, enqueue, builder.WithPredicates(utils.AnnotationsIgnoringPredicate(capacity.AnnotationsPrefix))).
+		Watches(&capsulev1beta2.CapsuleConfiguration{}, enqueue).
+		Watches(&admissionregistrationv1.ValidatingWebhookConfiguration{}, enqueue).
+		Watches(&admissionregistrationv1.MutatingWebhookConfiguration{}, enqueue).
+		Complete(r)
+}
+
+func (r *Manager) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	tntList := &capsulev1beta2.TenantList{}
+	if err := r.Client.List(ctx, tntList); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	required := requiredWebhooks(tntList.Items)
+	minimize := r.Configuration.MinimizeWebhookRules()
+
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		vwc := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: r.Configuration.ValidatingWebhookConfigurationName()}, vwc); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		saved, err := savedRules(vwc)
+		if err != nil {
+			return err
+		}
+
+		changed := false
+
+		for i := range vwc.Webhooks {
+			changed = r.sync(vwc.Webhooks[i].Name, &vwc.Webhooks[i].Rules, saved, minimize && !required[vwc.Webhooks[i].Name]) || changed
+		}
+
+		if !changed {
+			return nil
+		}
+
+		if err = setSavedRules(vwc, saved); err != nil {
+			return err
+		}
+
+		return r.Client.Update(ctx, vwc)
+	})
+	if err != nil {
+		r.Log.Error(err, "cannot sync ValidatingWebhookConfiguration rules")
+
+		return reconcile.Result{}, err
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		mwc := &admissionregistrationv1.MutatingWebhookConfiguration{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: r.Configuration.MutatingWebhookConfigurationName()}, mwc); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		saved, err := savedRules(mwc)
+		if err != nil {
+			return err
+		}
+
+		changed := false
+
+		for i := range mwc.Webhooks {
+			changed = r.sync(mwc.Webhooks[i].Name, &mwc.Webhooks[i].Rules, saved, minimize && !required[mwc.Webhooks[i].Name]) || changed
+		}
+
+		if !changed {
+			return nil
+		}
+
+		if err = setSavedRules(mwc, saved); err != nil {
+			return err
+		}
+
+		return r.Client.Update(ctx, mwc)
+	})
+	if err != nil {
+		r.Log.Error(err, "cannot sync MutatingWebhookConfiguration rules")
+
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// sync trims or restores the rules of the given webhook, returning true if they have been changed.
+func (r *Manager) sync(name string, rules *[]admissionregistrationv1.RuleWithOperations, saved map[string][]admissionregistrationv1.RuleWithOperations, trim bool) bool {
+	if _, ok := features[name]; !ok {
+		return false
+	}
+
+	switch {
+	case trim && len(*rules) > 0:
+		r.Log.Info("trimming webhook rules, no Tenant requires it", "webhook", name)
+
+		saved[name] = *rules
+		*rules = []admissionregistrationv1.RuleWithOperations{}
+
+		return true
+	case !trim && len(*rules) == 0 && len(saved[name]) > 0:
+		r.Log.Info("restoring webhook rules", "webhook", name)
+
+		*rules = saved[name]
+		delete(saved, name)
+
+		return true
+	default:
+		return false
+	}
+}
+
+func savedRules(obj client.Object) (map[string][]admissionregistrationv1.RuleWithOperations, error) {
+	saved := make(map[string][]admissionregistrationv1.RuleWithOperations)
+
+	value, ok := obj.GetAnnotations()[RulesAnnotation]
+	if !ok {
+		return saved, nil
+	}
+
+	if err := json.Unmarshal([]byte(value), &saved); err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+func setSavedRules(obj client.Object, saved map[string][]admissionregistrationv1.RuleWithOperations) error {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+
+	if len(saved) == 0 {
+		delete(annotations, RulesAnnotation)
+		obj.SetAnnotations(annotations)
+
+		return nil
+	}
+
+	value, err := json.Marshal(saved)
+	if err != nil {
+		return err
+	}
+
+	annotations[RulesAnnotation] = string(value)
+	obj.SetAnnotations(annotations)
+
+	return nil
+}
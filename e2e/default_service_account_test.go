@@ -0,0 +1,82 @@
+//go:build e2e
+
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+var _ = Describe("creating a Namespace for a Tenant with a default ServiceAccount policy", func() {
+	tnt := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "tenant-default-sa",
+		},
+		Spec: capsulev1beta2.TenantSpec{
+			Owners: capsulev1beta2.OwnerListSpec{
+				{
+					Name: "carlo",
+					Kind: "User",
+				},
+			},
+			NamespaceOptions: &capsulev1beta2.NamespaceOptions{
+				DefaultServiceAccount: &api.DefaultServiceAccountSpec{
+					ImagePullSecrets:             []string{"registry-credentials"},
+					AutomountServiceAccountToken: ptr.To(false),
+				},
+			},
+		},
+	}
+
+	JustBeforeEach(func() {
+		EventuallyCreation(func() error {
+			tnt.ResourceVersion = ""
+
+			return k8sClient.Create(context.TODO(), tnt)
+		}).Should(Succeed())
+	})
+	JustAfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), tnt)).Should(Succeed())
+	})
+
+	It("should apply and keep reconciled the policy on the default ServiceAccount", func() {
+		ns := NewNamespace("")
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+		TenantNamespaceList(tnt, defaultTimeoutInterval).Should(ContainElement(ns.GetName()))
+
+		sa := &corev1.ServiceAccount{}
+
+		compliant := func() bool {
+			if err := k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: ns.GetName(), Name: "default"}, sa); err != nil {
+				return false
+			}
+
+			return sa.AutomountServiceAccountToken != nil && !*sa.AutomountServiceAccountToken &&
+				len(sa.ImagePullSecrets) == 1 && sa.ImagePullSecrets[0].Name == "registry-credentials"
+		}
+
+		By("checking the policy is applied", func() {
+			Eventually(compliant, defaultTimeoutInterval, defaultPollInterval).Should(BeTrue())
+		})
+
+		By("reverting a manual change", func() {
+			sa.AutomountServiceAccountToken = ptr.To(true)
+			sa.ImagePullSecrets = nil
+			Expect(k8sClient.Update(context.TODO(), sa)).Should(Succeed())
+
+			Eventually(compliant, defaultTimeoutInterval, defaultPollInterval).Should(BeTrue())
+		})
+	})
+})
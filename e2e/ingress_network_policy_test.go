@@ -0,0 +1,161 @@
+//go:build e2e
+
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+var _ = Describe("generating the NetworkPolicies from the Tenant Ingress resources", func() {
+	tnt := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "ingress-network-policy",
+		},
+		Spec: capsulev1beta2.TenantSpec{
+			Owners: capsulev1beta2.OwnerListSpec{
+				{
+					Name: "igor",
+					Kind: "User",
+				},
+			},
+			IngressOptions: capsulev1beta2.IngressOptions{
+				AllowedHostnames: &api.AllowedListSpec{
+					Exact: []string{"allowed.clastix.io"},
+				},
+				GenerateNetworkPolicies: true,
+			},
+		},
+	}
+
+	JustBeforeEach(func() {
+		ModifyCapsuleConfigurationOpts(func(configuration *capsulev1beta2.CapsuleConfiguration) {
+			configuration.Spec.IngressControllers = []capsulev1beta2.IngressControllerSpec{
+				{
+					IngressClasses: []string{"nginx"},
+					NamespaceSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{"kubernetes.io/metadata.name": "ingress-nginx"},
+					},
+				},
+			}
+		})
+
+		EventuallyCreation(func() error {
+			tnt.ResourceVersion = ""
+
+			return k8sClient.Create(context.TODO(), tnt)
+		}).Should(Succeed())
+	})
+
+	JustAfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), tnt)).Should(Succeed())
+
+		ModifyCapsuleConfigurationOpts(func(configuration *capsulev1beta2.CapsuleConfiguration) {
+			configuration.Spec.IngressControllers = nil
+		})
+	})
+
+	It("should allow the Ingress controller to reach the backends of the allowed hostnames only", func() {
+		ns := NewNamespace("")
+
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+		TenantNamespaceList(tnt, defaultTimeoutInterval).Should(ContainElement(ns.GetName()))
+
+		for _, name := range []string{"web", "admin"} {
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: ns.GetName(),
+				},
+				Spec: corev1.ServiceSpec{
+					Selector: map[string]string{"app": name},
+					Ports: []corev1.ServicePort{
+						{
+							Name:       "http",
+							Port:       80,
+							TargetPort: intstr.FromInt32(8080),
+						},
+					},
+				},
+			}
+
+			EventuallyCreation(func() error {
+				return k8sClient.Create(context.TODO(), svc)
+			}).Should(Succeed())
+		}
+
+		ingress := &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "web",
+				Namespace: ns.GetName(),
+			},
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: ptr.To("nginx"),
+				Rules: []networkingv1.IngressRule{
+					{
+						Host: "allowed.clastix.io",
+						IngressRuleValue: networkingv1.IngressRuleValue{
+							HTTP: &networkingv1.HTTPIngressRuleValue{
+								Paths: []networkingv1.HTTPIngressPath{
+									{
+										Path:     "/",
+										PathType: ptr.To(networkingv1.PathTypePrefix),
+										Backend: networkingv1.IngressBackend{
+											Service: &networkingv1.IngressServiceBackend{
+												Name: "web",
+												Port: networkingv1.ServiceBackendPort{Name: "http"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		EventuallyCreation(func() error {
+			return k8sClient.Create(context.TODO(), ingress)
+		}).Should(Succeed())
+
+		np := &networkingv1.NetworkPolicy{}
+
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: ns.GetName(), Name: "capsule-ingress-web"}, np)
+		}, defaultTimeoutInterval, defaultPollInterval).Should(Succeed())
+
+		Expect(np.Spec.PodSelector.MatchLabels).To(Equal(map[string]string{"app": "web"}))
+		Expect(np.Spec.Ingress).To(HaveLen(1))
+		Expect(np.Spec.Ingress[0].From).To(HaveLen(1))
+		Expect(np.Spec.Ingress[0].From[0].NamespaceSelector.MatchLabels).To(Equal(map[string]string{"kubernetes.io/metadata.name": "ingress-nginx"}))
+		Expect(np.Spec.Ingress[0].Ports).To(HaveLen(1))
+		Expect(np.Spec.Ingress[0].Ports[0].Port.IntValue()).To(Equal(8080))
+
+		Consistently(func() error {
+			return k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: ns.GetName(), Name: "capsule-ingress-admin"}, &networkingv1.NetworkPolicy{})
+		}, defaultTimeoutInterval, defaultPollInterval).ShouldNot(Succeed())
+
+		By("deleting the Ingress", func() {
+			Expect(k8sClient.Delete(context.TODO(), ingress)).Should(Succeed())
+
+			Eventually(func() error {
+				return k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: ns.GetName(), Name: "capsule-ingress-web"}, &networkingv1.NetworkPolicy{})
+			}, defaultTimeoutInterval, defaultPollInterval).ShouldNot(Succeed())
+		})
+	})
+})
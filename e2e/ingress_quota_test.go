@@ -0,0 +1,95 @@
+//go:build e2e
+
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+var _ = Describe("creating Ingresses when the Tenant has an Ingress quota", func() {
+	tnt := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "ingress-quota",
+		},
+		Spec: capsulev1beta2.TenantSpec{
+			Owners: capsulev1beta2.OwnerListSpec{
+				{
+					Name: "ingrid",
+					Kind: "User",
+				},
+			},
+			IngressOptions: capsulev1beta2.IngressOptions{
+				Quota: ptr.To[int32](2),
+			},
+		},
+	}
+
+	JustBeforeEach(func() {
+		EventuallyCreation(func() error {
+			tnt.ResourceVersion = ""
+
+			return k8sClient.Create(context.TODO(), tnt)
+		}).Should(Succeed())
+	})
+
+	JustAfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), tnt)).Should(Succeed())
+	})
+
+	It("should block the Ingress exceeding the quota across the Tenant Namespaces", func() {
+		newIngress := func(namespace string, i int) *networkingv1.Ingress {
+			return &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("ingress-%d", i),
+					Namespace: namespace,
+				},
+				Spec: networkingv1.IngressSpec{
+					DefaultBackend: &networkingv1.IngressBackend{
+						Service: &networkingv1.IngressServiceBackend{
+							Name: "foo",
+							Port: networkingv1.ServiceBackendPort{Number: 8080},
+						},
+					},
+				},
+			}
+		}
+
+		first, second := NewNamespace(""), NewNamespace("")
+
+		NamespaceCreation(first, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+		NamespaceCreation(second, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+		TenantNamespaceList(tnt, defaultTimeoutInterval).Should(ContainElements(first.GetName(), second.GetName()))
+
+		EventuallyCreation(func() error {
+			return k8sClient.Create(context.Background(), newIngress(first.GetName(), 0))
+		}).Should(Succeed())
+
+		EventuallyCreation(func() error {
+			return k8sClient.Create(context.Background(), newIngress(second.GetName(), 1))
+		}).Should(Succeed())
+
+		EventuallyCreation(func() error {
+			return k8sClient.Create(context.Background(), newIngress(second.GetName(), 2))
+		}).ShouldNot(Succeed())
+
+		Eventually(func() uint {
+			t := &capsulev1beta2.Tenant{}
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: tnt.GetName()}, t)).Should(Succeed())
+
+			return t.Status.Ingresses
+		}, defaultTimeoutInterval, defaultPollInterval).Should(BeEquivalentTo(2))
+	})
+})
@@ -0,0 +1,147 @@
+//go:build e2e
+
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+var _ = Describe("updating the immutable labels and annotations of a Namespace", func() {
+	tnt := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "tenant-immutable-metadata",
+		},
+		Spec: capsulev1beta2.TenantSpec{
+			NamespaceOptions: &capsulev1beta2.NamespaceOptions{
+				ImmutableLabels: api.ImmutableListSpec{
+					Regex: "^pod-security.kubernetes.io/",
+				},
+				ImmutableAnnotations: api.ImmutableListSpec{
+					Exact: []string{"cost-center"},
+				},
+			},
+			Owners: capsulev1beta2.OwnerListSpec{
+				{
+					Name: "ivan",
+					Kind: "User",
+				},
+			},
+		},
+	}
+
+	JustBeforeEach(func() {
+		EventuallyCreation(func() error {
+			tnt.ResourceVersion = ""
+
+			return k8sClient.Create(context.TODO(), tnt)
+		}).Should(Succeed())
+	})
+
+	JustAfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), tnt)).Should(Succeed())
+	})
+
+	It("should allow the mutable keys only", func() {
+		ns := NewNamespace("")
+		ns.SetLabels(map[string]string{"pod-security.kubernetes.io/enforce": "restricted"})
+		ns.SetAnnotations(map[string]string{"cost-center": "oil"})
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		role := &rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "ns-update", Namespace: ns.GetName()},
+			Rules: []rbacv1.PolicyRule{
+				{
+					Verbs:     []string{"patch", "update"},
+					APIGroups: []string{""},
+					Resources: []string{"namespaces"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), role)).To(Succeed())
+
+		roleBinding := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "ns-update", Namespace: ns.GetName()},
+			Subjects: []rbacv1.Subject{
+				{
+					APIGroup: rbacv1.GroupName,
+					Kind:     tnt.Spec.Owners[0].Kind.String(),
+					Name:     tnt.Spec.Owners[0].Name,
+				},
+			},
+			RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: role.GetName()},
+		}
+		Expect(k8sClient.Create(context.TODO(), roleBinding)).To(Succeed())
+
+		cs := ownerClient(tnt.Spec.Owners[0])
+
+		update := func(mutate func(ns *corev1.Namespace)) error {
+			found := &corev1.Namespace{}
+			if err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: ns.GetName()}, found); err != nil {
+				return err
+			}
+
+			mutate(found)
+
+			_, err := cs.CoreV1().Namespaces().Update(context.TODO(), found, metav1.UpdateOptions{})
+
+			return err
+		}
+
+		By("changing a mutable label", func() {
+			Eventually(func() error {
+				return update(func(ns *corev1.Namespace) {
+					ns.Labels["team"] = "backend"
+				})
+			}, defaultTimeoutInterval, defaultPollInterval).Should(Succeed())
+		})
+
+		By("changing an immutable label", func() {
+			err := update(func(ns *corev1.Namespace) {
+				ns.Labels["pod-security.kubernetes.io/enforce"] = "privileged"
+			})
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("CAPS-NS-011"))
+			Expect(err.Error()).Should(ContainSubstring(`cannot be changed from "restricted" to "privileged"`))
+		})
+
+		By("adding an immutable label", func() {
+			err := update(func(ns *corev1.Namespace) {
+				ns.Labels["pod-security.kubernetes.io/warn"] = "baseline"
+			})
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("CAPS-NS-011"))
+		})
+
+		By("removing an immutable annotation", func() {
+			err := update(func(ns *corev1.Namespace) {
+				delete(ns.Annotations, "cost-center")
+			})
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("CAPS-NS-012"))
+		})
+	})
+
+	It("should deny the invalid immutable keys regex", func() {
+		invalid := tnt.DeepCopy()
+		invalid.SetResourceVersion("")
+		invalid.SetName("tenant-immutable-metadata-invalid")
+		invalid.Spec.NamespaceOptions.ImmutableAnnotations.Regex = "(.*gitops"
+
+		err := k8sClient.Create(context.TODO(), invalid)
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Error()).Should(ContainSubstring("CAPS-TNT-004"))
+	})
+})
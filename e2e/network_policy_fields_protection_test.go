@@ -0,0 +1,136 @@
+//go:build e2e
+
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+var _ = Describe("updating the Capsule NetworkPolicies protected at field level", func() {
+	tnt := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "network-policy-fields",
+		},
+		Spec: capsulev1beta2.TenantSpec{
+			Owners: capsulev1beta2.OwnerListSpec{
+				{
+					Name: "fiona",
+					Kind: "User",
+				},
+			},
+			NetworkPolicies: api.NetworkPolicySpec{
+				Protection: api.NetworkPolicyProtectionFields,
+				Items: []networkingv1.NetworkPolicySpec{
+					{
+						PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+						Ingress: []networkingv1.NetworkPolicyIngressRule{
+							{
+								From: []networkingv1.NetworkPolicyPeer{
+									{
+										NamespaceSelector: &metav1.LabelSelector{
+											MatchLabels: map[string]string{"capsule.clastix.io/tenant": "network-policy-fields"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	JustBeforeEach(func() {
+		EventuallyCreation(func() error {
+			tnt.ResourceVersion = ""
+
+			return k8sClient.Create(context.TODO(), tnt)
+		}).Should(Succeed())
+	})
+
+	JustAfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), tnt)).Should(Succeed())
+	})
+
+	It("should allow appending rules, but not changing the Capsule ones", func() {
+		ns := NewNamespace("")
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+		TenantNamespaceList(tnt, defaultTimeoutInterval).Should(ContainElement(ns.GetName()))
+
+		name := fmt.Sprintf("capsule-%s-0", tnt.GetName())
+
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: ns.GetName(), Name: name}, &networkingv1.NetworkPolicy{})
+		}, defaultTimeoutInterval, defaultPollInterval).Should(Succeed())
+
+		cs := ownerClient(tnt.Spec.Owners[0])
+
+		By("appending an ingress rule", func() {
+			patch := []byte(`[{"op": "add", "path": "/spec/ingress/-", "value": {"from": [{"namespaceSelector": {"matchLabels": {"kubernetes.io/metadata.name": "monitoring"}}}]}}]`)
+
+			Eventually(func() error {
+				_, err := cs.NetworkingV1().NetworkPolicies(ns.GetName()).Patch(context.TODO(), name, types.JSONPatchType, patch, metav1.PatchOptions{})
+
+				return err
+			}, defaultTimeoutInterval, defaultPollInterval).Should(Succeed())
+
+			Consistently(func() int {
+				np := &networkingv1.NetworkPolicy{}
+				if err := k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: ns.GetName(), Name: name}, np); err != nil {
+					return 0
+				}
+
+				return len(np.Spec.Ingress)
+			}, defaultTimeoutInterval, defaultPollInterval).Should(Equal(2))
+		})
+
+		By("changing the Capsule ingress rule after appending one", func() {
+			patch := []byte(`[{"op": "replace", "path": "/spec/ingress/0/from/0/namespaceSelector/matchLabels", "value": {"kubernetes.io/metadata.name": "monitoring"}}]`)
+
+			_, err := cs.NetworkingV1().NetworkPolicies(ns.GetName()).Patch(context.TODO(), name, types.JSONPatchType, patch, metav1.PatchOptions{})
+			Expect(err).Should(HaveOccurred())
+
+			np := &networkingv1.NetworkPolicy{}
+			Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: ns.GetName(), Name: name}, np)).Should(Succeed())
+			Expect(np.Spec.Ingress[0].From[0].NamespaceSelector.MatchLabels).Should(Equal(map[string]string{"capsule.clastix.io/tenant": tnt.GetName()}))
+		})
+
+		By("changing the pod selector", func() {
+			patch := []byte(`[{"op": "add", "path": "/spec/podSelector/matchLabels", "value": {"app": "none"}}]`)
+
+			_, err := cs.NetworkingV1().NetworkPolicies(ns.GetName()).Patch(context.TODO(), name, types.JSONPatchType, patch, metav1.PatchOptions{})
+			Expect(err).Should(HaveOccurred())
+		})
+
+		By("removing the Capsule ingress rule", func() {
+			patch := []byte(`[{"op": "remove", "path": "/spec/ingress/0"}]`)
+
+			_, err := cs.NetworkingV1().NetworkPolicies(ns.GetName()).Patch(context.TODO(), name, types.JSONPatchType, patch, metav1.PatchOptions{})
+			Expect(err).Should(HaveOccurred())
+		})
+
+		By("removing the Capsule labels", func() {
+			patch := []byte(`[{"op": "remove", "path": "/metadata/labels/capsule.clastix.io~1tenant"}]`)
+
+			_, err := cs.NetworkingV1().NetworkPolicies(ns.GetName()).Patch(context.TODO(), name, types.JSONPatchType, patch, metav1.PatchOptions{})
+			Expect(err).Should(HaveOccurred())
+		})
+
+		By("deleting the NetworkPolicy", func() {
+			Expect(cs.NetworkingV1().NetworkPolicies(ns.GetName()).Delete(context.TODO(), name, metav1.DeleteOptions{})).ShouldNot(Succeed())
+		})
+	})
+})
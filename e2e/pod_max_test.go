@@ -0,0 +1,158 @@
+//go:build e2e
+
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+var _ = Describe("creating Pods when the Tenant has a maximum number of Pods per node", func() {
+	tnt := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pod-max-per-node",
+		},
+		Spec: capsulev1beta2.TenantSpec{
+			Owners: capsulev1beta2.OwnerListSpec{
+				{
+					Name: "paula",
+					Kind: "User",
+				},
+			},
+			PodOptions: &api.PodOptions{
+				MaxPodsPerNode: ptr.To[int32](1),
+			},
+		},
+	}
+
+	JustBeforeEach(func() {
+		EventuallyCreation(func() error {
+			tnt.ResourceVersion = ""
+
+			return k8sClient.Create(context.TODO(), tnt)
+		}).Should(Succeed())
+	})
+
+	JustAfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), tnt)).Should(Succeed())
+	})
+
+	newPod := func(namespace string, i int) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("pod-%d", i),
+				Namespace: namespace,
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:  "container",
+						Image: "quay.io/google-containers/pause-amd64:3.0",
+					},
+				},
+			},
+		}
+	}
+
+	get := func(pod *corev1.Pod) *corev1.Pod {
+		current := &corev1.Pod{}
+		Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: pod.GetName(), Namespace: pod.GetNamespace()}, current)).Should(Succeed())
+
+		return current
+	}
+
+	It("should spread the Pods across the nodes with the node slots anti-affinity", func() {
+		first, second := NewNamespace(""), NewNamespace("")
+
+		NamespaceCreation(first, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+		NamespaceCreation(second, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+		TenantNamespaceList(tnt, defaultTimeoutInterval).Should(ContainElements(first.GetName(), second.GetName()))
+
+		firstPod, secondPod := newPod(first.GetName(), 0), newPod(second.GetName(), 1)
+
+		EventuallyCreation(func() error {
+			return k8sClient.Create(context.Background(), firstPod)
+		}).Should(Succeed())
+
+		created := get(firstPod)
+		Expect(created.GetLabels()).Should(HaveKeyWithValue(api.NodeSlotLabel, "0"))
+		Expect(created.Spec.Affinity).ShouldNot(BeNil())
+		Expect(created.Spec.Affinity.PodAntiAffinity).ShouldNot(BeNil())
+		Expect(created.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution).Should(ContainElement(corev1.PodAffinityTerm{
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{api.NodeSlotLabel: "0"}},
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"capsule.clastix.io/tenant": tnt.GetName()}},
+			TopologyKey:       corev1.LabelHostname,
+		}))
+
+		Eventually(func() string {
+			return get(firstPod).Spec.NodeName
+		}, defaultTimeoutInterval, defaultPollInterval).ShouldNot(BeEmpty())
+
+		nodes := &corev1.NodeList{}
+		Expect(k8sClient.List(context.Background(), nodes)).Should(Succeed())
+
+		if len(nodes.Items) > 1 {
+			Skip("the cluster has more than a node, the second Pod can be scheduled")
+		}
+
+		By("leaving the Pod exceeding the maximum on the node unschedulable", func() {
+			EventuallyCreation(func() error {
+				return k8sClient.Create(context.Background(), secondPod)
+			}).Should(Succeed())
+
+			Eventually(func() string {
+				for _, condition := range get(secondPod).Status.Conditions {
+					if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse {
+						return condition.Reason
+					}
+				}
+
+				return ""
+			}, defaultTimeoutInterval, defaultPollInterval).Should(Equal(corev1.PodReasonUnschedulable))
+		})
+	})
+
+	It("should deny the Pods bypassing the node slots", func() {
+		ns := NewNamespace("")
+
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+		TenantNamespaceList(tnt, defaultTimeoutInterval).Should(ContainElement(ns.GetName()))
+
+		nodes := &corev1.NodeList{}
+		Expect(k8sClient.List(context.Background(), nodes)).Should(Succeed())
+		Expect(nodes.Items).ShouldNot(BeEmpty())
+
+		By("creating a Pod with a node already assigned", func() {
+			pod := newPod(ns.GetName(), 0)
+			pod.Spec.NodeName = nodes.Items[0].GetName()
+
+			Expect(k8sClient.Create(context.Background(), pod)).ShouldNot(Succeed())
+		})
+
+		By("changing the node slot label of a Pod", func() {
+			pod := newPod(ns.GetName(), 1)
+
+			EventuallyCreation(func() error {
+				return k8sClient.Create(context.Background(), pod)
+			}).Should(Succeed())
+
+			pod = get(pod)
+			pod.Labels[api.NodeSlotLabel] = "7"
+
+			Expect(k8sClient.Update(context.Background(), pod)).ShouldNot(Succeed())
+		})
+	})
+})
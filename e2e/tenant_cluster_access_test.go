@@ -0,0 +1,114 @@
+//go:build e2e
+
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+var _ = Describe("granting the Tenant owners the read access to cluster scoped resources", func() {
+	tnt := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "tenant-cluster-access",
+		},
+		Spec: capsulev1beta2.TenantSpec{
+			Owners: capsulev1beta2.OwnerListSpec{
+				{
+					Name: "nora",
+					Kind: "User",
+				},
+			},
+			NodeSelector: map[string]string{
+				"kubernetes.io/os": "linux",
+			},
+			ClusterAccess: &api.ClusterAccessSpec{
+				Nodes:       true,
+				NodeMetrics: true,
+				Resources: []api.ClusterResourceAccess{
+					{
+						APIGroup:      "storage.k8s.io",
+						Resource:      "storageclasses",
+						ResourceNames: []string{"standard"},
+					},
+				},
+			},
+		},
+	}
+
+	JustBeforeEach(func() {
+		EventuallyCreation(func() error {
+			tnt.ResourceVersion = ""
+
+			return k8sClient.Create(context.TODO(), tnt)
+		}).Should(Succeed())
+	})
+
+	JustAfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), tnt)).Should(Succeed())
+	})
+
+	It("should allow reading the Tenant nodes and the given resources only", func() {
+		nodeList := &corev1.NodeList{}
+		Expect(k8sClient.List(context.TODO(), nodeList)).Should(Succeed())
+		Expect(nodeList.Items).ShouldNot(BeEmpty())
+
+		cs := ownerClient(tnt.Spec.Owners[0])
+
+		allowed := func(group, resource, name string) func() bool {
+			return func() bool {
+				review, err := cs.AuthorizationV1().SelfSubjectAccessReviews().Create(context.TODO(), &authorizationv1.SelfSubjectAccessReview{
+					Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+						ResourceAttributes: &authorizationv1.ResourceAttributes{
+							Group:    group,
+							Resource: resource,
+							Name:     name,
+							Verb:     "get",
+						},
+					},
+				}, metav1.CreateOptions{})
+				if err != nil {
+					return false
+				}
+
+				return review.Status.Allowed
+			}
+		}
+
+		node := nodeList.Items[0].GetName()
+
+		Eventually(allowed("", "nodes", node), defaultTimeoutInterval, defaultPollInterval).Should(BeTrue())
+		Eventually(allowed("metrics.k8s.io", "nodes", node), defaultTimeoutInterval, defaultPollInterval).Should(BeTrue())
+		Eventually(allowed("storage.k8s.io", "storageclasses", "standard"), defaultTimeoutInterval, defaultPollInterval).Should(BeTrue())
+		Consistently(allowed("", "nodes", "not-a-tenant-node"), defaultTimeoutInterval, defaultPollInterval).Should(BeFalse())
+		Consistently(allowed("storage.k8s.io", "storageclasses", "premium"), defaultTimeoutInterval, defaultPollInterval).Should(BeFalse())
+	})
+
+	It("should deny granting the access to namespaced resources", func() {
+		Eventually(func() error {
+			found := &capsulev1beta2.Tenant{}
+			if err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: tnt.GetName()}, found); err != nil {
+				return err
+			}
+
+			found.Spec.ClusterAccess.Resources = append(found.Spec.ClusterAccess.Resources, api.ClusterResourceAccess{
+				Resource:      "secrets",
+				ResourceNames: []string{"db"},
+			})
+
+			return k8sClient.Update(context.TODO(), found)
+		}, defaultTimeoutInterval, defaultPollInterval).Should(MatchError(ContainSubstring("CAPS-TNT-009")))
+	})
+})
@@ -0,0 +1,100 @@
+//go:build e2e
+
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+var _ = Describe("re-evaluating the existing objects upon a Tenant policy change", func() {
+	tnt := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "policy-reevaluation",
+		},
+		Spec: capsulev1beta2.TenantSpec{
+			Owners: capsulev1beta2.OwnerListSpec{
+				{
+					Name: "rebecca",
+					Kind: "User",
+				},
+			},
+		},
+	}
+
+	JustBeforeEach(func() {
+		EventuallyCreation(func() error {
+			tnt.ResourceVersion = ""
+
+			return k8sClient.Create(context.TODO(), tnt)
+		}).Should(Succeed())
+	})
+
+	JustAfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), tnt)).Should(Succeed())
+	})
+
+	It("should report the Pods violating the new container registries policy", func() {
+		ns := NewNamespace("")
+
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+		TenantNamespaceList(tnt, defaultTimeoutInterval).Should(ContainElement(ns.GetName()))
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "legacy",
+				Namespace: ns.GetName(),
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:  "container",
+						Image: "docker.io/library/nginx:1.25",
+					},
+				},
+			},
+		}
+
+		EventuallyCreation(func() error {
+			return k8sClient.Create(context.Background(), pod)
+		}).Should(Succeed())
+
+		Eventually(func() error {
+			t := &capsulev1beta2.Tenant{}
+			if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: tnt.GetName()}, t); err != nil {
+				return err
+			}
+
+			t.Spec.ContainerRegistries = &api.AllowedListSpec{Exact: []string{"quay.io"}}
+
+			return k8sClient.Update(context.Background(), t)
+		}, defaultTimeoutInterval, defaultPollInterval).Should(Succeed())
+
+		Eventually(func() []capsulev1beta2.TenantViolation {
+			t := &capsulev1beta2.Tenant{}
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: tnt.GetName()}, t)).Should(Succeed())
+
+			if t.Status.Violations == nil || t.Status.Violations.TenantGeneration != t.GetGeneration() {
+				return nil
+			}
+
+			return t.Status.Violations.Items
+		}, defaultTimeoutInterval, defaultPollInterval).Should(ContainElement(And(
+			HaveField("Kind", "Pod"),
+			HaveField("Namespace", ns.GetName()),
+			HaveField("Name", "legacy"),
+			HaveField("Code", "CAPS-REG-001"),
+		)))
+	})
+})
@@ -0,0 +1,95 @@
+//go:build e2e
+// +build e2e
+
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+var _ = Describe("reading the own Tenant as owner", func() {
+	tnt := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "tenant-viewer",
+		},
+		Spec: capsulev1beta2.TenantSpec{
+			Owners: capsulev1beta2.OwnerListSpec{
+				{
+					Name: "ezekiel",
+					Kind: "User",
+				},
+			},
+		},
+	}
+
+	other := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "tenant-viewer-other",
+		},
+		Spec: capsulev1beta2.TenantSpec{
+			Owners: capsulev1beta2.OwnerListSpec{
+				{
+					Name: "negan",
+					Kind: "User",
+				},
+			},
+		},
+	}
+
+	JustBeforeEach(func() {
+		for _, t := range []*capsulev1beta2.Tenant{tnt, other} {
+			EventuallyCreation(func() error {
+				t.ResourceVersion = ""
+
+				return k8sClient.Create(context.TODO(), t)
+			}).Should(Succeed())
+		}
+	})
+
+	JustAfterEach(func() {
+		for _, t := range []*capsulev1beta2.Tenant{tnt, other} {
+			Expect(k8sClient.Delete(context.TODO(), t)).Should(Succeed())
+		}
+	})
+
+	It("should allow reading the own Tenant only", func() {
+		cs := ownerClient(tnt.Spec.Owners[0])
+
+		allowed := func(name, verb string) func() bool {
+			return func() bool {
+				review, err := cs.AuthorizationV1().SelfSubjectAccessReviews().Create(context.TODO(), &authorizationv1.SelfSubjectAccessReview{
+					Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+						ResourceAttributes: &authorizationv1.ResourceAttributes{
+							Group:    capsulev1beta2.GroupVersion.Group,
+							Resource: "tenants",
+							Name:     name,
+							Verb:     verb,
+						},
+					},
+				}, metav1.CreateOptions{})
+				if err != nil {
+					return false
+				}
+
+				return review.Status.Allowed
+			}
+		}
+
+		for _, verb := range []string{"get", "watch"} {
+			Eventually(allowed(tnt.GetName(), verb), defaultTimeoutInterval, defaultPollInterval).Should(BeTrue())
+			Consistently(allowed(other.GetName(), verb), defaultTimeoutInterval, defaultPollInterval).Should(BeFalse())
+		}
+
+		Consistently(allowed(tnt.GetName(), "update"), defaultTimeoutInterval, defaultPollInterval).Should(BeFalse())
+	})
+})
@@ -0,0 +1,127 @@
+//go:build e2e
+
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+var _ = Describe("applying the policies of a TenantGroup to its member Tenants", func() {
+	member := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "tenantgroup-member",
+			Labels: map[string]string{"tenant-group-e2e": "gold"},
+		},
+		Spec: capsulev1beta2.TenantSpec{
+			Owners: capsulev1beta2.OwnerListSpec{
+				{
+					Name: "olga",
+					Kind: "User",
+				},
+			},
+		},
+	}
+
+	overriding := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "tenantgroup-overriding",
+			Labels: map[string]string{"tenant-group-e2e": "gold"},
+		},
+		Spec: capsulev1beta2.TenantSpec{
+			Owners: capsulev1beta2.OwnerListSpec{
+				{
+					Name: "olga",
+					Kind: "User",
+				},
+			},
+			ContainerRegistries: &api.AllowedListSpec{
+				Exact: []string{"docker.io"},
+			},
+		},
+	}
+
+	group := &capsulev1beta2.TenantGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "gold-e2e",
+		},
+		Spec: capsulev1beta2.TenantGroupSpec{
+			TenantSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"tenant-group-e2e": "gold"},
+			},
+			Policies: &capsulev1beta2.TenantGroupPolicies{
+				ContainerRegistries: &api.AllowedListSpec{
+					Exact: []string{"quay.io"},
+				},
+			},
+		},
+	}
+
+	JustBeforeEach(func() {
+		for _, tnt := range []*capsulev1beta2.Tenant{member, overriding} {
+			EventuallyCreation(func() error {
+				tnt.ResourceVersion = ""
+
+				return k8sClient.Create(context.TODO(), tnt)
+			}).Should(Succeed())
+		}
+
+		EventuallyCreation(func() error {
+			group.ResourceVersion = ""
+
+			return k8sClient.Create(context.TODO(), group)
+		}).Should(Succeed())
+	})
+
+	JustAfterEach(func() {
+		_ = k8sClient.Delete(context.TODO(), group)
+
+		for _, tnt := range []*capsulev1beta2.Tenant{member, overriding} {
+			Expect(k8sClient.Delete(context.TODO(), tnt)).Should(Succeed())
+		}
+	})
+
+	registries := func(name string) func() []string {
+		return func() []string {
+			tnt := &capsulev1beta2.Tenant{}
+			if err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: name}, tnt); err != nil || tnt.Spec.ContainerRegistries == nil {
+				return nil
+			}
+
+			return tnt.Spec.ContainerRegistries.Exact
+		}
+	}
+
+	It("should set the group policies, unless defined by the Tenant", func() {
+		Eventually(registries(member.GetName()), defaultTimeoutInterval, defaultPollInterval).Should(Equal([]string{"quay.io"}))
+		Eventually(registries(overriding.GetName()), defaultTimeoutInterval, defaultPollInterval).Should(Equal([]string{"docker.io"}))
+
+		Eventually(func() []string {
+			found := &capsulev1beta2.TenantGroup{}
+			if err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: group.GetName()}, found); err != nil {
+				return nil
+			}
+
+			return found.Status.Overridden
+		}, defaultTimeoutInterval, defaultPollInterval).Should(Equal([]string{"tenantgroup-overriding:containerRegistries"}))
+	})
+
+	It("should remove the group policies upon the group deletion", func() {
+		Eventually(registries(member.GetName()), defaultTimeoutInterval, defaultPollInterval).Should(Equal([]string{"quay.io"}))
+
+		Expect(k8sClient.Delete(context.TODO(), group)).Should(Succeed())
+
+		Eventually(registries(member.GetName()), defaultTimeoutInterval, defaultPollInterval).Should(BeNil())
+		Eventually(registries(overriding.GetName()), defaultTimeoutInterval, defaultPollInterval).Should(Equal([]string{"docker.io"}))
+	})
+})
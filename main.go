@@ -6,14 +6,19 @@ package main
 import (
 	goflag "flag"
 	"fmt"
+	"net/http"
 	"os"
+	"path/filepath"
 	goRuntime "runtime"
+	"time"
 
 	flag "github.com/spf13/pflag"
 	_ "go.uber.org/automaxprocs"
 	"go.uber.org/zap/zapcore"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -22,38 +27,58 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	capsulev1beta1 "github.com/projectcapsule/capsule/api/v1beta1"
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/controllers/capacity"
 	configcontroller "github.com/projectcapsule/capsule/controllers/config"
+	"github.com/projectcapsule/capsule/controllers/ingressquota"
+	"github.com/projectcapsule/capsule/controllers/isolation"
+	migrationcontroller "github.com/projectcapsule/capsule/controllers/migration"
 	podlabelscontroller "github.com/projectcapsule/capsule/controllers/pod"
 	"github.com/projectcapsule/capsule/controllers/pv"
 	rbaccontroller "github.com/projectcapsule/capsule/controllers/rbac"
+	"github.com/projectcapsule/capsule/controllers/reevaluation"
 	"github.com/projectcapsule/capsule/controllers/resources"
 	servicelabelscontroller "github.com/projectcapsule/capsule/controllers/servicelabels"
 	tenantcontroller "github.com/projectcapsule/capsule/controllers/tenant"
+	"github.com/projectcapsule/capsule/controllers/tenantgroup"
 	tlscontroller "github.com/projectcapsule/capsule/controllers/tls"
+	"github.com/projectcapsule/capsule/controllers/usage"
+	"github.com/projectcapsule/capsule/controllers/webhookrules"
+	"github.com/projectcapsule/capsule/pkg/cert"
+	"github.com/projectcapsule/capsule/pkg/compatibility"
 	"github.com/projectcapsule/capsule/pkg/configuration"
+	"github.com/projectcapsule/capsule/pkg/discovery"
+	"github.com/projectcapsule/capsule/pkg/history"
 	"github.com/projectcapsule/capsule/pkg/indexer"
+	"github.com/projectcapsule/capsule/pkg/migration"
+	"github.com/projectcapsule/capsule/pkg/warmup"
 	"github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/certificate"
 	"github.com/projectcapsule/capsule/pkg/webhook/defaults"
 	"github.com/projectcapsule/capsule/pkg/webhook/ingress"
 	namespacewebhook "github.com/projectcapsule/capsule/pkg/webhook/namespace"
 	"github.com/projectcapsule/capsule/pkg/webhook/networkpolicy"
 	"github.com/projectcapsule/capsule/pkg/webhook/node"
+	"github.com/projectcapsule/capsule/pkg/webhook/operator"
 	"github.com/projectcapsule/capsule/pkg/webhook/ownerreference"
 	"github.com/projectcapsule/capsule/pkg/webhook/pod"
 	"github.com/projectcapsule/capsule/pkg/webhook/pvc"
 	"github.com/projectcapsule/capsule/pkg/webhook/route"
+	"github.com/projectcapsule/capsule/pkg/webhook/secretproviderclass"
 	"github.com/projectcapsule/capsule/pkg/webhook/service"
 	"github.com/projectcapsule/capsule/pkg/webhook/tenant"
 	tntresource "github.com/projectcapsule/capsule/pkg/webhook/tenantresource"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+	"github.com/projectcapsule/capsule/pkg/webhook/workload"
 )
 
 var (
@@ -85,6 +110,30 @@ func main() {
 
 	var webhookPort int
 
+	var isolationInterval, isolationTimeout time.Duration
+
+	var isolationImage string
+
+	var isolationConcurrency int
+
+	var tokenReviewAudiences []string
+
+	var capacityMetrics, capacityHints bool
+
+	var enableReevaluation, reevaluationEvents bool
+
+	var enableMigration bool
+
+	var migrationBatchSize int64
+
+	var compatibilityCheck string
+
+	var operatorPodSelector string
+
+	var historyBackend, historyDSN string
+
+	var historyRetention, historyUsageInterval time.Duration
+
 	var goFlagSet goflag.FlagSet
 
 	flag.IntVar(&webhookPort, "webhook-port", 9443, "The port the webhook server binds to.")
@@ -94,6 +143,23 @@ func main() {
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.BoolVar(&version, "version", false, "Print the Capsule version and exit")
 	flag.StringVar(&configurationName, "configuration-name", "default", "The CapsuleConfiguration resource name to use")
+	flag.BoolVar(&enableMigration, "enable-storage-version-migration", false, "Migrate the stored Tenant objects to the CustomResourceDefinition storage version at startup, only when the CustomResourceDefinition status.storedVersions lists former versions")
+	flag.Int64Var(&migrationBatchSize, "storage-version-migration-batch-size", migration.DefaultBatchSize, "Number of Tenant objects rewritten per batch during the storage version migration")
+	flag.StringVar(&compatibilityCheck, "compatibility-check", "warn", "Check the stored Tenants against the running version at startup, writing a report in the capsule-compatibility-report ConfigMap: with enforce Capsule does not start when a stored Tenant is not compatible, with warn the Tenant update denials of the exact reported violations are turned into warnings, while disabled skips the check")
+	flag.DurationVar(&isolationInterval, "isolation-verification-interval", 0, "Interval between the verifications of the Tenants isolation with probe Pods, disabled when zero")
+	flag.DurationVar(&isolationTimeout, "isolation-verification-timeout", time.Minute, "Timeout for the isolation probe Pods to be ready or completed")
+	flag.StringVar(&isolationImage, "isolation-verification-image", "busybox:1.36", "Image of the isolation probe Pods, providing the busybox httpd and wget applets")
+	flag.IntVar(&isolationConcurrency, "isolation-verification-concurrency", 4, "Number of Tenants whose isolation is verified in parallel")
+	flag.StringVar(&operatorPodSelector, "operator-pod-selector", "", "Label selector of the Capsule Pods in its Namespace, restarted upon certificates rotation: when empty, the labels of the Pod named by the POD_NAME environment variable are used")
+	flag.StringSliceVar(&tokenReviewAudiences, "token-review-audiences", nil, "Audiences the bearer tokens authenticating the discovery and Tenant events requests must be issued for, the API server ones when empty")
+	flag.BoolVar(&enableReevaluation, "enable-policy-reevaluation", false, "Re-evaluate in background the existing objects of a Tenant upon the changes of its policies, reporting the violations in the Tenant status and metrics")
+	flag.BoolVar(&reevaluationEvents, "policy-reevaluation-events", false, "Emit an Event on the existing objects violating the Tenant policies upon their re-evaluation")
+	flag.StringVar(&historyBackend, "history-backend", string(history.BackendNone), "Storage backend of the audit records of the policy denials and of the Tenants usage history, one of none, memory, configmap, sqlite, or postgres")
+	flag.StringVar(&historyDSN, "history-dsn", os.Getenv("HISTORY_DSN"), "Database file path of the sqlite history backend, or connection string of the postgres one: defaults to the HISTORY_DSN environment variable")
+	flag.DurationVar(&historyRetention, "history-retention", 90*24*time.Hour, "Retention of the history records, disabling the pruning when zero")
+	flag.DurationVar(&historyUsageInterval, "history-usage-interval", time.Hour, "Interval between two samples of the Tenants usage stored in the history")
+	flag.BoolVar(&capacityMetrics, "capacity-metrics", false, "Expose the Pending and unschedulable Pods of each Tenant as metrics, watching the Pods of the whole cluster")
+	flag.BoolVar(&capacityHints, "capacity-hints", false, "Publish the Pending and unschedulable Pods of each Tenant as Tenant annotations, for the automation managing the node groups dedicated to the Tenants")
 
 	opts := zap.Options{
 		EncoderConfigOptions: append([]zap.EncoderConfigOption{}, func(config *zapcore.EncoderConfig) {
@@ -113,24 +179,57 @@ func main() {
 		os.Exit(0)
 	}
 
-	if namespace = os.Getenv("NAMESPACE"); len(namespace) == 0 {
+	// The NAMESPACE environment variable is still supported for the existing installations.
+	if namespace = os.Getenv("POD_NAMESPACE"); len(namespace) == 0 {
+		namespace = os.Getenv("NAMESPACE")
+	}
+
+	if len(namespace) == 0 {
 		setupLog.Error(fmt.Errorf("unable to determinate the Namespace Capsule is running on"), "unable to start manager")
 		os.Exit(1)
 	}
 
+	podName := os.Getenv("POD_NAME")
+
+	var podSelector labels.Selector
+
+	if len(operatorPodSelector) > 0 {
+		var selectorErr error
+
+		if podSelector, selectorErr = labels.Parse(operatorPodSelector); selectorErr != nil {
+			setupLog.Error(selectorErr, "unable to parse the operator Pod selector")
+			os.Exit(1)
+		}
+	}
+
+	if compatibilityCheck != "warn" && compatibilityCheck != "enforce" && compatibilityCheck != "disabled" {
+		setupLog.Error(fmt.Errorf("unsupported compatibility check mode %s", compatibilityCheck), "unable to start manager")
+		os.Exit(1)
+	}
+
 	if len(configurationName) == 0 {
 		setupLog.Error(fmt.Errorf("missing CapsuleConfiguration resource name"), "unable to start manager")
 		os.Exit(1)
 	}
 
+	// The controller-runtime default, made explicit since the certificates fingerprints are served from it.
+	webhookCertDir := filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs")
+
 	manager, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
 		Metrics: metricsserver.Options{
 			BindAddress: metricsAddr,
 		},
 		WebhookServer: ctrlwebhook.NewServer(ctrlwebhook.Options{
-			Port: webhookPort,
+			Port:    webhookPort,
+			CertDir: webhookCertDir,
 		}),
+		Cache: cache.Options{
+			ByObject: map[client.Object]cache.ByObject{
+				// Only the Tenant Warning Events are watched, to be delivered to the TenantGroup notification targets.
+				&corev1.Event{}: {Field: fields.SelectorFromSet(fields.Set{"involvedObject.kind": "Tenant", "type": corev1.EventTypeWarning})},
+			},
+		},
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "42c733ea.clastix.capsule.io",
 		HealthProbeBindAddress: ":10080",
@@ -169,6 +268,8 @@ func main() {
 			Log:           ctrl.Log.WithName("controllers").WithName("TLS"),
 			Namespace:     namespace,
 			Configuration: directCfg,
+			PodName:       podName,
+			PodSelector:   podSelector,
 		}
 
 		if err = tlsReconciler.SetupWithManager(manager); err != nil {
@@ -190,10 +291,11 @@ func main() {
 	}
 
 	if err = (&tenantcontroller.Manager{
-		RESTConfig: manager.GetConfig(),
-		Client:     manager.GetClient(),
-		Log:        ctrl.Log.WithName("controllers").WithName("Tenant"),
-		Recorder:   manager.GetEventRecorderFor("tenant-controller"),
+		RESTConfig:    manager.GetConfig(),
+		Client:        manager.GetClient(),
+		Log:           ctrl.Log.WithName("controllers").WithName("Tenant"),
+		Recorder:      manager.GetEventRecorderFor("tenant-controller"),
+		Configuration: cfg,
 	}).SetupWithManager(manager); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Tenant")
 		os.Exit(1)
@@ -209,6 +311,21 @@ func main() {
 		os.Exit(1)
 	}
 
+	// The webhooks are ready once started and the caches they rely on are warmed up.
+	cacheWarmUp := &warmup.Runnable{
+		Cache:   manager.GetCache(),
+		Log:     ctrl.Log.WithName("warmup"),
+		Objects: []client.Object{&capsulev1beta2.Tenant{}, &corev1.Namespace{}, &capsulev1beta2.CapsuleConfiguration{}},
+	}
+
+	if err = manager.Add(cacheWarmUp); err != nil {
+		setupLog.Error(err, "unable to add the cache warm-up")
+		os.Exit(1)
+	}
+
+	_ = manager.AddReadyzCheck("caches", cacheWarmUp.Check)
+	_ = manager.AddReadyzCheck("webhook", manager.GetWebhookServer().StartedChecker())
+
 	var kubeVersion *utilVersion.Version
 
 	if kubeVersion, err = utils.GetK8sVersion(); err != nil {
@@ -216,21 +333,63 @@ func main() {
 		os.Exit(1)
 	}
 
+	tenantHandlers := []webhook.Handler{tenant.NameHandler(), tenant.RoleBindingRegexHandler(), tenant.IngressClassRegexHandler(), tenant.StorageClassRegexHandler(), tenant.ContainerRegistryRegexHandler(), tenant.HostnameRegexHandler(), tenant.FreezedEmitter(), tenant.ServiceAccountNameHandler(), tenant.ForbiddenAnnotationsRegexHandler(), tenant.ProtectedHandler(), tenant.DeletionImpactHandler(cfg, manager.GetAPIReader()), tenant.MetaHandler(), tenant.ClusterAccessHandler()}
+	tenantWebhook := route.Tenant(tenantHandlers...)
+
+	if compatibilityCheck != "disabled" {
+		report, checkErr := (&compatibility.Checker{
+			Client:   directClient,
+			Decoder:  admission.NewDecoder(manager.GetScheme()),
+			Handlers: tenantHandlers,
+			Version:  GitTag,
+		}).Check(ctx)
+		if checkErr != nil {
+			setupLog.Error(checkErr, "unable to check the Tenants compatibility")
+			os.Exit(1)
+		}
+
+		for _, finding := range report.Findings {
+			setupLog.Info("Tenant is not compatible with the running version", "tenant", finding.Tenant, "code", finding.Code, "message", finding.Message)
+		}
+
+		if compatibilityCheck == "enforce" && len(report.Findings) > 0 {
+			setupLog.Error(fmt.Errorf("%d incompatible Tenant findings", len(report.Findings)), "refusing to start with Tenants not compatible with the running version: fix them, or start with --compatibility-check=warn")
+			os.Exit(1)
+		}
+
+		if err = manager.Add(&compatibility.Publisher{
+			Client:    directClient,
+			Log:       ctrl.Log.WithName("compatibility"),
+			Namespace: namespace,
+			Report:    report,
+		}); err != nil {
+			setupLog.Error(err, "unable to add the compatibility report publisher")
+			os.Exit(1)
+		}
+
+		if compatibilityCheck == "warn" && len(report.Findings) > 0 {
+			tenantWebhook = route.Tenant(compatibility.Tolerate(report, tenantHandlers...))
+		}
+	}
+
 	// webhooks: the order matters, don't change it and just append
 	webhooksList := append(
 		make([]webhook.Webhook, 0),
-		route.Pod(pod.ImagePullPolicy(), pod.ContainerRegistry(), pod.PriorityClass(), pod.RuntimeClass()),
+		route.Pod(pod.ImagePullPolicy(), pod.ContainerRegistry(), pod.PriorityClass(), pod.RuntimeClass(), pod.SecretsStore(), pod.MaxPodsPerNode()),
 		route.Namespace(utils.InCapsuleGroups(cfg, namespacewebhook.PatchHandler(), namespacewebhook.QuotaHandler(), namespacewebhook.FreezeHandler(cfg), namespacewebhook.PrefixHandler(cfg), namespacewebhook.UserMetadataHandler())),
-		route.Ingress(ingress.Class(cfg, kubeVersion), ingress.Hostnames(cfg), ingress.Collision(cfg), ingress.Wildcard()),
+		route.Ingress(ingress.Class(cfg, kubeVersion), ingress.Hostnames(cfg), ingress.Collision(cfg), ingress.Wildcard(), ingress.Quota()),
 		route.PVC(pvc.Validating(), pvc.PersistentVolumeReuse()),
 		route.Service(service.Handler()),
 		route.TenantResourceObjects(utils.InCapsuleGroups(cfg, tntresource.WriteOpsHandler())),
 		route.NetworkPolicy(utils.InCapsuleGroups(cfg, networkpolicy.Handler())),
-		route.Tenant(tenant.NameHandler(), tenant.RoleBindingRegexHandler(), tenant.IngressClassRegexHandler(), tenant.StorageClassRegexHandler(), tenant.ContainerRegistryRegexHandler(), tenant.HostnameRegexHandler(), tenant.FreezedEmitter(), tenant.ServiceAccountNameHandler(), tenant.ForbiddenAnnotationsRegexHandler(), tenant.ProtectedHandler(), tenant.MetaHandler()),
+		tenantWebhook,
 		route.OwnerReference(utils.InCapsuleGroups(cfg, ownerreference.Handler(cfg))),
-		route.Cordoning(tenant.CordoningHandler(cfg), tenant.ResourceCounterHandler(manager.GetClient())),
+		route.Cordoning(tenant.CordoningHandler(cfg), tenant.ObjectSizeHandler(), workload.HygieneHandler(), utils.InCapsuleGroups(cfg, operator.Handler()), tenant.ResourceCounterHandler(manager.GetClient())),
 		route.Node(utils.InCapsuleGroups(cfg, node.UserMetadataHandler(cfg, kubeVersion))),
 		route.Defaults(defaults.Handler(cfg, kubeVersion)),
+		route.SecretProviderClass(secretproviderclass.Handler()),
+		route.Certificate(certificate.Quota()),
+		route.TenantAuthor(tenant.AuthorHandler()),
 	)
 
 	nodeWebhookSupported, _ := utils.NodeWebhookSupported(kubeVersion)
@@ -238,11 +397,63 @@ func main() {
 		setupLog.Info("Disabling node labels verification webhook as current Kubernetes version doesn't have fix for CVE-2021-25735")
 	}
 
-	if err = webhook.Register(manager, webhooksList...); err != nil {
+	historyStore, err := history.Open(history.Backend(historyBackend), history.Options{
+		Client:    directClient,
+		Namespace: namespace,
+		DSN:       historyDSN,
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to open the history storage backend")
+		os.Exit(1)
+	}
+
+	var historyRecorder *history.Recorder
+
+	if historyStore != nil {
+		historyRecorder = history.NewRecorder(historyStore, ctrl.Log.WithName("history"))
+
+		if err = manager.Add(historyRecorder); err != nil {
+			setupLog.Error(err, "unable to add the history recorder")
+			os.Exit(1)
+		}
+
+		if err = manager.Add(&usage.Sampler{
+			Client:    manager.GetClient(),
+			Log:       ctrl.Log.WithName("controllers").WithName("Usage"),
+			Store:     historyStore,
+			Interval:  historyUsageInterval,
+			Retention: historyRetention,
+		}); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Usage")
+			os.Exit(1)
+		}
+	}
+
+	if err = webhook.Register(manager, historyRecorder, webhooksList...); err != nil {
 		setupLog.Error(err, "unable to setup webhooks")
 		os.Exit(1)
 	}
 
+	manager.GetWebhookServer().Register(discovery.Path, &discovery.Handler{
+		Client:        manager.GetClient(),
+		APIReader:     manager.GetAPIReader(),
+		Configuration: cfg,
+		Log:           ctrl.Log.WithName("discovery"),
+		Namespace:     namespace,
+		Audiences:     tokenReviewAudiences,
+	})
+
+	manager.GetWebhookServer().Register(discovery.EventsPath, &discovery.EventsHandler{
+		Client:    manager.GetClient(),
+		APIReader: manager.GetAPIReader(),
+		Log:       ctrl.Log.WithName("tenant-events"),
+		Audiences: tokenReviewAudiences,
+	})
+
+	manager.GetWebhookServer().Register(cert.FingerprintsPath, cert.FingerprintsHandler{
+		CertDir: webhookCertDir,
+	})
+
 	rbacManager := &rbaccontroller.Manager{
 		Log:           ctrl.Log.WithName("controllers").WithName("Rbac"),
 		Client:        manager.GetClient(),
@@ -291,6 +502,77 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = (&webhookrules.Manager{
+		Client:        manager.GetClient(),
+		Log:           ctrl.Log.WithName("controllers").WithName("WebhookRules"),
+		Configuration: cfg,
+	}).SetupWithManager(manager); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "WebhookRules")
+		os.Exit(1)
+	}
+
+	if err = (&ingressquota.Manager{
+		Client: manager.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("IngressQuota"),
+	}).SetupWithManager(manager); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "IngressQuota")
+		os.Exit(1)
+	}
+
+	if capacityMetrics || capacityHints {
+		if err = (&capacity.Manager{
+			Client:  manager.GetClient(),
+			Log:     ctrl.Log.WithName("controllers").WithName("Capacity"),
+			Metrics: capacityMetrics,
+			Hints:   capacityHints,
+		}).SetupWithManager(manager); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Capacity")
+			os.Exit(1)
+		}
+	}
+
+	if enableReevaluation {
+		if err = (&reevaluation.Manager{
+			Client:   manager.GetClient(),
+			Reader:   manager.GetAPIReader(),
+			Decoder:  admission.NewDecoder(manager.GetScheme()),
+			Log:      ctrl.Log.WithName("controllers").WithName("Reevaluation"),
+			Recorder: manager.GetEventRecorderFor("tenant-reevaluation"),
+			Events:   reevaluationEvents,
+			Kinds:    reevaluation.DefaultKinds(cfg, kubeVersion),
+		}).SetupWithManager(manager); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Reevaluation")
+			os.Exit(1)
+		}
+	}
+
+	if enableMigration {
+		if err = manager.Add(&migrationcontroller.Manager{
+			Client:    directClient,
+			Log:       ctrl.Log.WithName("controllers").WithName("StorageVersionMigration"),
+			CRDName:   cfg.TenantCRDName(),
+			BatchSize: migrationBatchSize,
+		}); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "StorageVersionMigration")
+			os.Exit(1)
+		}
+	}
+
+	if isolationInterval > 0 {
+		if err = manager.Add(&isolation.Verifier{
+			Client:      manager.GetClient(),
+			Log:         ctrl.Log.WithName("controllers").WithName("Isolation"),
+			Recorder:    manager.GetEventRecorderFor("isolation-verifier"),
+			Image:       isolationImage,
+			Interval:    isolationInterval,
+			Timeout:     isolationTimeout,
+			Concurrency: isolationConcurrency,
+		}); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Isolation")
+			os.Exit(1)
+		}
+	}
+
 	if err = (&configcontroller.Manager{
 		Log: ctrl.Log.WithName("controllers").WithName("CapsuleConfiguration"),
 	}).SetupWithManager(manager, configurationName); err != nil {
@@ -298,6 +580,40 @@ func main() {
 		os.Exit(1)
 	}
 
+	tenantSpecDefaults, err := tenantgroup.SpecDefaults(ctx, directClient)
+	if err != nil {
+		setupLog.Error(err, "unable to read the Tenant specification defaults")
+		os.Exit(1)
+	}
+
+	if err = (&tenantgroup.Manager{
+		Client:   manager.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName("TenantGroup"),
+		Defaults: tenantSpecDefaults,
+	}).SetupWithManager(manager); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TenantGroup")
+		os.Exit(1)
+	}
+
+	if err = (&tenantgroup.Notifications{
+		Client:     manager.GetClient(),
+		Log:        ctrl.Log.WithName("controllers").WithName("TenantGroupNotifications"),
+		Recorder:   manager.GetEventRecorderFor("tenantgroup-notifications"),
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}).SetupWithManager(manager); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TenantGroupNotifications")
+		os.Exit(1)
+	}
+
+	if err = (&tenantgroup.Members{
+		Client:   manager.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName("TenantGroupMembers"),
+		Defaults: tenantSpecDefaults,
+	}).SetupWithManager(manager); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TenantGroupMembers")
+		os.Exit(1)
+	}
+
 	if err = (&resources.Global{}).SetupWithManager(manager); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "resources.Global")
 		os.Exit(1)
@@ -314,4 +630,10 @@ func main() {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+
+	if historyStore != nil {
+		if err = historyStore.Close(); err != nil {
+			setupLog.Error(err, "unable to close the history storage backend")
+		}
+	}
 }
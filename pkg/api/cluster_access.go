@@ -0,0 +1,31 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+// +kubebuilder:object:generate=true
+
+type ClusterAccessSpec struct {
+	// Grants the Tenant owners the read access to the nodes selected by the Tenant node selector,
+	// restricted by resource name: no access is granted when the Tenant has no node selector. Optional.
+	Nodes bool `json:"nodes,omitempty"`
+	// Grants the Tenant owners the read access to the metrics of the nodes selected by the Tenant node selector,
+	// as served by the metrics.k8s.io API, restricted by resource name. Optional.
+	NodeMetrics bool `json:"nodeMetrics,omitempty"`
+	// Grants the Tenant owners the read access to the given cluster scoped resources, such as the custom resources
+	// dedicated to the Tenant, restricted by resource name: the namespaced resources are rejected, while the access to
+	// the resources not yet served by the API server is granted once they are known as cluster scoped. Optional.
+	Resources []ClusterResourceAccess `json:"resources,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+type ClusterResourceAccess struct {
+	// The API group of the resources, empty for the core group.
+	APIGroup string `json:"apiGroup,omitempty"`
+	// The plural name of the resources, such as clusterissuers.
+	Resource string `json:"resource"`
+	// The names of the resources the Tenant owners can read.
+	// +kubebuilder:validation:MinItems=1
+	ResourceNames []string `json:"resourceNames"`
+}
@@ -0,0 +1,121 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+const (
+	// ImmutableLabelReason used as reason string to deny the changes of the immutable labels.
+	ImmutableLabelReason = "ImmutableLabel"
+	// ImmutableAnnotationReason used as reason string to deny the changes of the immutable annotations.
+	ImmutableAnnotationReason = "ImmutableAnnotation"
+)
+
+// ImmutableListSpec selects the metadata keys whose values cannot be added, changed, or removed after the creation.
+// +kubebuilder:object:generate=true
+type ImmutableListSpec struct {
+	Exact []string `json:"keys,omitempty"`
+	Regex string   `json:"keysRegex,omitempty"`
+}
+
+// matcher returns the function checking whether a key is immutable,
+// or an error when the regular expression cannot be compiled.
+func (in ImmutableListSpec) matcher() (func(key string) bool, error) {
+	var regex *regexp.Regexp
+
+	if len(in.Regex) > 0 {
+		var err error
+
+		if regex, err = regexp.Compile(in.Regex); err != nil {
+			return nil, fmt.Errorf("unable to compile the immutable keys regex %q: %w", in.Regex, err)
+		}
+	}
+
+	return func(key string) bool {
+		for _, exact := range in.Exact {
+			if exact == key {
+				return true
+			}
+		}
+
+		return regex != nil && regex.MatchString(key)
+	}, nil
+}
+
+type ImmutableError struct {
+	kind     string
+	key      string
+	oldValue *string
+	newValue *string
+}
+
+func (i ImmutableError) Error() string {
+	switch {
+	case i.oldValue == nil:
+		return fmt.Sprintf("the %s %s is immutable for the current Tenant: it cannot be added with value %q after the Namespace creation", i.kind, i.key, *i.newValue)
+	case i.newValue == nil:
+		return fmt.Sprintf("the %s %s is immutable for the current Tenant: it cannot be removed, its value is %q", i.kind, i.key, *i.oldValue)
+	default:
+		return fmt.Sprintf("the %s %s is immutable for the current Tenant: it cannot be changed from %q to %q", i.kind, i.key, *i.oldValue, *i.newValue)
+	}
+}
+
+// ValidateImmutable returns an ImmutableError for the first immutable key, in alphabetical order, added, changed,
+// or removed between the old and the new metadata: the kind, such as label, is reported in the error message.
+// Any other error is returned when the immutable keys regular expression is invalid.
+func ValidateImmutable(kind string, oldMetadata, newMetadata map[string]string, immutableList ImmutableListSpec) error {
+	if len(immutableList.Exact) == 0 && len(immutableList.Regex) == 0 {
+		return nil
+	}
+
+	immutable, err := immutableList.matcher()
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(oldMetadata)+len(newMetadata))
+
+	for key := range oldMetadata {
+		keys = append(keys, key)
+	}
+
+	for key := range newMetadata {
+		if _, ok := oldMetadata[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !immutable(key) {
+			continue
+		}
+
+		oldValue, oldOk := oldMetadata[key]
+		newValue, newOk := newMetadata[key]
+
+		if oldOk == newOk && oldValue == newValue {
+			continue
+		}
+
+		err := ImmutableError{kind: kind, key: key}
+
+		if oldOk {
+			err.oldValue = &oldValue
+		}
+
+		if newOk {
+			err.newValue = &newValue
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateImmutable(t *testing.T) {
+	immutable := ImmutableListSpec{
+		Exact: []string{"capsule.clastix.io/tenant"},
+		Regex: "^pod-security.kubernetes.io/",
+	}
+
+	old := map[string]string{
+		"capsule.clastix.io/tenant":          "oil",
+		"pod-security.kubernetes.io/enforce": "restricted",
+		"team":                               "backend",
+	}
+
+	tests := []struct {
+		name     string
+		metadata map[string]string
+		err      string
+	}{
+		{
+			name: "mutable key changed",
+			metadata: map[string]string{
+				"capsule.clastix.io/tenant":          "oil",
+				"pod-security.kubernetes.io/enforce": "restricted",
+				"team":                               "frontend",
+			},
+		},
+		{
+			name: "immutable key changed",
+			metadata: map[string]string{
+				"capsule.clastix.io/tenant":          "oil",
+				"pod-security.kubernetes.io/enforce": "privileged",
+			},
+			err: `the label pod-security.kubernetes.io/enforce is immutable for the current Tenant: it cannot be changed from "restricted" to "privileged"`,
+		},
+		{
+			name: "immutable key removed",
+			metadata: map[string]string{
+				"pod-security.kubernetes.io/enforce": "restricted",
+			},
+			err: `the label capsule.clastix.io/tenant is immutable for the current Tenant: it cannot be removed, its value is "oil"`,
+		},
+		{
+			name: "immutable key added",
+			metadata: map[string]string{
+				"capsule.clastix.io/tenant":          "oil",
+				"pod-security.kubernetes.io/enforce": "restricted",
+				"pod-security.kubernetes.io/warn":    "baseline",
+			},
+			err: `the label pod-security.kubernetes.io/warn is immutable for the current Tenant: it cannot be added with value "baseline" after the Namespace creation`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateImmutable("label", old, tc.metadata, immutable)
+			if len(tc.err) == 0 {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+
+	assert.NoError(t, ValidateImmutable("label", old, nil, ImmutableListSpec{}))
+
+	err := ValidateImmutable("label", old, nil, ImmutableListSpec{Regex: "["})
+	assert.Error(t, err)
+	assert.False(t, errors.As(err, &ImmutableError{}))
+}
@@ -7,8 +7,33 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 )
 
+const (
+	NetworkPolicyProtectionObject NetworkPolicyProtection = "Object"
+	NetworkPolicyProtectionFields NetworkPolicyProtection = "Fields"
+)
+
+// +kubebuilder:validation:Enum=Object;Fields
+type NetworkPolicyProtection string
+
 // +kubebuilder:object:generate=true
 
 type NetworkPolicySpec struct {
 	Items []networkingv1.NetworkPolicySpec `json:"items,omitempty"`
+	// Defines how the NetworkPolicies generated by Capsule in the Tenant Namespaces are protected from the Tenant owners.
+	//
+	//
+	// - Object: the NetworkPolicies cannot be updated, nor deleted.
+	//
+	// - Fields: the NetworkPolicies can be updated as long as the fields managed by Capsule, and the ingress and egress
+	// rules it generates, are preserved, allowing the Tenant owners to append further rules, and to change or remove them later.
+	// The NetworkPolicies cannot be deleted.
+	//
+	//
+	// Optional: defaults to Object.
+	Protection NetworkPolicyProtection `json:"protection,omitempty"`
+}
+
+// FieldsProtection reports whether the NetworkPolicies are protected at field level.
+func (in *NetworkPolicySpec) FieldsProtection() bool {
+	return in.Protection == NetworkPolicyProtectionFields
 }
@@ -0,0 +1,61 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const ObjectSizeLimitWildcard = "*"
+
+// +kubebuilder:object:generate=true
+
+type ObjectSizeLimitSpec struct {
+	// API group of the limited objects, empty for the core group: the wildcard "*" matches any group.
+	Group string `json:"group,omitempty"`
+	// Kind of the limited objects, such as ConfigMap or Secret: the wildcard "*" matches any kind.
+	Kind string `json:"kind"`
+	// Maximum size of the serialized objects, such as 256Ki.
+	MaxSize resource.Quantity `json:"maxSize"`
+}
+
+type ObjectSizeLimitsSpec []ObjectSizeLimitSpec
+
+// LimitFor returns the limit applied to the objects of the given kind, nil if not limited.
+// When more limits match, the most specific one is returned: an exact kind prevails over an exact group.
+func (in ObjectSizeLimitsSpec) LimitFor(gk schema.GroupKind) *ObjectSizeLimitSpec {
+	var (
+		limit *ObjectSizeLimitSpec
+		score = -1
+	)
+
+	for i := range in {
+		item := in[i]
+
+		current := 0
+
+		switch item.Kind {
+		case gk.Kind:
+			current += 2
+		case ObjectSizeLimitWildcard:
+		default:
+			continue
+		}
+
+		switch item.Group {
+		case gk.Group:
+			current++
+		case ObjectSizeLimitWildcard:
+		default:
+			continue
+		}
+
+		if current > score {
+			limit, score = &in[i], current
+		}
+	}
+
+	return limit
+}
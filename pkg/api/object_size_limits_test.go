@@ -0,0 +1,42 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestObjectSizeLimitsSpec_LimitFor(t *testing.T) {
+	limits := ObjectSizeLimitsSpec{
+		{Group: "*", Kind: "*", MaxSize: resource.MustParse("1Mi")},
+		{Group: "example.com", Kind: "*", MaxSize: resource.MustParse("512Ki")},
+		{Group: "*", Kind: "Widget", MaxSize: resource.MustParse("128Ki")},
+		{Kind: "ConfigMap", MaxSize: resource.MustParse("256Ki")},
+		{Group: "example.com", Kind: "Widget", MaxSize: resource.MustParse("64Ki")},
+	}
+
+	for _, tc := range []struct {
+		GroupKind schema.GroupKind
+		Expected  string
+	}{
+		{schema.GroupKind{Kind: "ConfigMap"}, "256Ki"},
+		{schema.GroupKind{Kind: "Secret"}, "1Mi"},
+		{schema.GroupKind{Group: "example.com", Kind: "Gadget"}, "512Ki"},
+		{schema.GroupKind{Group: "other.com", Kind: "Widget"}, "128Ki"},
+		{schema.GroupKind{Group: "example.com", Kind: "Widget"}, "64Ki"},
+		{schema.GroupKind{Group: "apps", Kind: "ConfigMap"}, "1Mi"},
+	} {
+		limit := limits.LimitFor(tc.GroupKind)
+		if assert.NotNil(t, limit, tc.GroupKind.String()) {
+			assert.Equal(t, tc.Expected, limit.MaxSize.String(), tc.GroupKind.String())
+		}
+	}
+
+	assert.Nil(t, ObjectSizeLimitsSpec{{Kind: "ConfigMap", MaxSize: resource.MustParse("1Ki")}}.LimitFor(schema.GroupKind{Kind: "Secret"}))
+	assert.Nil(t, ObjectSizeLimitsSpec(nil).LimitFor(schema.GroupKind{Kind: "Secret"}))
+}
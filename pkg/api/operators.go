@@ -0,0 +1,100 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const OLMGroup = "operators.coreos.com"
+
+//nolint:gochecknoglobals
+var (
+	OLMOperatorGroupKind = schema.GroupKind{Group: OLMGroup, Kind: "OperatorGroup"}
+	OLMSubscriptionKind  = schema.GroupKind{Group: OLMGroup, Kind: "Subscription"}
+)
+
+// +kubebuilder:object:generate=true
+
+type OperatorsSpec struct {
+	// Allows the Tenant owners to install namespaced operators in the Tenant namespaces,
+	// creating OLM OperatorGroup and Subscription resources, or the additional operator resources.
+	// OperatorGroup resources can only target the Tenant namespaces.
+	//+kubebuilder:default:=false
+	AllowInstallation bool `json:"allowInstallation,omitempty"`
+	// Specifies the OLM CatalogSources the Subscriptions can install operators from, in the <namespace>/<name> format.
+	// Optional: when unset, any catalog is allowed.
+	AllowedCatalogs *AllowedListSpec `json:"allowedCatalogs,omitempty"`
+	// Specifies additional kinds of resources installing operators, such as the custom resources of a Helm operator,
+	// governed as the OLM ones. Optional.
+	AdditionalResources []OperatorResourceSpec `json:"additionalResources,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+type OperatorResourceSpec struct {
+	// API group of the resource.
+	Group string `json:"group"`
+	// Kind of the resource.
+	Kind string `json:"kind"`
+}
+
+// IsOperatorResource returns true if the objects of the given kind install an operator.
+func (in *OperatorsSpec) IsOperatorResource(gk schema.GroupKind) bool {
+	if gk == OLMOperatorGroupKind || gk == OLMSubscriptionKind {
+		return true
+	}
+
+	for _, resource := range in.AdditionalResources {
+		if resource.Group == gk.Group && resource.Kind == gk.Kind {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidateCatalog checks the CatalogSource of a Subscription against the allowed ones.
+func (in *OperatorsSpec) ValidateCatalog(sourceNamespace, source string) error {
+	catalog := sourceNamespace + "/" + source
+
+	if in.AllowedCatalogs == nil || in.AllowedCatalogs.Match(catalog) {
+		return nil
+	}
+
+	return NewOperatorCatalogForbiddenError(catalog, *in.AllowedCatalogs)
+}
+
+type OperatorCatalogForbiddenError struct {
+	catalog string
+	spec    AllowedListSpec
+}
+
+func NewOperatorCatalogForbiddenError(catalog string, spec AllowedListSpec) error {
+	return &OperatorCatalogForbiddenError{
+		catalog: catalog,
+		spec:    spec,
+	}
+}
+
+func (f OperatorCatalogForbiddenError) Error() (err string) {
+	err = fmt.Sprintf("operator catalog %s is forbidden for the current Tenant: ", f.catalog)
+
+	var extra []string
+
+	if len(f.spec.Exact) > 0 {
+		extra = append(extra, fmt.Sprintf("use one from the following list (%s)", strings.Join(f.spec.Exact, ", ")))
+	}
+
+	if len(f.spec.Regex) > 0 {
+		extra = append(extra, fmt.Sprintf("use one matching the following regex (%s)", f.spec.Regex))
+	}
+
+	err += strings.Join(extra, " or ")
+
+	return
+}
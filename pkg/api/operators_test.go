@@ -0,0 +1,52 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestOperatorsSpec_IsOperatorResource(t *testing.T) {
+	spec := OperatorsSpec{
+		AdditionalResources: []OperatorResourceSpec{{Group: "helm.example.com", Kind: "HelmOperator"}},
+	}
+
+	for gk, expected := range map[schema.GroupKind]bool{
+		OLMOperatorGroupKind: true,
+		OLMSubscriptionKind:  true,
+		{Group: "helm.example.com", Kind: "HelmOperator"}: true,
+		{Group: OLMGroup, Kind: "CatalogSource"}:          false,
+		{Group: "helm.example.com", Kind: "Other"}:        false,
+		{Kind: "ConfigMap"}:                               false,
+	} {
+		assert.Equal(t, expected, spec.IsOperatorResource(gk), gk.String())
+	}
+}
+
+func TestOperatorsSpec_ValidateCatalog(t *testing.T) {
+	type tc struct {
+		Spec            OperatorsSpec
+		SourceNamespace string
+		Source          string
+		Allowed         bool
+	}
+
+	for _, tc := range []tc{
+		{OperatorsSpec{}, "olm", "operatorhubio-catalog", true},
+		{OperatorsSpec{AllowedCatalogs: &AllowedListSpec{Exact: []string{"olm/operatorhubio-catalog"}}}, "olm", "operatorhubio-catalog", true},
+		{OperatorsSpec{AllowedCatalogs: &AllowedListSpec{Exact: []string{"olm/operatorhubio-catalog"}}}, "oil-production", "operatorhubio-catalog", false},
+		{OperatorsSpec{AllowedCatalogs: &AllowedListSpec{Regex: "^olm/.*$"}}, "olm", "community", true},
+		{OperatorsSpec{AllowedCatalogs: &AllowedListSpec{Regex: "^olm/.*$"}}, "oil-production", "custom", false},
+	} {
+		err := tc.Spec.ValidateCatalog(tc.SourceNamespace, tc.Source)
+		if tc.Allowed {
+			assert.NoError(t, err)
+		} else {
+			assert.Error(t, err)
+		}
+	}
+}
@@ -3,9 +3,19 @@
 
 package api
 
+// NodeSlotLabel labels the Pods of the Tenants with a maximum number of Pods per node with their node slot.
+const NodeSlotLabel = "capsule.clastix.io/node-slot"
+
 // +kubebuilder:object:generate=true
 
 type PodOptions struct {
 	// Specifies additional labels and annotations the Capsule operator places on any Pod resource in the Tenant. Optional.
 	AdditionalMetadata *AdditionalMetadataSpec `json:"additionalMetadata,omitempty"`
+	// Specifies the maximum number of Pods of the Tenant scheduled on a single node, counted across all the Tenant
+	// Namespaces, protecting the shared nodes from the saturation caused by a single Tenant. Capsule labels the created
+	// Pods with one of as many node slots, and injects a required Pod anti-affinity on the hostname topology key towards
+	// the Tenant Pods of the same slot: the scheduler places at most one Pod per slot, and thus the maximum, on each node.
+	// The Pods bypassing the scheduler with an assigned node are denied. Optional.
+	// +kubebuilder:validation:Minimum=1
+	MaxPodsPerNode *int32 `json:"maxPodsPerNode,omitempty"`
 }
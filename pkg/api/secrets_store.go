@@ -0,0 +1,158 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// +kubebuilder:object:generate=true
+
+type SecretsStoreSpec struct {
+	// Specifies the allowed providers for the SecretProviderClass resources used in the Tenant, such as vault, azure, aws, or gcp.
+	// Optional.
+	AllowedProviders *AllowedListSpec `json:"allowedProviders,omitempty"`
+	// Restricts the values of the SecretProviderClass parameters to the given prefixes,
+	// e.g. allowing only the Vault paths reserved to the Tenant.
+	// Optional.
+	AllowedParameters []SecretProviderParameterSpec `json:"allowedParameters,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+type SecretProviderParameterSpec struct {
+	// Name of the SecretProviderClass parameter, such as roleName or objects.
+	Name string `json:"name"`
+	// When the parameter holds a YAML list of objects, as the objects parameter of the Vault provider,
+	// the key of each object whose value must be checked, such as secretPath.
+	// Optional.
+	Key string `json:"key,omitempty"`
+	// The allowed prefixes of the parameter value.
+	// +kubebuilder:validation:MinItems=1
+	Prefixes []string `json:"prefixes"`
+}
+
+// hasAllowedPrefix matches the value, cleaned as a path, against the allowed prefixes:
+// the values with parent directory segments are rejected, since the providers may resolve them out of the prefixes.
+func (in *SecretProviderParameterSpec) hasAllowedPrefix(value string) bool {
+	if slices.Contains(strings.Split(value, "/"), "..") {
+		return false
+	}
+
+	if len(value) > 0 {
+		cleaned := path.Clean(value)
+		// Cleaning drops the trailing slash, possibly matched by a prefix.
+		if strings.HasSuffix(value, "/") && cleaned != "/" {
+			cleaned += "/"
+		}
+
+		value = cleaned
+	}
+
+	for _, prefix := range in.Prefixes {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidateProvider checks the provider of a SecretProviderClass against the allowed ones.
+func (in *SecretsStoreSpec) ValidateProvider(provider string) error {
+	if in.AllowedProviders == nil || in.AllowedProviders.Match(provider) {
+		return nil
+	}
+
+	return NewSecretProviderForbiddenError(provider, *in.AllowedProviders)
+}
+
+// Validate checks the parameters of a SecretProviderClass against the allowed prefixes,
+// returning an error for the first value that is not allowed.
+func (in *SecretsStoreSpec) Validate(parameters map[string]string) error {
+	for _, spec := range in.AllowedParameters {
+		raw, ok := parameters[spec.Name]
+		if !ok {
+			continue
+		}
+
+		if len(spec.Key) == 0 {
+			if !spec.hasAllowedPrefix(raw) {
+				return NewSecretProviderParameterForbiddenError(spec, raw)
+			}
+
+			continue
+		}
+
+		var objects []map[string]interface{}
+		if err := yaml.Unmarshal([]byte(raw), &objects); err != nil {
+			return fmt.Errorf("cannot parse SecretProviderClass parameter %s: %w", spec.Name, err)
+		}
+
+		for _, object := range objects {
+			value, _ := object[spec.Key].(string)
+			if !spec.hasAllowedPrefix(value) {
+				return NewSecretProviderParameterForbiddenError(spec, value)
+			}
+		}
+	}
+
+	return nil
+}
+
+type SecretProviderParameterForbiddenError struct {
+	spec  SecretProviderParameterSpec
+	value string
+}
+
+func NewSecretProviderParameterForbiddenError(spec SecretProviderParameterSpec, value string) error {
+	return &SecretProviderParameterForbiddenError{
+		spec:  spec,
+		value: value,
+	}
+}
+
+func (f SecretProviderParameterForbiddenError) Error() string {
+	name := f.spec.Name
+	if len(f.spec.Key) > 0 {
+		name += "." + f.spec.Key
+	}
+
+	return fmt.Sprintf("SecretProviderClass parameter %s value %q is forbidden for the current Tenant: use one of the following prefixes (%s)", name, f.value, strings.Join(f.spec.Prefixes, ", "))
+}
+
+type SecretProviderForbiddenError struct {
+	provider string
+	spec     AllowedListSpec
+}
+
+func NewSecretProviderForbiddenError(provider string, spec AllowedListSpec) error {
+	return &SecretProviderForbiddenError{
+		provider: provider,
+		spec:     spec,
+	}
+}
+
+func (f SecretProviderForbiddenError) Error() (err string) {
+	err = fmt.Sprintf("Secrets Store provider %s is forbidden for the current Tenant: ", f.provider)
+
+	var extra []string
+
+	if len(f.spec.Exact) > 0 {
+		extra = append(extra, fmt.Sprintf("use one from the following list (%s)", strings.Join(f.spec.Exact, ", ")))
+	}
+
+	if len(f.spec.Regex) > 0 {
+		extra = append(extra, fmt.Sprintf("use one matching the following regex (%s)", f.spec.Regex))
+	}
+
+	err += strings.Join(extra, " or ")
+
+	return
+}
@@ -0,0 +1,119 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretsStoreSpec_Validate(t *testing.T) {
+	spec := SecretsStoreSpec{
+		AllowedParameters: []SecretProviderParameterSpec{
+			{
+				Name:     "roleName",
+				Prefixes: []string{"tenant-a-"},
+			},
+			{
+				Name:     "objects",
+				Key:      "secretPath",
+				Prefixes: []string{"secret/data/tenant-a/", "kv/tenant-a/"},
+			},
+		},
+	}
+
+	type tc struct {
+		Parameters map[string]string
+		Valid      bool
+	}
+
+	for _, tc := range []tc{
+		{
+			map[string]string{"vaultAddress": "https://vault:8200"},
+			true,
+		},
+		{
+			map[string]string{"roleName": "tenant-a-reader"},
+			true,
+		},
+		{
+			map[string]string{"roleName": "tenant-b-reader"},
+			false,
+		},
+		{
+			map[string]string{"objects": `
+- objectName: "db-password"
+  secretPath: "secret/data/tenant-a/db"
+  secretKey: "password"
+- objectName: "api-key"
+  secretPath: "kv/tenant-a/api"
+  secretKey: "key"
+`},
+			true,
+		},
+		{
+			map[string]string{"objects": `
+- objectName: "db-password"
+  secretPath: "secret/data/tenant-a/db"
+- objectName: "stolen"
+  secretPath: "secret/data/tenant-b/db"
+`},
+			false,
+		},
+		{
+			map[string]string{"objects": `
+- objectName: "escaping"
+  secretPath: "secret/data/tenant-a/../tenant-b/db"
+`},
+			false,
+		},
+		{
+			map[string]string{"objects": `
+- objectName: "escaping"
+  secretPath: "secret/data/tenant-a/.."
+`},
+			false,
+		},
+		{
+			map[string]string{"objects": `
+- objectName: "not clean"
+  secretPath: "secret/data/tenant-a/./db"
+- objectName: "double slash"
+  secretPath: "kv//tenant-a/api"
+`},
+			true,
+		},
+		{
+			map[string]string{"objects": `
+- objectName: "dot prefix"
+  secretPath: "./secret/data/tenant-b/db"
+`},
+			false,
+		},
+		{
+			map[string]string{"objects": `
+- objectName: "missing-path"
+`},
+			false,
+		},
+		{
+			map[string]string{"objects": "not: a list"},
+			false,
+		},
+	} {
+		err := spec.Validate(tc.Parameters)
+		assert.Equal(t, tc.Valid, err == nil, "parameters %v", tc.Parameters)
+	}
+}
+
+func TestSecretsStoreSpec_ValidateProvider(t *testing.T) {
+	spec := SecretsStoreSpec{}
+	assert.NoError(t, spec.ValidateProvider("azure"))
+
+	spec.AllowedProviders = &AllowedListSpec{Exact: []string{"vault"}, Regex: "^gcp"}
+	assert.NoError(t, spec.ValidateProvider("vault"))
+	assert.NoError(t, spec.ValidateProvider("gcp"))
+	assert.Error(t, spec.ValidateProvider("azure"))
+}
@@ -0,0 +1,14 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+// +kubebuilder:object:generate=true
+
+type DefaultServiceAccountSpec struct {
+	// Names of the Secrets, living in each Namespace of the Tenant, the default ServiceAccount uses to pull the container images.
+	// Secrets added by other parties to the default ServiceAccount are preserved. Optional.
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+	// Specifies whether the token of the default ServiceAccount is automatically mounted in the Pods. Optional.
+	AutomountServiceAccountToken *bool `json:"automountServiceAccountToken,omitempty"`
+}
@@ -12,6 +12,8 @@ type ServiceOptions struct {
 	AllowedServices *AllowedServices `json:"allowedServices,omitempty"`
 	// Specifies the external IPs that can be used in Services with type ClusterIP. An empty list means no IPs are allowed. Optional.
 	ExternalServiceIPs *ExternalServiceIPsSpec `json:"externalIPs,omitempty"`
+	// Restricts the ports and the protocols of the Service resources, for the environments with network compliance rules. Optional.
+	Ports *ServicePortsSpec `json:"ports,omitempty"`
 	// Define the labels that a Tenant Owner cannot set for their Service resources.
 	ForbiddenLabels ForbiddenListSpec `json:"forbiddenLabels,omitempty"`
 	// Define the annotations that a Tenant Owner cannot set for their Service resources.
@@ -0,0 +1,85 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// +kubebuilder:object:generate=true
+
+type ServicePortsSpec struct {
+	// Specifies the protocols allowed for the Service ports, such as TCP and UDP, denying the other ones, such as SCTP.
+	// An empty list means all the protocols are allowed. Optional.
+	AllowedProtocols []corev1.Protocol `json:"allowedProtocols,omitempty"`
+	// Specifies the ranges the Service ports must belong to, such as 1024-65535 to deny the privileged ports.
+	// An empty list means all the ports are allowed. Optional.
+	AllowedRanges []ServicePortRange `json:"allowedRanges,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+type ServicePortRange struct {
+	// The first port of the range, inclusive.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	From int32 `json:"from"`
+	// The last port of the range, inclusive.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	To int32 `json:"to"`
+}
+
+func (in ServicePortRange) String() string {
+	return fmt.Sprintf("%d-%d", in.From, in.To)
+}
+
+// ValidateProtocol checks the protocol of a Service port against the allowed ones,
+// the port protocol defaulting to TCP when empty.
+func (in *ServicePortsSpec) ValidateProtocol(port corev1.ServicePort) error {
+	if len(in.AllowedProtocols) == 0 {
+		return nil
+	}
+
+	protocol := port.Protocol
+	if len(protocol) == 0 {
+		protocol = corev1.ProtocolTCP
+	}
+
+	for _, allowed := range in.AllowedProtocols {
+		if allowed == protocol {
+			return nil
+		}
+	}
+
+	protocols := make([]string, 0, len(in.AllowedProtocols))
+	for _, allowed := range in.AllowedProtocols {
+		protocols = append(protocols, string(allowed))
+	}
+
+	return fmt.Errorf("service port %d uses the forbidden protocol %s: allowed protocols are %s", port.Port, protocol, strings.Join(protocols, ", "))
+}
+
+// ValidatePort checks the port of a Service port against the allowed ranges.
+func (in *ServicePortsSpec) ValidatePort(port corev1.ServicePort) error {
+	if len(in.AllowedRanges) == 0 {
+		return nil
+	}
+
+	for _, r := range in.AllowedRanges {
+		if port.Port >= r.From && port.Port <= r.To {
+			return nil
+		}
+	}
+
+	ranges := make([]string, 0, len(in.AllowedRanges))
+	for _, r := range in.AllowedRanges {
+		ranges = append(ranges, r.String())
+	}
+
+	return fmt.Errorf("service port %d is forbidden: allowed port ranges are %s", port.Port, strings.Join(ranges, ", "))
+}
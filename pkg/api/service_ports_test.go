@@ -0,0 +1,31 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestServicePortsSpec(t *testing.T) {
+	spec := &ServicePortsSpec{
+		AllowedProtocols: []corev1.Protocol{corev1.ProtocolTCP, corev1.ProtocolUDP},
+		AllowedRanges:    []ServicePortRange{{From: 1024, To: 65535}},
+	}
+
+	assert.NoError(t, spec.ValidateProtocol(corev1.ServicePort{Port: 8080}))
+	assert.NoError(t, spec.ValidateProtocol(corev1.ServicePort{Port: 53, Protocol: corev1.ProtocolUDP}))
+	assert.Error(t, spec.ValidateProtocol(corev1.ServicePort{Port: 9999, Protocol: corev1.ProtocolSCTP}))
+
+	assert.NoError(t, spec.ValidatePort(corev1.ServicePort{Port: 1024}))
+	assert.NoError(t, spec.ValidatePort(corev1.ServicePort{Port: 65535}))
+	assert.Error(t, spec.ValidatePort(corev1.ServicePort{Port: 443}))
+
+	empty := &ServicePortsSpec{}
+
+	assert.NoError(t, empty.ValidateProtocol(corev1.ServicePort{Port: 9999, Protocol: corev1.ProtocolSCTP}))
+	assert.NoError(t, empty.ValidatePort(corev1.ServicePort{Port: 80}))
+}
@@ -0,0 +1,94 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// +kubebuilder:validation:Enum=Warn;Enforce
+type WorkloadHygieneMode string
+
+const (
+	WorkloadHygieneModeWarn    WorkloadHygieneMode = "Warn"
+	WorkloadHygieneModeEnforce WorkloadHygieneMode = "Enforce"
+)
+
+// +kubebuilder:object:generate=true
+
+type WorkloadHygieneSpec struct {
+	// +kubebuilder:default=Warn
+	// With Warn, the workloads not compliant with the enabled rules are admitted with warnings,
+	// while with Enforce they are denied. Possible values are "Warn", "Enforce".
+	Mode WorkloadHygieneMode `json:"mode,omitempty"`
+	// Require a liveness probe for the containers of the long-running workloads, Jobs and CronJobs are not checked.
+	RequireLivenessProbe bool `json:"requireLivenessProbe,omitempty"`
+	// Require a readiness probe for the containers of the long-running workloads, Jobs and CronJobs are not checked.
+	RequireReadinessProbe bool `json:"requireReadinessProbe,omitempty"`
+	// Forbid container images with the latest tag, or with no tag nor digest.
+	ForbidLatestTag bool `json:"forbidLatestTag,omitempty"`
+	// Labels keys required on the workloads, such as app.kubernetes.io/name.
+	RequiredLabels []string `json:"requiredLabels,omitempty"`
+}
+
+// IsEnforced returns true if the violations must be denied, rather than reported as warnings.
+func (in *WorkloadHygieneSpec) IsEnforced() bool {
+	return in.Mode == WorkloadHygieneModeEnforce
+}
+
+// Violations returns the rules violated by a workload with the given labels and Pod specification:
+// the probes are required only for the long-running ones.
+func (in *WorkloadHygieneSpec) Violations(labels map[string]string, spec corev1.PodSpec, longRunning bool) (violations []string) {
+	for _, key := range in.RequiredLabels {
+		if _, ok := labels[key]; !ok {
+			violations = append(violations, fmt.Sprintf("missing required label %s", key))
+		}
+	}
+
+	for _, container := range spec.Containers {
+		if longRunning && in.RequireLivenessProbe && container.LivenessProbe == nil {
+			violations = append(violations, fmt.Sprintf("container %s has no liveness probe", container.Name))
+		}
+
+		if longRunning && in.RequireReadinessProbe && container.ReadinessProbe == nil {
+			violations = append(violations, fmt.Sprintf("container %s has no readiness probe", container.Name))
+		}
+	}
+
+	if in.ForbidLatestTag {
+		containers := make([]corev1.Container, 0, len(spec.InitContainers)+len(spec.Containers))
+		containers = append(containers, spec.InitContainers...)
+		containers = append(containers, spec.Containers...)
+
+		for _, container := range containers {
+			if IsLatestImage(container.Image) {
+				violations = append(violations, fmt.Sprintf("container %s image %s uses the latest tag", container.Name, container.Image))
+			}
+		}
+	}
+
+	return violations
+}
+
+// IsLatestImage returns true if the image refers to the latest tag, explicitly or by having no tag nor digest.
+func IsLatestImage(image string) bool {
+	if strings.Contains(image, "@") {
+		return false
+	}
+
+	name := image
+	if i := strings.LastIndex(image, "/"); i >= 0 {
+		name = image[i+1:]
+	}
+
+	i := strings.LastIndex(name, ":")
+	if i < 0 {
+		return true
+	}
+
+	return name[i+1:] == "latest"
+}
@@ -0,0 +1,47 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestIsLatestImage(t *testing.T) {
+	for image, expected := range map[string]bool{
+		"nginx":                             true,
+		"nginx:latest":                      true,
+		"nginx:1.25":                        false,
+		"registry.example.com:5000/nginx":   true,
+		"registry.example.com:5000/nginx:1": false,
+		"docker.io/library/nginx:latest":    true,
+		"nginx@sha256:0123456789abcdef":     false,
+	} {
+		assert.Equal(t, expected, IsLatestImage(image), image)
+	}
+}
+
+func TestWorkloadHygieneSpec_Violations(t *testing.T) {
+	spec := &WorkloadHygieneSpec{
+		RequireLivenessProbe:  true,
+		RequireReadinessProbe: true,
+		ForbidLatestTag:       true,
+		RequiredLabels:        []string{"app.kubernetes.io/name"},
+	}
+
+	pod := corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "init", Image: "busybox"}},
+		Containers: []corev1.Container{
+			{Name: "app", Image: "nginx:1.25", LivenessProbe: &corev1.Probe{}, ReadinessProbe: &corev1.Probe{}},
+			{Name: "sidecar", Image: "envoy:1.28"},
+		},
+	}
+
+	assert.Len(t, spec.Violations(nil, pod, true), 4)
+	assert.Len(t, spec.Violations(map[string]string{"app.kubernetes.io/name": "app"}, pod, true), 3)
+	assert.Len(t, spec.Violations(map[string]string{"app.kubernetes.io/name": "app"}, pod, false), 1)
+	assert.Empty(t, (&WorkloadHygieneSpec{}).Violations(nil, pod, true))
+}
@@ -112,6 +112,48 @@ func (in *AllowedServices) DeepCopy() *AllowedServices {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAccessSpec) DeepCopyInto(out *ClusterAccessSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ClusterResourceAccess, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessSpec.
+func (in *ClusterAccessSpec) DeepCopy() *ClusterAccessSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAccessSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterResourceAccess) DeepCopyInto(out *ClusterResourceAccess) {
+	*out = *in
+	if in.ResourceNames != nil {
+		in, out := &in.ResourceNames, &out.ResourceNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResourceAccess.
+func (in *ClusterResourceAccess) DeepCopy() *ClusterResourceAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterResourceAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DefaultAllowedListSpec) DeepCopyInto(out *DefaultAllowedListSpec) {
 	*out = *in
@@ -128,6 +170,31 @@ func (in *DefaultAllowedListSpec) DeepCopy() *DefaultAllowedListSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultServiceAccountSpec) DeepCopyInto(out *DefaultServiceAccountSpec) {
+	*out = *in
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AutomountServiceAccountToken != nil {
+		in, out := &in.AutomountServiceAccountToken, &out.AutomountServiceAccountToken
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultServiceAccountSpec.
+func (in *DefaultServiceAccountSpec) DeepCopy() *DefaultServiceAccountSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultServiceAccountSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalServiceIPsSpec) DeepCopyInto(out *ExternalServiceIPsSpec) {
 	*out = *in
@@ -168,6 +235,26 @@ func (in *ForbiddenListSpec) DeepCopy() *ForbiddenListSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImmutableListSpec) DeepCopyInto(out *ImmutableListSpec) {
+	*out = *in
+	if in.Exact != nil {
+		in, out := &in.Exact, &out.Exact
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImmutableListSpec.
+func (in *ImmutableListSpec) DeepCopy() *ImmutableListSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImmutableListSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LimitRangesSpec) DeepCopyInto(out *LimitRangesSpec) {
 	*out = *in
@@ -212,6 +299,62 @@ func (in *NetworkPolicySpec) DeepCopy() *NetworkPolicySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectSizeLimitSpec) DeepCopyInto(out *ObjectSizeLimitSpec) {
+	*out = *in
+	out.MaxSize = in.MaxSize.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectSizeLimitSpec.
+func (in *ObjectSizeLimitSpec) DeepCopy() *ObjectSizeLimitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectSizeLimitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorResourceSpec) DeepCopyInto(out *OperatorResourceSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorResourceSpec.
+func (in *OperatorResourceSpec) DeepCopy() *OperatorResourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorResourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorsSpec) DeepCopyInto(out *OperatorsSpec) {
+	*out = *in
+	if in.AllowedCatalogs != nil {
+		in, out := &in.AllowedCatalogs, &out.AllowedCatalogs
+		*out = new(AllowedListSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalResources != nil {
+		in, out := &in.AdditionalResources, &out.AdditionalResources
+		*out = make([]OperatorResourceSpec, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorsSpec.
+func (in *OperatorsSpec) DeepCopy() *OperatorsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodOptions) DeepCopyInto(out *PodOptions) {
 	*out = *in
@@ -220,6 +363,11 @@ func (in *PodOptions) DeepCopyInto(out *PodOptions) {
 		*out = new(AdditionalMetadataSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MaxPodsPerNode != nil {
+		in, out := &in.MaxPodsPerNode, &out.MaxPodsPerNode
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodOptions.
@@ -254,6 +402,53 @@ func (in *ResourceQuotaSpec) DeepCopy() *ResourceQuotaSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretProviderParameterSpec) DeepCopyInto(out *SecretProviderParameterSpec) {
+	*out = *in
+	if in.Prefixes != nil {
+		in, out := &in.Prefixes, &out.Prefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretProviderParameterSpec.
+func (in *SecretProviderParameterSpec) DeepCopy() *SecretProviderParameterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretProviderParameterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretsStoreSpec) DeepCopyInto(out *SecretsStoreSpec) {
+	*out = *in
+	if in.AllowedProviders != nil {
+		in, out := &in.AllowedProviders, &out.AllowedProviders
+		*out = new(AllowedListSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedParameters != nil {
+		in, out := &in.AllowedParameters, &out.AllowedParameters
+		*out = make([]SecretProviderParameterSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsStoreSpec.
+func (in *SecretsStoreSpec) DeepCopy() *SecretsStoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretsStoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SelectorAllowedListSpec) DeepCopyInto(out *SelectorAllowedListSpec) {
 	*out = *in
@@ -289,6 +484,11 @@ func (in *ServiceOptions) DeepCopyInto(out *ServiceOptions) {
 		*out = new(ExternalServiceIPsSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = new(ServicePortsSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.ForbiddenLabels.DeepCopyInto(&out.ForbiddenLabels)
 	in.ForbiddenAnnotations.DeepCopyInto(&out.ForbiddenAnnotations)
 }
@@ -302,3 +502,63 @@ func (in *ServiceOptions) DeepCopy() *ServiceOptions {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServicePortRange) DeepCopyInto(out *ServicePortRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicePortRange.
+func (in *ServicePortRange) DeepCopy() *ServicePortRange {
+	if in == nil {
+		return nil
+	}
+	out := new(ServicePortRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServicePortsSpec) DeepCopyInto(out *ServicePortsSpec) {
+	*out = *in
+	if in.AllowedProtocols != nil {
+		in, out := &in.AllowedProtocols, &out.AllowedProtocols
+		*out = make([]corev1.Protocol, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedRanges != nil {
+		in, out := &in.AllowedRanges, &out.AllowedRanges
+		*out = make([]ServicePortRange, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicePortsSpec.
+func (in *ServicePortsSpec) DeepCopy() *ServicePortsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServicePortsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadHygieneSpec) DeepCopyInto(out *WorkloadHygieneSpec) {
+	*out = *in
+	if in.RequiredLabels != nil {
+		in, out := &in.RequiredLabels, &out.RequiredLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadHygieneSpec.
+func (in *WorkloadHygieneSpec) DeepCopy() *WorkloadHygieneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadHygieneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,82 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package bench
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatenciesSummary(t *testing.T) {
+	latencies := &Latencies{}
+
+	for i := 1; i <= 100; i++ {
+		var err error
+		if i%25 == 0 {
+			err = errors.New("denied")
+		}
+
+		latencies.Observe(time.Duration(i)*time.Millisecond, err)
+	}
+
+	summary := latencies.Summary()
+	assert.Equal(t, 100, summary.Count)
+	assert.Equal(t, 4, summary.Errors)
+	assert.Equal(t, 50*time.Millisecond, summary.P50)
+	assert.Equal(t, 90*time.Millisecond, summary.P90)
+	assert.Equal(t, 99*time.Millisecond, summary.P99)
+	assert.Equal(t, 100*time.Millisecond, summary.Max)
+
+	assert.Equal(t, LatencySummary{}, (&Latencies{}).Summary())
+}
+
+const scrapeBefore = `# TYPE controller_runtime_reconcile_total counter
+controller_runtime_reconcile_total{controller="tenant",result="success"} 10
+controller_runtime_reconcile_total{controller="tenant",result="error"} 1
+controller_runtime_reconcile_total{controller="capacity",result="success"} 7
+# TYPE controller_runtime_webhook_latency_seconds histogram
+controller_runtime_webhook_latency_seconds_bucket{webhook="/pods",le="0.01"} 10
+controller_runtime_webhook_latency_seconds_bucket{webhook="/pods",le="0.1"} 10
+controller_runtime_webhook_latency_seconds_bucket{webhook="/pods",le="+Inf"} 10
+controller_runtime_webhook_latency_seconds_sum{webhook="/pods"} 0.05
+controller_runtime_webhook_latency_seconds_count{webhook="/pods"} 10
+`
+
+const scrapeAfter = `# TYPE controller_runtime_reconcile_total counter
+controller_runtime_reconcile_total{controller="tenant",result="success"} 60
+controller_runtime_reconcile_total{controller="tenant",result="error"} 1
+controller_runtime_reconcile_total{controller="capacity",result="success"} 7
+# TYPE controller_runtime_webhook_latency_seconds histogram
+controller_runtime_webhook_latency_seconds_bucket{webhook="/pods",le="0.01"} 60
+controller_runtime_webhook_latency_seconds_bucket{webhook="/pods",le="0.1"} 110
+controller_runtime_webhook_latency_seconds_bucket{webhook="/pods",le="+Inf"} 110
+controller_runtime_webhook_latency_seconds_sum{webhook="/pods"} 3
+controller_runtime_webhook_latency_seconds_count{webhook="/pods"} 110
+# TYPE process_resident_memory_bytes gauge
+process_resident_memory_bytes 1.048576e+08
+`
+
+func TestMetrics(t *testing.T) {
+	before, err := ParseMetrics(strings.NewReader(scrapeBefore))
+	require.NoError(t, err)
+
+	after, err := ParseMetrics(strings.NewReader(scrapeAfter))
+	require.NoError(t, err)
+
+	assert.Equal(t, 11.0, before.Sum("controller_runtime_reconcile_total", map[string]string{"controller": "tenant"}))
+	assert.Equal(t, 60.0, after.Sum("controller_runtime_reconcile_total", map[string]string{"controller": "tenant", "result": "success"}))
+	assert.Equal(t, 104857600.0, after.Sum("process_resident_memory_bytes", nil))
+	assert.Equal(t, 0.0, after.Sum("missing", nil))
+
+	// 100 samples between the scrapes: 50 below 10ms, and 50 between 10ms and 100ms.
+	assert.InDelta(t, 0.01, HistogramQuantile(0.5, before, after, "controller_runtime_webhook_latency_seconds", nil), 1e-9)
+	assert.InDelta(t, 0.0982, HistogramQuantile(0.99, before, after, "controller_runtime_webhook_latency_seconds", map[string]string{"webhook": "/pods"}), 1e-9)
+	assert.True(t, math.IsNaN(HistogramQuantile(0.99, after, after, "controller_runtime_webhook_latency_seconds", nil)))
+}
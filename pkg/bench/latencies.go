@@ -0,0 +1,73 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package bench
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Latencies collects the latencies of concurrent requests.
+type Latencies struct {
+	mu      sync.Mutex
+	samples []time.Duration
+	errors  int
+}
+
+// Observe records the latency of a request, and whether it failed.
+func (l *Latencies) Observe(latency time.Duration, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.samples = append(l.samples, latency)
+
+	if err != nil {
+		l.errors++
+	}
+}
+
+// LatencySummary reports the distribution of the observed latencies.
+type LatencySummary struct {
+	Count  int           `json:"count"`
+	Errors int           `json:"errors"`
+	P50    time.Duration `json:"p50"`
+	P90    time.Duration `json:"p90"`
+	P99    time.Duration `json:"p99"`
+	Max    time.Duration `json:"max"`
+}
+
+func (l *Latencies) Summary() LatencySummary {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	sorted := append([]time.Duration(nil), l.samples...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	summary := LatencySummary{Count: len(sorted), Errors: l.errors}
+
+	if len(sorted) == 0 {
+		return summary
+	}
+
+	summary.P50 = percentile(sorted, 0.50)
+	summary.P90 = percentile(sorted, 0.90)
+	summary.P99 = percentile(sorted, 0.99)
+	summary.Max = sorted[len(sorted)-1]
+
+	return summary
+}
+
+// percentile returns the nearest-rank percentile of the sorted samples.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+
+	return sorted[rank]
+}
@@ -0,0 +1,143 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package bench
+
+import (
+	"io"
+	"math"
+	"sort"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// Metrics is a scrape of the Prometheus text format exposed by the Capsule metrics endpoint.
+type Metrics map[string]*dto.MetricFamily
+
+func ParseMetrics(r io.Reader) (Metrics, error) {
+	var parser expfmt.TextParser
+
+	families, err := parser.TextToMetricFamilies(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return families, nil
+}
+
+// Sum returns the sum of the counter, or gauge, samples of the metric matching the labels.
+func (m Metrics) Sum(name string, labels map[string]string) (sum float64) {
+	family, ok := m[name]
+	if !ok {
+		return 0
+	}
+
+	for _, metric := range family.GetMetric() {
+		if !matches(metric, labels) {
+			continue
+		}
+
+		switch {
+		case metric.GetCounter() != nil:
+			sum += metric.GetCounter().GetValue()
+		case metric.GetGauge() != nil:
+			sum += metric.GetGauge().GetValue()
+		case metric.GetUntyped() != nil:
+			sum += metric.GetUntyped().GetValue()
+		}
+	}
+
+	return sum
+}
+
+// buckets returns the cumulative counts of the histogram samples matching the labels, keyed by upper bound.
+func (m Metrics) buckets(name string, labels map[string]string) map[float64]float64 {
+	buckets := map[float64]float64{}
+
+	family, ok := m[name]
+	if !ok {
+		return buckets
+	}
+
+	for _, metric := range family.GetMetric() {
+		if metric.GetHistogram() == nil || !matches(metric, labels) {
+			continue
+		}
+
+		for _, bucket := range metric.GetHistogram().GetBucket() {
+			if !math.IsInf(bucket.GetUpperBound(), 1) {
+				buckets[bucket.GetUpperBound()] += float64(bucket.GetCumulativeCount())
+			}
+		}
+		// The +Inf bucket is the count of the samples, regardless of its presence among the buckets.
+
+		buckets[math.Inf(1)] += float64(metric.GetHistogram().GetSampleCount())
+	}
+
+	return buckets
+}
+
+// HistogramQuantile estimates the quantile of the histogram samples observed between the two scrapes,
+// interpolating linearly within the buckets as the PromQL histogram_quantile function does.
+// It returns NaN when no sample has been observed.
+func HistogramQuantile(q float64, before, after Metrics, name string, labels map[string]string) float64 {
+	previous, current := before.buckets(name, labels), after.buckets(name, labels)
+
+	bounds := make([]float64, 0, len(current))
+	for bound := range current {
+		bounds = append(bounds, bound)
+	}
+
+	sort.Float64s(bounds)
+
+	if len(bounds) == 0 {
+		return math.NaN()
+	}
+
+	total := current[math.Inf(1)] - previous[math.Inf(1)]
+	if total <= 0 {
+		return math.NaN()
+	}
+
+	rank := q * total
+
+	lowerBound, lowerCount := 0.0, 0.0
+
+	for _, bound := range bounds {
+		count := current[bound] - previous[bound]
+
+		if count >= rank {
+			if math.IsInf(bound, 1) {
+				// The quantile falls in the last bucket: its lower bound is the best estimate.
+				return lowerBound
+			}
+
+			if count == lowerCount {
+				return bound
+			}
+
+			return lowerBound + (bound-lowerBound)*(rank-lowerCount)/(count-lowerCount)
+		}
+
+		lowerBound, lowerCount = bound, count
+	}
+
+	return lowerBound
+}
+
+func matches(metric *dto.Metric, labels map[string]string) bool {
+	matched := 0
+
+	for _, pair := range metric.GetLabel() {
+		if value, ok := labels[pair.GetName()]; ok {
+			if value != pair.GetValue() {
+				return false
+			}
+
+			matched++
+		}
+	}
+
+	return matched == len(labels)
+}
@@ -0,0 +1,115 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package cert
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// FingerprintsPath is the path the fingerprints of the served certificates are exposed at by the webhook server.
+const FingerprintsPath = "/certificates/fingerprints"
+
+// Fingerprint returns the SHA-256 fingerprint of the first PEM encoded certificate,
+// in the colon separated uppercase hexadecimal format printed by openssl.
+func Fingerprint(certBytes []byte) (string, error) {
+	b, _ := pem.Decode(certBytes)
+	if b == nil || b.Type != "CERTIFICATE" {
+		return "", errors.New("no PEM encoded certificate")
+	}
+
+	sum := sha256.Sum256(b.Bytes)
+
+	parts := make([]string, 0, len(sum))
+
+	for _, item := range sum {
+		parts = append(parts, fmt.Sprintf("%02X", item))
+	}
+
+	return strings.Join(parts, ":"), nil
+}
+
+// Fingerprints describes the certificates served by Capsule.
+type Fingerprints struct {
+	CA          string    `json:"ca"`
+	Certificate string    `json:"certificate"`
+	NotAfter    time.Time `json:"notAfter"`
+}
+
+// FingerprintsHandler serves the fingerprints of the certificates loaded from the webhook server certificates directory,
+// allowing the automation pinning them to verify the published ones are actually served.
+// With the sha256 query parameter, it responds with 404 if the given fingerprint is not one of the served certificates.
+type FingerprintsHandler struct {
+	CertDir string
+}
+
+func (h FingerprintsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	fingerprints, err := h.fingerprints()
+	if err != nil {
+		http.Error(w, "cannot read the served certificates", http.StatusInternalServerError)
+
+		return
+	}
+
+	if expected := r.URL.Query().Get("sha256"); len(expected) > 0 {
+		if !strings.EqualFold(expected, fingerprints.CA) && !strings.EqualFold(expected, fingerprints.Certificate) {
+			http.NotFound(w, r)
+
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(fingerprints)
+}
+
+func (h FingerprintsHandler) fingerprints() (fingerprints Fingerprints, err error) {
+	var certBytes, caBytes []byte
+
+	if certBytes, err = os.ReadFile(filepath.Join(h.CertDir, "tls.crt")); err != nil {
+		return
+	}
+
+	if caBytes, err = os.ReadFile(filepath.Join(h.CertDir, "ca.crt")); err != nil {
+		return
+	}
+
+	return GetFingerprints(caBytes, certBytes)
+}
+
+// GetFingerprints returns the fingerprints of the given PEM encoded Certificate Authority and certificate.
+func GetFingerprints(caBytes, certBytes []byte) (fingerprints Fingerprints, err error) {
+	if fingerprints.CA, err = Fingerprint(caBytes); err != nil {
+		return
+	}
+
+	if fingerprints.Certificate, err = Fingerprint(certBytes); err != nil {
+		return
+	}
+
+	certificate, err := GetCertificateFromBytes(certBytes)
+	if err != nil {
+		return
+	}
+
+	fingerprints.NotAfter = certificate.NotAfter
+
+	return
+}
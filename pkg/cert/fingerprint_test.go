@@ -0,0 +1,76 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package cert
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFingerprint(t *testing.T) {
+	ca, err := GenerateCertificateAuthority()
+	assert.Nil(t, err)
+
+	crt, _, err := ca.GenerateCertificate(NewCertOpts(time.Now().AddDate(1, 0, 0), "foo.tld"))
+	assert.Nil(t, err)
+
+	fingerprint, err := Fingerprint(crt.Bytes())
+	assert.Nil(t, err)
+
+	b, _ := pem.Decode(crt.Bytes())
+	sum := sha256.Sum256(b.Bytes)
+
+	assert.Len(t, strings.Split(fingerprint, ":"), len(sum))
+	assert.Equal(t, strings.ToUpper(strings.ReplaceAll(fingerprint, ":", "")), strings.ReplaceAll(fingerprint, ":", ""))
+
+	_, err = Fingerprint([]byte("not a certificate"))
+	assert.NotNil(t, err)
+}
+
+func TestFingerprintsHandler(t *testing.T) {
+	ca, err := GenerateCertificateAuthority()
+	assert.Nil(t, err)
+
+	caCrt, err := ca.CACertificatePem()
+	assert.Nil(t, err)
+
+	crt, _, err := ca.GenerateCertificate(NewCertOpts(time.Now().AddDate(1, 0, 0), "foo.tld"))
+	assert.Nil(t, err)
+
+	dir := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "ca.crt"), caCrt.Bytes(), 0o600))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "tls.crt"), crt.Bytes(), 0o600))
+
+	expected, err := GetFingerprints(caCrt.Bytes(), crt.Bytes())
+	assert.Nil(t, err)
+
+	handler := FingerprintsHandler{CertDir: dir}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, FingerprintsPath, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var served Fingerprints
+	assert.Nil(t, json.NewDecoder(rec.Body).Decode(&served))
+	assert.Equal(t, expected.CA, served.CA)
+	assert.Equal(t, expected.Certificate, served.Certificate)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, FingerprintsPath+"?sha256="+strings.ToLower(expected.CA), nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, FingerprintsPath+"?sha256=00:11", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
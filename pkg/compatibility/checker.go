@@ -0,0 +1,104 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package compatibility
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/policy"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+)
+
+// Checker validates the stored Tenants against the schema and the validation policies of the running Capsule version,
+// reporting the Tenants the Tenant webhook would reject at their next update.
+type Checker struct {
+	// Client must not be backed by a cache, since the check is performed before starting the manager.
+	Client  client.Client
+	Decoder admission.Decoder
+	// Handlers of the Tenant webhook, replayed against each stored Tenant.
+	Handlers []capsulewebhook.Handler
+	Version  string
+}
+
+func (c *Checker) Check(ctx context.Context) (*Report, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(capsulev1beta2.GroupVersion.WithKind("TenantList"))
+
+	if err := c.Client.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("cannot list Tenants: %w", err)
+	}
+
+	report := &Report{
+		Version:     c.Version,
+		GeneratedAt: metav1.Now(),
+		Tenants:     len(list.Items),
+		Findings:    []Finding{},
+	}
+
+	for i := range list.Items {
+		report.Findings = append(report.Findings, c.check(ctx, &list.Items[i])...)
+	}
+
+	return report, nil
+}
+
+func (c *Checker) check(ctx context.Context, obj *unstructured.Unstructured) (findings []Finding) {
+	// Fields unknown to the running version are ignored by Capsule, and dropped at the next update.
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructuredWithValidation(obj.UnstructuredContent(), &capsulev1beta2.Tenant{}, true); err != nil {
+		findings = append(findings, Finding{Tenant: obj.GetName(), Message: err.Error()})
+	}
+
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return append(findings, Finding{Tenant: obj.GetName(), Message: err.Error()})
+	}
+	// A no-op update is replayed, reporting the policies denying it.
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Kind: metav1.GroupVersionKind{
+				Group:   capsulev1beta2.GroupVersion.Group,
+				Version: capsulev1beta2.GroupVersion.Version,
+				Kind:    "Tenant",
+			},
+			Name:      obj.GetName(),
+			Operation: admissionv1.Update,
+			Object:    runtime.RawExtension{Raw: raw},
+			OldObject: runtime.RawExtension{Raw: raw},
+		},
+	}
+	// Events are not recorded, the Tenants are not actually updated.
+	recorder := &record.FakeRecorder{}
+
+	for _, handler := range c.Handlers {
+		response := handler.OnUpdate(c.Client, c.Decoder, recorder)(ctx, req)
+		if response == nil || response.Allowed {
+			continue
+		}
+
+		finding := Finding{
+			Tenant:     obj.GetName(),
+			Code:       policy.Code(response.AuditAnnotations[policy.CodeAnnotation]),
+			Violations: policy.ViolationsOf(*response),
+		}
+
+		if response.Result != nil {
+			finding.Message = response.Result.Message
+		}
+
+		findings = append(findings, finding)
+	}
+
+	return findings
+}
@@ -0,0 +1,34 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package compatibility
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Publisher stores the compatibility report in the Capsule Namespace once elected as leader:
+// the check is performed by every replica at startup, while the report is written by a single one.
+type Publisher struct {
+	// Client should not be backed by a cache, sparing an informer on the ConfigMaps.
+	Client    client.Client
+	Log       logr.Logger
+	Namespace string
+	Report    *Report
+}
+
+func (p *Publisher) NeedLeaderElection() bool {
+	return true
+}
+
+func (p *Publisher) Start(ctx context.Context) error {
+	// The report is informative, failing to store it must not stop the manager.
+	if err := p.Report.Store(ctx, p.Client, p.Namespace); err != nil {
+		p.Log.Error(err, "unable to store the compatibility report")
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package compatibility
+
+import (
+	"context"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/projectcapsule/capsule/pkg/policy"
+)
+
+const (
+	// ReportConfigMapName is the name of the ConfigMap, in the Capsule Namespace, storing the last compatibility report.
+	ReportConfigMapName = "capsule-compatibility-report"
+	// ReportKey is the ConfigMap key holding the JSON encoded report.
+	ReportKey = "report.json"
+)
+
+// Finding describes a stored Tenant incompatible with the running Capsule version.
+type Finding struct {
+	Tenant string `json:"tenant"`
+	// Code of the policy the Tenant violates, empty for schema findings.
+	Code    policy.Code `json:"code,omitempty"`
+	Message string      `json:"message"`
+	// Violations lists the fields, and their values, violating the policy.
+	Violations []policy.Violation `json:"violations,omitempty"`
+}
+
+// Report is the outcome of the compatibility check of the stored Tenants.
+type Report struct {
+	// Capsule version the Tenants have been checked against.
+	Version     string      `json:"version"`
+	GeneratedAt metav1.Time `json:"generatedAt"`
+	// Number of checked Tenants.
+	Tenants  int       `json:"tenants"`
+	Findings []Finding `json:"findings"`
+}
+
+// Tolerates returns true if every given violation of the policy identified by the code has been reported for the Tenant,
+// with the same field and value: a denial without violations is never tolerated.
+func (r *Report) Tolerates(tenant string, code policy.Code, violations []policy.Violation) bool {
+	if r == nil || len(violations) == 0 {
+		return false
+	}
+
+	for _, violation := range violations {
+		if !r.reported(tenant, code, violation) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *Report) reported(tenant string, code policy.Code, violation policy.Violation) bool {
+	for _, finding := range r.Findings {
+		if finding.Tenant != tenant || finding.Code != code {
+			continue
+		}
+
+		for _, reported := range finding.Violations {
+			if reported == violation {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Store writes the report in the given ConfigMap, creating it if missing.
+func (r *Report) Store(ctx context.Context, c client.Client, namespace string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ReportConfigMapName,
+			Namespace: namespace,
+		},
+	}
+
+	_, err = controllerutil.CreateOrUpdate(ctx, c, cm, func() error {
+		cm.Data = map[string]string{
+			ReportKey: string(data),
+		}
+
+		return nil
+	})
+
+	return err
+}
@@ -0,0 +1,87 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package compatibility
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/projectcapsule/capsule/pkg/policy"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+)
+
+// Tolerate wraps the Tenant webhook handlers, turning into warnings the update denials of the violations
+// the Tenants already had when the report has been generated, matching the policy code, the field and its value:
+// this prevents an upgrade from blocking the updates of existing Tenants, while any new violation is still denied.
+// Create and delete requests are handled as usual.
+func Tolerate(report *Report, handlers ...capsulewebhook.Handler) capsulewebhook.Handler {
+	return &tolerateHandler{
+		report:   report,
+		handlers: handlers,
+	}
+}
+
+type tolerateHandler struct {
+	report   *Report
+	handlers []capsulewebhook.Handler
+}
+
+func (h *tolerateHandler) OnCreate(client client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		for _, hndl := range h.handlers {
+			if response := hndl.OnCreate(client, decoder, recorder)(ctx, req); response != nil {
+				return response
+			}
+		}
+
+		return nil
+	}
+}
+
+func (h *tolerateHandler) OnDelete(client client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		for _, hndl := range h.handlers {
+			if response := hndl.OnDelete(client, decoder, recorder)(ctx, req); response != nil {
+				return response
+			}
+		}
+
+		return nil
+	}
+}
+
+func (h *tolerateHandler) OnUpdate(client client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		var warnings []string
+
+		for _, hndl := range h.handlers {
+			response := hndl.OnUpdate(client, decoder, recorder)(ctx, req)
+			if response == nil {
+				continue
+			}
+
+			code := policy.Code(response.AuditAnnotations[policy.CodeAnnotation])
+
+			if response.Allowed || len(code) == 0 || !h.report.Tolerates(req.Name, code, policy.ViolationsOf(*response)) {
+				response.Warnings = append(warnings, response.Warnings...)
+
+				return response
+			}
+
+			warnings = append(warnings, fmt.Sprintf("%s: tolerated, since the Tenant was already violating it before the upgrade: see the compatibility report", response.Result.Message))
+		}
+
+		if len(warnings) == 0 {
+			return nil
+		}
+
+		response := admission.Allowed("").WithWarnings(warnings...)
+
+		return &response
+	}
+}
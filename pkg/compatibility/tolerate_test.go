@@ -0,0 +1,101 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package compatibility
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/projectcapsule/capsule/pkg/policy"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+)
+
+type denyingHandler struct {
+	code       policy.Code
+	violations []policy.Violation
+}
+
+func (d denyingHandler) deny(context.Context, admission.Request) *admission.Response {
+	response := policy.DenyViolations(d.code, "denied", d.violations...)
+
+	return &response
+}
+
+func (d denyingHandler) OnCreate(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return d.deny
+}
+
+func (d denyingHandler) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return d.deny
+}
+
+func (d denyingHandler) OnUpdate(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return d.deny
+}
+
+func TestTolerate(t *testing.T) {
+	registry := policy.Violation{Field: "spec.containerRegistries.allowedRegex", Value: "(docker.io"}
+	owner := policy.Violation{Field: "spec.owners[0].name", Value: "robot"}
+
+	report := &Report{
+		Findings: []Finding{
+			{Tenant: "oil", Code: policy.TenantInvalidRegex, Violations: []policy.Violation{registry}},
+			{Tenant: "oil", Code: policy.TenantInvalidOwner, Violations: []policy.Violation{owner}},
+			{Tenant: "gas", Message: "unknown field"},
+		},
+	}
+
+	update := func(tenant string, handlers ...capsulewebhook.Handler) *admission.Response {
+		req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Name: tenant, Operation: admissionv1.Update}}
+
+		return Tolerate(report, handlers...).OnUpdate(nil, nil, nil)(context.Background(), req)
+	}
+
+	response := update("oil", denyingHandler{policy.TenantInvalidRegex, []policy.Violation{registry}}, denyingHandler{policy.TenantInvalidOwner, []policy.Violation{owner}})
+	if assert.NotNil(t, response) {
+		assert.True(t, response.Allowed)
+		assert.Len(t, response.Warnings, 2)
+	}
+
+	response = update("oil", denyingHandler{policy.TenantInvalidRegex, []policy.Violation{registry}}, denyingHandler{policy.TenantImmutableLabel, nil})
+	if assert.NotNil(t, response) {
+		assert.False(t, response.Allowed)
+		assert.Len(t, response.Warnings, 1)
+	}
+	// A new value of the same field violating the same policy is denied.
+	response = update("oil", denyingHandler{policy.TenantInvalidRegex, []policy.Violation{{Field: registry.Field, Value: "(quay.io"}}})
+	if assert.NotNil(t, response) {
+		assert.False(t, response.Allowed)
+	}
+	// A new violation is not masked by the reported one.
+	response = update("oil", denyingHandler{policy.TenantInvalidOwner, []policy.Violation{owner, {Field: "spec.owners[1].name", Value: "bot"}}})
+	if assert.NotNil(t, response) {
+		assert.False(t, response.Allowed)
+	}
+	// Denials without violations are never tolerated.
+	response = update("oil", denyingHandler{policy.TenantInvalidRegex, nil})
+	if assert.NotNil(t, response) {
+		assert.False(t, response.Allowed)
+	}
+
+	response = update("gas", denyingHandler{policy.TenantInvalidRegex, []policy.Violation{registry}})
+	if assert.NotNil(t, response) {
+		assert.False(t, response.Allowed)
+	}
+
+	assert.Nil(t, update("oil"))
+
+	req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Name: "oil", Operation: admissionv1.Create}}
+	response = Tolerate(report, denyingHandler{policy.TenantInvalidRegex, []policy.Violation{registry}}).OnCreate(nil, nil, nil)(context.Background(), req)
+
+	if assert.NotNil(t, response) {
+		assert.False(t, response.Allowed)
+	}
+}
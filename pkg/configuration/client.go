@@ -100,3 +100,19 @@ func (c *capsuleConfiguration) ForbiddenUserNodeAnnotations() *capsuleapi.Forbid
 
 	return &c.retrievalFn().Spec.NodeMetadata.ForbiddenAnnotations
 }
+
+func (c *capsuleConfiguration) Discovery() *capsulev1beta2.DiscoverySpec {
+	return c.retrievalFn().Spec.Discovery
+}
+
+func (c *capsuleConfiguration) DeletionImpactThresholds() *capsulev1beta2.DeletionImpactThresholds {
+	return c.retrievalFn().Spec.DeletionImpactThresholds
+}
+
+func (c *capsuleConfiguration) IngressControllers() []capsulev1beta2.IngressControllerSpec {
+	return c.retrievalFn().Spec.IngressControllers
+}
+
+func (c *capsuleConfiguration) MinimizeWebhookRules() bool {
+	return c.retrievalFn().Spec.MinimizeWebhookRules
+}
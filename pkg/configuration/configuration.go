@@ -6,6 +6,7 @@ package configuration
 import (
 	"regexp"
 
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
 	capsuleapi "github.com/projectcapsule/capsule/pkg/api"
 )
 
@@ -26,4 +27,12 @@ type Configuration interface {
 	UserGroups() []string
 	ForbiddenUserNodeLabels() *capsuleapi.ForbiddenListSpec
 	ForbiddenUserNodeAnnotations() *capsuleapi.ForbiddenListSpec
+	// MinimizeWebhookRules enables the trimming of the webhook rules not required by any Tenant.
+	MinimizeWebhookRules() bool
+	// Discovery returns the settings of the per-Tenant discovery documents, nil when disabled.
+	Discovery() *capsulev1beta2.DiscoverySpec
+	// DeletionImpactThresholds returns the thresholds requiring the confirmation of the Tenant deletions, nil when disabled.
+	DeletionImpactThresholds() *capsulev1beta2.DeletionImpactThresholds
+	// IngressControllers returns the Ingress controllers serving the IngressClasses of the Tenants.
+	IngressControllers() []capsulev1beta2.IngressControllerSpec
 }
@@ -0,0 +1,84 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func checks() []Check {
+	return []Check{
+		{Name: "passing", Run: func(context.Context) error { return nil }},
+		{Name: "failing", Run: func(context.Context) error { return errors.New("isolation broken") }},
+		{Name: "skipping", Run: func(context.Context) error { return Skip("TLS reconciler %s", "disabled") }},
+		{Name: "panicking", Run: func(context.Context) error { panic("boom") }},
+		{Name: "disruptive", Disruptive: true, Run: func(context.Context) error { return nil }},
+		{Name: "timing-out", Run: func(ctx context.Context) error {
+			<-ctx.Done()
+
+			return ctx.Err()
+		}},
+	}
+}
+
+func TestRun(t *testing.T) {
+	report := Run(context.Background(), checks(), Options{Timeout: 10 * time.Millisecond})
+
+	assert.Equal(t, 1, report.Passed)
+	assert.Equal(t, 3, report.Failed)
+	assert.Equal(t, 2, report.Skipped)
+
+	statuses := map[string]Status{}
+	for _, result := range report.Results {
+		statuses[result.Name] = result.Status
+	}
+
+	assert.Equal(t, map[string]Status{
+		"passing":    StatusPassed,
+		"failing":    StatusFailed,
+		"skipping":   StatusSkipped,
+		"panicking":  StatusFailed,
+		"disruptive": StatusSkipped,
+		"timing-out": StatusFailed,
+	}, statuses)
+	assert.Equal(t, "TLS reconciler disabled", report.Results[2].Message)
+	assert.Equal(t, "panic: boom", report.Results[3].Message)
+
+	report = Run(context.Background(), checks(), Options{Focus: regexp.MustCompile("^(passing|disruptive)$"), Disruptive: true})
+
+	assert.Equal(t, 2, report.Passed)
+	assert.Len(t, report.Results, 2)
+}
+
+func TestReportWriters(t *testing.T) {
+	report := Run(context.Background(), checks()[:3], Options{})
+
+	junit := &bytes.Buffer{}
+	assert.NoError(t, report.WriteJUnit(junit))
+	assert.True(t, strings.HasPrefix(junit.String(), "<?xml"))
+	assert.Contains(t, junit.String(), `<testsuite name="capsule-conformance" tests="3" failures="1" skipped="1"`)
+	assert.Contains(t, junit.String(), `<failure message="isolation broken"></failure>`)
+	assert.Contains(t, junit.String(), `<skipped message="TLS reconciler disabled"></skipped>`)
+
+	raw := &bytes.Buffer{}
+	assert.NoError(t, report.WriteJSON(raw))
+
+	decoded := &Report{}
+	assert.NoError(t, json.Unmarshal(raw.Bytes(), decoded))
+	assert.Equal(t, report.Results, decoded.Results)
+
+	text := &bytes.Buffer{}
+	assert.NoError(t, report.WriteText(text))
+	assert.Contains(t, text.String(), "[failed] failing")
+	assert.Contains(t, text.String(), "1 passed, 1 failed, 1 skipped")
+}
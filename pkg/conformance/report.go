@@ -0,0 +1,154 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package conformance
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Status is the outcome of a check.
+type Status string
+
+const (
+	StatusPassed  Status = "passed"
+	StatusFailed  Status = "failed"
+	StatusSkipped Status = "skipped"
+)
+
+// Result of a check.
+type Result struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Status      Status        `json:"status"`
+	Message     string        `json:"message,omitempty"`
+	Duration    time.Duration `json:"duration"`
+}
+
+// Report of a conformance run.
+type Report struct {
+	Started  time.Time     `json:"started"`
+	Duration time.Duration `json:"duration"`
+	Passed   int           `json:"passed"`
+	Failed   int           `json:"failed"`
+	Skipped  int           `json:"skipped"`
+	Results  []Result      `json:"results"`
+}
+
+func (r *Report) add(result Result) {
+	switch result.Status {
+	case StatusPassed:
+		r.Passed++
+	case StatusFailed:
+		r.Failed++
+	case StatusSkipped:
+		r.Skipped++
+	}
+
+	r.Results = append(r.Results, result)
+}
+
+// WriteText writes the report in a human readable format.
+func (r *Report) WriteText(w io.Writer) error {
+	for _, result := range r.Results {
+		line := fmt.Sprintf("[%s] %s (%s)", result.Status, result.Name, result.Duration.Round(time.Millisecond))
+		if result.Message != "" {
+			line += ": " + result.Message
+		}
+
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d passed, %d failed, %d skipped in %s\n", r.Passed, r.Failed, r.Skipped, r.Duration.Round(time.Millisecond))
+
+	return err
+}
+
+// WriteJSON writes the report as JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(r)
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+// WriteJUnit writes the report in the JUnit XML format, as consumed by the CI systems.
+func (r *Report) WriteJUnit(w io.Writer) error {
+	suite := junitTestSuite{
+		Name:      "capsule-conformance",
+		Tests:     len(r.Results),
+		Failures:  r.Failed,
+		Skipped:   r.Skipped,
+		Time:      seconds(r.Duration),
+		Timestamp: r.Started.UTC().Format(time.RFC3339),
+	}
+
+	for _, result := range r.Results {
+		testCase := junitTestCase{
+			Name:      result.Name,
+			ClassName: "capsule.conformance",
+			Time:      seconds(result.Duration),
+		}
+
+		switch result.Status {
+		case StatusFailed:
+			testCase.Failure = &junitMessage{Message: result.Message}
+		case StatusSkipped:
+			testCase.Skipped = &junitMessage{Message: result.Message}
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	if err := encoder.Encode(junitTestSuites{Suites: []junitTestSuite{suite}}); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,102 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package conformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Check is a scenario verifying a capability of a Capsule installation.
+type Check struct {
+	Name        string
+	Description string
+	// Disruptive checks, such as the ones restarting the Capsule Pods, run only when enabled.
+	Disruptive bool
+	Run        func(ctx context.Context) error
+}
+
+// Options of a conformance run.
+type Options struct {
+	// Focus selects the checks to run by name, all of them when nil.
+	Focus *regexp.Regexp
+	// Disruptive enables the disruptive checks.
+	Disruptive bool
+	// Timeout of each check, no timeout when zero.
+	Timeout time.Duration
+}
+
+type skipError struct {
+	reason string
+}
+
+func (s skipError) Error() string {
+	return s.reason
+}
+
+// Skip returns the error skipping a check, such as when the installation does not enable the verified capability.
+func Skip(format string, args ...interface{}) error {
+	return skipError{reason: fmt.Sprintf(format, args...)}
+}
+
+// Run runs the given checks in order, collecting their results in the report.
+func Run(ctx context.Context, checks []Check, opts Options) *Report {
+	report := &Report{Started: time.Now()}
+
+	for _, check := range checks {
+		if opts.Focus != nil && !opts.Focus.MatchString(check.Name) {
+			continue
+		}
+
+		result := Result{Name: check.Name, Description: check.Description}
+
+		if check.Disruptive && !opts.Disruptive {
+			result.Status, result.Message = StatusSkipped, "disruptive check not enabled"
+			report.add(result)
+
+			continue
+		}
+
+		start := time.Now()
+		err := run(ctx, check, opts.Timeout)
+		result.Duration = time.Since(start)
+
+		var skip skipError
+
+		switch {
+		case err == nil:
+			result.Status = StatusPassed
+		case errors.As(err, &skip):
+			result.Status, result.Message = StatusSkipped, skip.reason
+		default:
+			result.Status, result.Message = StatusFailed, err.Error()
+		}
+
+		report.add(result)
+	}
+
+	report.Duration = time.Since(report.Started)
+
+	return report
+}
+
+func run(ctx context.Context, check Check, timeout time.Duration) (err error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	return check.Run(ctx)
+}
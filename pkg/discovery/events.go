@@ -0,0 +1,113 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+// EventsPath is the prefix the Tenant Events are served from, at EventsPath + <tenant name>/events.
+//
+// The Events of the cluster scoped resources are recorded in the default Namespace with generated names,
+// and RBAC cannot restrict their access per involved object: the Tenant owners can read the Events
+// of their own Tenant from this endpoint only.
+const EventsPath = "/tenants/"
+
+// EventsHandler serves the Events of a Tenant to its owners,
+// authenticating the bearer token of the request with a TokenReview.
+type EventsHandler struct {
+	Client client.Client
+	// APIReader lists the Events by involved object without starting an informer on Events.
+	APIReader client.Reader
+	Log       logr.Logger
+	// Audiences the bearer tokens must be issued for, the API server ones when empty.
+	Audiences []string
+}
+
+func (h *EventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	name, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, EventsPath), "/events")
+	if !ok || len(name) == 0 || strings.Contains(name, "/") {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	userInfo, err := authenticate(r.Context(), h.Client, r, h.Audiences)
+	if err != nil {
+		h.Log.Error(err, "cannot authenticate events request")
+		http.Error(w, "cannot authenticate the request", http.StatusInternalServerError)
+
+		return
+	}
+
+	if userInfo == nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+
+		return
+	}
+
+	tnt := &capsulev1beta2.Tenant{}
+	if err = h.Client.Get(r.Context(), types.NamespacedName{Name: name}, tnt); err != nil {
+		if apierrors.IsNotFound(err) {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		h.Log.Error(err, "cannot retrieve Tenant", "tenant", name)
+		http.Error(w, "cannot retrieve the Tenant", http.StatusInternalServerError)
+
+		return
+	}
+	// Tenants not owned by the requester are not disclosed, even if existing.
+	if !utils.IsTenantOwner(tnt.Spec.Owners, *userInfo) {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	eventList := &corev1.EventList{}
+	if err = h.APIReader.List(r.Context(), eventList, client.MatchingFieldsSelector{Selector: fields.SelectorFromSet(fields.Set{
+		"involvedObject.apiVersion": capsulev1beta2.GroupVersion.String(),
+		"involvedObject.kind":       "Tenant",
+		"involvedObject.name":       tnt.GetName(),
+		"involvedObject.uid":        string(tnt.GetUID()),
+	})}); err != nil {
+		h.Log.Error(err, "cannot list Tenant events", "tenant", name)
+		http.Error(w, "cannot list the Tenant Events", http.StatusInternalServerError)
+
+		return
+	}
+
+	sort.SliceStable(eventList.Items, func(i, j int) bool {
+		return eventList.Items[i].LastTimestamp.Before(&eventList.Items[j].LastTimestamp)
+	})
+
+	eventList.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("EventList"))
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err = json.NewEncoder(w).Encode(eventList); err != nil {
+		h.Log.Error(err, "cannot encode Tenant events")
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+func newTenantEvent(name string, tnt *capsulev1beta2.Tenant) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: capsulev1beta2.GroupVersion.String(),
+			Kind:       "Tenant",
+			Name:       tnt.GetName(),
+			UID:        tnt.GetUID(),
+		},
+		Reason: "Reconciled",
+	}
+}
+
+// newEventsClient returns a client listing the Events by the involved object fields.
+func newEventsClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+
+	builder := newClientBuilder(t, objs...)
+
+	for field, extract := range map[string]func(*corev1.Event) string{
+		"involvedObject.apiVersion": func(e *corev1.Event) string { return e.InvolvedObject.APIVersion },
+		"involvedObject.kind":       func(e *corev1.Event) string { return e.InvolvedObject.Kind },
+		"involvedObject.name":       func(e *corev1.Event) string { return e.InvolvedObject.Name },
+		"involvedObject.uid":        func(e *corev1.Event) string { return string(e.InvolvedObject.UID) },
+	} {
+		builder = builder.WithIndex(&corev1.Event{}, field, func(obj client.Object) []string {
+			return []string{extract(obj.(*corev1.Event))} //nolint:forcetypeassert
+		})
+	}
+
+	return builder.Build()
+}
+
+func TestEventsHandler(t *testing.T) {
+	oil, gas := newTenant("oil", "alice"), newTenant("gas", "bob")
+
+	c := newEventsClient(t, oil, gas, newTenantEvent("oil.1", oil), newTenantEvent("oil.2", oil), newTenantEvent("gas.1", gas))
+
+	handler := &EventsHandler{Client: c, APIReader: c, Log: logr.Discard()}
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, serve(handler, "/tenants/oil/events", "").Code)
+		assert.Equal(t, http.StatusUnauthorized, serve(handler, "/tenants/oil/events", "unknown-token").Code)
+	})
+
+	t.Run("not owner", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, serve(handler, "/tenants/oil/events", "joe-token").Code)
+		assert.Equal(t, http.StatusNotFound, serve(handler, "/tenants/gas/events", "alice-token").Code)
+	})
+
+	t.Run("missing Tenant", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, serve(handler, "/tenants/water/events", "alice-token").Code)
+	})
+
+	t.Run("owner", func(t *testing.T) {
+		rec := serve(handler, "/tenants/oil/events", "alice-token")
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		eventList := &corev1.EventList{}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(eventList))
+
+		names := make([]string, 0, len(eventList.Items))
+		for _, event := range eventList.Items {
+			names = append(names, event.GetName())
+		}
+
+		assert.ElementsMatch(t, []string{"oil.1", "oil.2"}, names)
+	})
+}
@@ -0,0 +1,195 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/configuration"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+// Path is the prefix the discovery documents are served from:
+// the index of the Tenants owned by the requester is served at the prefix itself,
+// while a single Tenant document is served at Path + <tenant name>.
+const Path = "/discovery/tenants/"
+
+const rootCAConfigMap = "kube-root-ca.crt"
+
+// Document contains the information required by CLI tooling to build the kubeconfig of a Tenant.
+type Document struct {
+	Tenant                   string   `json:"tenant"`
+	ServerURL                string   `json:"serverURL"`
+	CertificateAuthorityData string   `json:"certificateAuthorityData,omitempty"`
+	IssuerURL                string   `json:"issuerURL,omitempty"`
+	ClientID                 string   `json:"clientID,omitempty"`
+	Namespaces               []string `json:"namespaces"`
+}
+
+// Handler serves the discovery documents to the Tenant owners,
+// authenticating the bearer token of the request with a TokenReview.
+type Handler struct {
+	Client client.Client
+	// APIReader retrieves the cluster Certificate Authority without starting an informer on ConfigMaps.
+	APIReader     client.Reader
+	Configuration configuration.Configuration
+	Log           logr.Logger
+	// Namespace where Capsule is running, used to retrieve the cluster Certificate Authority.
+	Namespace string
+	// Audiences the bearer tokens must be issued for, the API server ones when empty.
+	Audiences []string
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	spec := h.Configuration.Discovery()
+	if spec == nil {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	userInfo, err := authenticate(r.Context(), h.Client, r, h.Audiences)
+	if err != nil {
+		h.Log.Error(err, "cannot authenticate discovery request")
+		http.Error(w, "cannot authenticate the request", http.StatusInternalServerError)
+
+		return
+	}
+
+	if userInfo == nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+
+		return
+	}
+
+	tntList := &capsulev1beta2.TenantList{}
+	if err = h.Client.List(r.Context(), tntList); err != nil {
+		h.Log.Error(err, "cannot list Tenants")
+		http.Error(w, "cannot list Tenants", http.StatusInternalServerError)
+
+		return
+	}
+
+	ca, err := h.certificateAuthority(r.Context(), spec)
+	if err != nil {
+		h.Log.Error(err, "cannot retrieve the Certificate Authority")
+		http.Error(w, "cannot retrieve the Certificate Authority", http.StatusInternalServerError)
+
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, Path)
+
+	documents := make([]Document, 0, len(tntList.Items))
+
+	for _, tnt := range tntList.Items {
+		if len(name) > 0 && tnt.GetName() != name {
+			continue
+		}
+		// Tenants not owned by the requester are not disclosed, even if existing.
+		if !utils.IsTenantOwner(tnt.Spec.Owners, *userInfo) {
+			continue
+		}
+
+		namespaces := tnt.GetNamespaces()
+		sort.Strings(namespaces)
+
+		documents = append(documents, Document{
+			Tenant:                   tnt.GetName(),
+			ServerURL:                spec.ServerURL,
+			CertificateAuthorityData: ca,
+			IssuerURL:                spec.IssuerURL,
+			ClientID:                 spec.ClientID,
+			Namespaces:               namespaces,
+		})
+	}
+
+	var body interface{} = documents
+
+	if len(name) > 0 {
+		if len(documents) == 0 {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		body = documents[0]
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err = json.NewEncoder(w).Encode(body); err != nil {
+		h.Log.Error(err, "cannot encode discovery document")
+	}
+}
+
+// authenticate returns the UserInfo of the requester, nil if the bearer token is missing or not valid
+// for any of the given audiences.
+func authenticate(ctx context.Context, c client.Client, r *http.Request, audiences []string) (*authenticationv1.UserInfo, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || len(token) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: audiences,
+		},
+	}
+
+	if err := c.Create(ctx, review); err != nil {
+		return nil, err
+	}
+
+	if !review.Status.Authenticated {
+		return nil, nil //nolint:nilnil
+	}
+	// The authenticator reports the requested audiences the token is valid for: one is required.
+	if len(audiences) > 0 && !slices.ContainsFunc(review.Status.Audiences, func(audience string) bool {
+		return slices.Contains(audiences, audience)
+	}) {
+		return nil, nil //nolint:nilnil
+	}
+
+	return &review.Status.User, nil
+}
+
+// certificateAuthority returns the base64 encoded Certificate Authority of the server URL.
+func (h *Handler) certificateAuthority(ctx context.Context, spec *capsulev1beta2.DiscoverySpec) (string, error) {
+	if len(spec.CertificateAuthority) > 0 {
+		return base64.StdEncoding.EncodeToString([]byte(spec.CertificateAuthority)), nil
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := h.APIReader.Get(ctx, types.NamespacedName{Namespace: h.Namespace, Name: rootCAConfigMap}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString([]byte(cm.Data["ca.crt"])), nil
+}
@@ -0,0 +1,193 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/configuration"
+)
+
+// apiServerAudience is the audience of the bearer tokens accepted by the fake TokenReview.
+const apiServerAudience = "https://kubernetes.default.svc"
+
+// tokens maps the bearer tokens accepted by the fake TokenReview to the authenticated users.
+var tokens = map[string]authenticationv1.UserInfo{
+	"alice-token": {Username: "alice", Groups: []string{"projectcapsule.dev"}},
+	"bob-token":   {Username: "bob", Groups: []string{"projectcapsule.dev"}},
+	"joe-token":   {Username: "joe", Groups: []string{"system:authenticated"}},
+}
+
+func newTenant(name, owner string) *capsulev1beta2.Tenant {
+	return &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: name, UID: types.UID("uid-" + name)},
+		Spec: capsulev1beta2.TenantSpec{
+			Owners: capsulev1beta2.OwnerListSpec{{Kind: capsulev1beta2.UserOwner, Name: owner}},
+		},
+	}
+}
+
+// newClientBuilder returns a client builder authenticating the requests with the tokens map.
+func newClientBuilder(t *testing.T, objs ...client.Object) *fake.ClientBuilder {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, capsulev1beta2.AddToScheme(scheme))
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			review, ok := obj.(*authenticationv1.TokenReview)
+			if !ok {
+				return c.Create(ctx, obj, opts...)
+			}
+
+			review.Status.User, review.Status.Authenticated = tokens[review.Spec.Token]
+			// The authenticator reports the requested audiences the token is valid for, the API server ones if none.
+			if len(review.Spec.Audiences) == 0 || slices.Contains(review.Spec.Audiences, apiServerAudience) {
+				review.Status.Audiences = []string{apiServerAudience}
+			}
+
+			return nil
+		},
+	})
+}
+
+func serve(handler http.Handler, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if len(token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	return rec
+}
+
+// fakeConfiguration overrides the discovery settings only: the handler doesn't use any other setting.
+type fakeConfiguration struct {
+	configuration.Configuration
+
+	discovery *capsulev1beta2.DiscoverySpec
+}
+
+func (c fakeConfiguration) Discovery() *capsulev1beta2.DiscoverySpec {
+	return c.discovery
+}
+
+func newHandler(t *testing.T, spec *capsulev1beta2.DiscoverySpec) *Handler {
+	t.Helper()
+
+	oil, gas, fuel := newTenant("oil", "alice"), newTenant("gas", "bob"), newTenant("fuel", "alice")
+	oil.Status.Namespaces = []string{"oil-production", "oil-development"}
+	fuel.Status.Namespaces = []string{"fuel-production"}
+
+	rootCA := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "capsule-system", Name: rootCAConfigMap},
+		Data:       map[string]string{"ca.crt": "cluster-ca"},
+	}
+
+	c := newClientBuilder(t, oil, gas, fuel, rootCA).Build()
+
+	return &Handler{
+		Client:        c,
+		APIReader:     c,
+		Configuration: fakeConfiguration{discovery: spec},
+		Log:           logr.Discard(),
+		Namespace:     "capsule-system",
+	}
+}
+
+func decodeDocuments(t *testing.T, body []byte) []Document {
+	t.Helper()
+
+	var documents []Document
+	require.NoError(t, json.Unmarshal(body, &documents))
+
+	return documents
+}
+
+func TestHandler(t *testing.T) {
+	handler := newHandler(t, &capsulev1beta2.DiscoverySpec{ServerURL: "https://capsule-proxy.example.com", ClientID: "kubernetes"})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, serve(handler, Path, "").Code)
+		assert.Equal(t, http.StatusUnauthorized, serve(handler, Path+"oil", "unknown-token").Code)
+	})
+
+	t.Run("not owner", func(t *testing.T) {
+		rec := serve(handler, Path, "joe-token")
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, decodeDocuments(t, rec.Body.Bytes()))
+
+		assert.Equal(t, http.StatusNotFound, serve(handler, Path+"oil", "joe-token").Code)
+	})
+
+	t.Run("owner", func(t *testing.T) {
+		rec := serve(handler, Path, "alice-token")
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		documents := decodeDocuments(t, rec.Body.Bytes())
+
+		tenants := make([]string, 0, len(documents))
+		for _, document := range documents {
+			tenants = append(tenants, document.Tenant)
+		}
+
+		assert.ElementsMatch(t, []string{"oil", "fuel"}, tenants)
+	})
+
+	t.Run("owner single Tenant", func(t *testing.T) {
+		rec := serve(handler, Path+"oil", "alice-token")
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		document := Document{}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&document))
+
+		assert.Equal(t, Document{
+			Tenant:                   "oil",
+			ServerURL:                "https://capsule-proxy.example.com",
+			CertificateAuthorityData: base64.StdEncoding.EncodeToString([]byte("cluster-ca")),
+			ClientID:                 "kubernetes",
+			Namespaces:               []string{"oil-development", "oil-production"},
+		}, document)
+
+		assert.Equal(t, http.StatusNotFound, serve(handler, Path+"gas", "alice-token").Code)
+	})
+
+	t.Run("audiences", func(t *testing.T) {
+		handler.Audiences = []string{"capsule"}
+		assert.Equal(t, http.StatusUnauthorized, serve(handler, Path, "alice-token").Code)
+
+		handler.Audiences = []string{"capsule", apiServerAudience}
+		assert.Equal(t, http.StatusOK, serve(handler, Path, "alice-token").Code)
+
+		handler.Audiences = nil
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, serve(newHandler(t, nil), Path, "alice-token").Code)
+	})
+}
@@ -0,0 +1,258 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultMaxBytes is the size of the records retained by each ConfigMap of the configmap backend, unless specified:
+	// the oldest records are dropped, keeping the ConfigMap far from the etcd object size limit.
+	DefaultMaxBytes = 256 * 1024
+	// ConfigMapLabel marks the ConfigMaps of the configmap backend.
+	ConfigMapLabel = "capsule.clastix.io/history"
+
+	configMapPrefix           = "capsule-history"
+	configMapTenantAnnotation = "capsule.clastix.io/history-tenant"
+)
+
+// ConfigMapStore retains the records in a ConfigMap per Tenant, as JSON lines keyed by record kind:
+// suitable for small installations only, since the history is stored in etcd.
+type ConfigMapStore struct {
+	client    client.Client
+	namespace string
+	maxBytes  int
+}
+
+func NewConfigMapStore(c client.Client, namespace string, maxBytes int) *ConfigMapStore {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+
+	return &ConfigMapStore{client: c, namespace: namespace, maxBytes: maxBytes}
+}
+
+// ConfigMapName returns the name of the ConfigMap retaining the records of the Tenant,
+// the records not belonging to any Tenant are retained by the capsule-history one.
+func ConfigMapName(tenant string) string {
+	if len(tenant) == 0 {
+		return configMapPrefix
+	}
+
+	return fmt.Sprintf("%s-%s", configMapPrefix, tenant)
+}
+
+func (c *ConfigMapStore) Append(ctx context.Context, records ...Record) error {
+	byTenant := map[string][]Record{}
+
+	for _, record := range records {
+		byTenant[record.Tenant] = append(byTenant[record.Tenant], record)
+	}
+
+	for tenant, tenantRecords := range byTenant {
+		if err := c.update(ctx, tenant, func(data map[string][]Record) {
+			for _, record := range tenantRecords {
+				data[string(record.Kind)] = append(data[string(record.Kind)], record)
+			}
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *ConfigMapStore) List(ctx context.Context, query Query) ([]Record, error) {
+	var configMaps []corev1.ConfigMap
+
+	if len(query.Tenant) > 0 {
+		cm := corev1.ConfigMap{}
+		if err := c.client.Get(ctx, types.NamespacedName{Namespace: c.namespace, Name: ConfigMapName(query.Tenant)}, &cm); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, nil
+			}
+
+			return nil, err
+		}
+
+		configMaps = append(configMaps, cm)
+	} else {
+		cmList := &corev1.ConfigMapList{}
+		if err := c.client.List(ctx, cmList, client.InNamespace(c.namespace), client.MatchingLabels{ConfigMapLabel: "true"}); err != nil {
+			return nil, err
+		}
+
+		configMaps = cmList.Items
+	}
+
+	var records []Record
+
+	for _, cm := range configMaps {
+		data, err := decodeConfigMap(&cm)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, kindRecords := range data {
+			records = append(records, kindRecords...)
+		}
+	}
+
+	return selectRecords(records, query), nil
+}
+
+func (c *ConfigMapStore) Prune(ctx context.Context, before time.Time) error {
+	cmList := &corev1.ConfigMapList{}
+	if err := c.client.List(ctx, cmList, client.InNamespace(c.namespace), client.MatchingLabels{ConfigMapLabel: "true"}); err != nil {
+		return err
+	}
+
+	for _, cm := range cmList.Items {
+		tenant := cm.GetAnnotations()[configMapTenantAnnotation]
+
+		if err := c.update(ctx, tenant, func(data map[string][]Record) {
+			for kind, kindRecords := range data {
+				retained := kindRecords[:0]
+
+				for _, record := range kindRecords {
+					if !record.Time.Before(before) {
+						retained = append(retained, record)
+					}
+				}
+
+				data[kind] = retained
+			}
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *ConfigMapStore) Close() error {
+	return nil
+}
+
+// update applies the mutation to the records of the Tenant ConfigMap, creating it if missing,
+// and dropping the oldest records exceeding the maximum size.
+func (c *ConfigMapStore) update(ctx context.Context, tenant string, mutate func(data map[string][]Record)) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		cm := &corev1.ConfigMap{}
+
+		err := c.client.Get(ctx, types.NamespacedName{Namespace: c.namespace, Name: ConfigMapName(tenant)}, cm)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		create := apierrors.IsNotFound(err)
+		if create {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      ConfigMapName(tenant),
+					Namespace: c.namespace,
+					Labels: map[string]string{
+						ConfigMapLabel: "true",
+					},
+					Annotations: map[string]string{
+						configMapTenantAnnotation: tenant,
+					},
+				},
+			}
+		}
+
+		data, err := decodeConfigMap(cm)
+		if err != nil {
+			return err
+		}
+
+		mutate(data)
+
+		cm.Data = make(map[string]string, len(data))
+
+		for kind, kindRecords := range data {
+			if cm.Data[kind], err = encodeRecords(kindRecords, c.maxBytes); err != nil {
+				return err
+			}
+		}
+
+		if create {
+			return c.client.Create(ctx, cm)
+		}
+
+		return c.client.Update(ctx, cm)
+	})
+}
+
+func decodeConfigMap(cm *corev1.ConfigMap) (map[string][]Record, error) {
+	data := make(map[string][]Record, len(cm.Data))
+
+	for kind, lines := range cm.Data {
+		scanner := bufio.NewScanner(bytes.NewBufferString(lines))
+		scanner.Buffer(make([]byte, 0, 64*1024), len(lines)+1)
+
+		for scanner.Scan() {
+			if len(scanner.Bytes()) == 0 {
+				continue
+			}
+
+			var record Record
+			if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+				return nil, fmt.Errorf("cannot decode the %s records of the ConfigMap %s: %w", kind, cm.GetName(), err)
+			}
+
+			data[kind] = append(data[kind], record)
+		}
+
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
+
+// encodeRecords returns the JSON lines of the most recent records fitting the maximum size.
+func encodeRecords(records []Record, maxBytes int) (string, error) {
+	sortByTime(records)
+
+	lines := make([][]byte, 0, len(records))
+	size := 0
+	// Iterating from the most recent record, until the maximum size is reached.
+	for i := len(records) - 1; i >= 0; i-- {
+		line, err := json.Marshal(records[i])
+		if err != nil {
+			return "", err
+		}
+
+		if size+len(line)+1 > maxBytes {
+			break
+		}
+
+		size += len(line) + 1
+		lines = append(lines, line)
+	}
+
+	var buf bytes.Buffer
+
+	for i := len(lines) - 1; i >= 0; i-- {
+		buf.Write(lines[i])
+		buf.WriteByte('\n')
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package history retains the audit records of the Capsule policy denials, and the usage history of the Tenants,
+// in a pluggable storage backend: larger installations can keep months of per-Tenant history out of etcd.
+package history
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Kind is the kind of a history record.
+type Kind string
+
+const (
+	// KindAudit records a request denied by a Capsule policy.
+	KindAudit Kind = "audit"
+	// KindUsage records a sample of the resources used by a Tenant.
+	KindUsage Kind = "usage"
+)
+
+// Backend is the name of a storage backend.
+type Backend string
+
+const (
+	BackendNone      Backend = "none"
+	BackendMemory    Backend = "memory"
+	BackendConfigMap Backend = "configmap"
+	BackendSQLite    Backend = "sqlite"
+	BackendPostgres  Backend = "postgres"
+)
+
+// Record is an entry of the history, either an audit record or a usage sample.
+type Record struct {
+	Time   time.Time `json:"time"`
+	Kind   Kind      `json:"kind"`
+	Tenant string    `json:"tenant,omitempty"`
+	// Namespace, Resource, and Name of the denied object.
+	Namespace string `json:"namespace,omitempty"`
+	Resource  string `json:"resource,omitempty"`
+	Name      string `json:"name,omitempty"`
+	// Operation and User of the denied request.
+	Operation string `json:"operation,omitempty"`
+	User      string `json:"user,omitempty"`
+	// Code and Message of the denying policy.
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+	// Usage of the Tenant resources, at the time of the sample.
+	Usage corev1.ResourceList `json:"usage,omitempty"`
+}
+
+// Query selects the records of a kind: the zero values of the other fields do not filter.
+type Query struct {
+	Kind   Kind
+	Tenant string
+	Since  time.Time
+	Until  time.Time
+	// Limit retains the most recent records only.
+	Limit int
+}
+
+// Matches returns true when the record is selected by the query, regardless of the limit.
+func (q Query) Matches(record Record) bool {
+	if len(q.Kind) > 0 && record.Kind != q.Kind {
+		return false
+	}
+
+	if len(q.Tenant) > 0 && record.Tenant != q.Tenant {
+		return false
+	}
+
+	if !q.Since.IsZero() && record.Time.Before(q.Since) {
+		return false
+	}
+
+	if !q.Until.IsZero() && !record.Time.Before(q.Until) {
+		return false
+	}
+
+	return true
+}
+
+// Store retains the history records.
+type Store interface {
+	// Append stores the records.
+	Append(ctx context.Context, records ...Record) error
+	// List returns the records selected by the query, sorted by time.
+	List(ctx context.Context, query Query) ([]Record, error)
+	// Prune removes the records older than the given time.
+	Prune(ctx context.Context, before time.Time) error
+	Close() error
+}
+
+// Options configures the storage backend opened by Open.
+type Options struct {
+	// Client and Namespace of the ConfigMaps used by the configmap backend.
+	Client    client.Client
+	Namespace string
+	// DSN of the SQL backends: the database file path for sqlite, the connection string for postgres.
+	DSN string
+	// MaxRecords retained by the memory backend.
+	MaxRecords int
+	// MaxBytes of each ConfigMap of the configmap backend.
+	MaxBytes int
+}
+
+// Open returns the store of the given backend, or nil with the none one.
+func Open(backend Backend, opts Options) (Store, error) {
+	switch backend {
+	case BackendNone, "":
+		return nil, nil //nolint:nilnil
+	case BackendMemory:
+		return NewMemoryStore(opts.MaxRecords), nil
+	case BackendConfigMap:
+		if opts.Client == nil || len(opts.Namespace) == 0 {
+			return nil, fmt.Errorf("the configmap history backend requires a client and a namespace")
+		}
+
+		return NewConfigMapStore(opts.Client, opts.Namespace, opts.MaxBytes), nil
+	case BackendSQLite, BackendPostgres:
+		if len(opts.DSN) == 0 {
+			return nil, fmt.Errorf("the %s history backend requires a DSN", backend)
+		}
+
+		return NewSQLStore(backend, opts.DSN)
+	default:
+		return nil, fmt.Errorf("unsupported history backend %s", backend)
+	}
+}
+
+// selectRecords returns the records selected by the query, sorted by time and limited to the most recent ones.
+func selectRecords(records []Record, query Query) []Record {
+	selected := make([]Record, 0, len(records))
+
+	for _, record := range records {
+		if query.Matches(record) {
+			selected = append(selected, record)
+		}
+	}
+
+	sortByTime(selected)
+
+	if query.Limit > 0 && len(selected) > query.Limit {
+		selected = selected[len(selected)-query.Limit:]
+	}
+
+	return selected
+}
+
+func sortByTime(records []Record) {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Time.Before(records[j].Time)
+	})
+}
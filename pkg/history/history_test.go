@@ -0,0 +1,117 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package history
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestStores(t *testing.T) {
+	sqlite, err := NewSQLStore(BackendSQLite, filepath.Join(t.TempDir(), "history.db"))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = sqlite.Close()
+	})
+
+	stores := map[string]Store{
+		"memory":    NewMemoryStore(0),
+		"configmap": NewConfigMapStore(fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(), "capsule-system", 0),
+		"sqlite":    sqlite,
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	records := []Record{
+		{Time: now.Add(-48 * time.Hour), Kind: KindAudit, Tenant: "oil", Namespace: "oil-production", Resource: "pods", Name: "web", Code: "CAPS-POD-001", Message: "denied"},
+		{Time: now.Add(-time.Hour), Kind: KindAudit, Tenant: "oil", Namespace: "oil-production", Resource: "services", Name: "web", Code: "CAPS-SVC-001"},
+		{Time: now.Add(-time.Hour), Kind: KindUsage, Tenant: "oil", Usage: corev1.ResourceList{"namespaces": resource.MustParse("2")}},
+		{Time: now, Kind: KindAudit, Tenant: "gas", Namespace: "gas-production", Resource: "pods", Name: "api"},
+		{Time: now, Kind: KindAudit, Resource: "namespaces", Name: "orphan"},
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			require.NoError(t, store.Append(ctx, records...))
+
+			audits, err := store.List(ctx, Query{Kind: KindAudit, Tenant: "oil"})
+			require.NoError(t, err)
+			require.Len(t, audits, 2)
+			assert.Equal(t, "CAPS-POD-001", audits[0].Code)
+			assert.Equal(t, "denied", audits[0].Message)
+			assert.True(t, audits[0].Time.Equal(records[0].Time))
+			assert.Equal(t, "CAPS-SVC-001", audits[1].Code)
+
+			latest, err := store.List(ctx, Query{Kind: KindAudit, Tenant: "oil", Limit: 1})
+			require.NoError(t, err)
+			require.Len(t, latest, 1)
+			assert.Equal(t, "CAPS-SVC-001", latest[0].Code)
+
+			usage, err := store.List(ctx, Query{Kind: KindUsage, Tenant: "oil"})
+			require.NoError(t, err)
+			require.Len(t, usage, 1)
+			assert.True(t, usage[0].Usage.Name("namespaces", resource.DecimalSI).Equal(resource.MustParse("2")))
+
+			all, err := store.List(ctx, Query{Kind: KindAudit, Since: now.Add(-2 * time.Hour)})
+			require.NoError(t, err)
+			assert.Len(t, all, 3)
+
+			require.NoError(t, store.Prune(ctx, now.Add(-24*time.Hour)))
+
+			audits, err = store.List(ctx, Query{Tenant: "oil"})
+			require.NoError(t, err)
+			assert.Len(t, audits, 2)
+		})
+	}
+}
+
+func TestMemoryStoreMaxRecords(t *testing.T) {
+	store := NewMemoryStore(2)
+
+	now := time.Now()
+
+	require.NoError(t, store.Append(context.Background(),
+		Record{Time: now, Kind: KindAudit, Name: "first"},
+		Record{Time: now.Add(time.Second), Kind: KindAudit, Name: "second"},
+		Record{Time: now.Add(2 * time.Second), Kind: KindAudit, Name: "third"},
+	))
+
+	records, err := store.List(context.Background(), Query{})
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "second", records[0].Name)
+	assert.Equal(t, "third", records[1].Name)
+}
+
+func TestEncodeRecordsMaxBytes(t *testing.T) {
+	now := time.Now()
+
+	records := []Record{
+		{Time: now, Kind: KindAudit, Name: "first"},
+		{Time: now.Add(time.Second), Kind: KindAudit, Name: "second"},
+	}
+
+	encoded, err := encodeRecords(records, 100)
+	require.NoError(t, err)
+	assert.NotContains(t, encoded, "first")
+	assert.Contains(t, encoded, "second")
+}
+
+func TestSQLStoreRebind(t *testing.T) {
+	store := &SQLStore{postgres: true}
+
+	assert.Equal(t, "DELETE FROM capsule_history WHERE time < $1 AND kind = $2", store.rebind("DELETE FROM capsule_history WHERE time < ? AND kind = ?"))
+}
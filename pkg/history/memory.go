@@ -0,0 +1,70 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package history
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultMaxRecords is the number of records retained by the memory backend, unless specified.
+const DefaultMaxRecords = 10000
+
+// MemoryStore retains the most recent records in memory: the history is lost upon restarts,
+// and each replica only retains the audit records of the requests it served.
+type MemoryStore struct {
+	mu         sync.RWMutex
+	records    []Record
+	maxRecords int
+}
+
+func NewMemoryStore(maxRecords int) *MemoryStore {
+	if maxRecords <= 0 {
+		maxRecords = DefaultMaxRecords
+	}
+
+	return &MemoryStore{maxRecords: maxRecords}
+}
+
+func (m *MemoryStore) Append(_ context.Context, records ...Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.records = append(m.records, records...)
+
+	if exceeding := len(m.records) - m.maxRecords; exceeding > 0 {
+		m.records = append([]Record(nil), m.records[exceeding:]...)
+	}
+
+	return nil
+}
+
+func (m *MemoryStore) List(_ context.Context, query Query) ([]Record, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return selectRecords(m.records, query), nil
+}
+
+func (m *MemoryStore) Prune(_ context.Context, before time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	retained := m.records[:0]
+
+	for _, record := range m.records {
+		if !record.Time.Before(before) {
+			retained = append(retained, record)
+		}
+	}
+
+	m.records = retained
+
+	return nil
+}
+
+func (m *MemoryStore) Close() error {
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package history
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	recorderBufferSize = 1024
+	recorderBatchSize  = 100
+	// DefaultFlushInterval is the interval between two writes of the buffered records, unless specified.
+	DefaultFlushInterval = 5 * time.Second
+)
+
+// Recorder buffers the records, writing them in batches to the store: the admission requests are not delayed
+// by the storage backend, and the records exceeding the buffer are dropped.
+// A nil Recorder discards the records.
+type Recorder struct {
+	Store Store
+	Log   logr.Logger
+	// FlushInterval between two writes of the buffered records.
+	FlushInterval time.Duration
+
+	records chan Record
+}
+
+func NewRecorder(store Store, log logr.Logger) *Recorder {
+	return &Recorder{
+		Store:         store,
+		Log:           log,
+		FlushInterval: DefaultFlushInterval,
+		records:       make(chan Record, recorderBufferSize),
+	}
+}
+
+// Record enqueues the record, without blocking.
+func (r *Recorder) Record(record Record) {
+	if r == nil {
+		return
+	}
+
+	if record.Time.IsZero() {
+		record.Time = time.Now()
+	}
+
+	select {
+	case r.records <- record:
+	default:
+		r.Log.Info("dropping history record, the buffer is full", "kind", record.Kind, "tenant", record.Tenant)
+	}
+}
+
+// NeedLeaderElection returns false, since all the replicas serve the webhooks and record their denials.
+func (r *Recorder) NeedLeaderElection() bool {
+	return false
+}
+
+func (r *Recorder) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Record, 0, recorderBatchSize)
+
+	flush := func(ctx context.Context) {
+		if len(batch) == 0 {
+			return
+		}
+
+		if err := r.Store.Append(ctx, batch...); err != nil {
+			r.Log.Error(err, "cannot store history records", "count", len(batch))
+		}
+
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			// Storing the buffered records before the shutdown.
+			for len(r.records) > 0 {
+				batch = append(batch, <-r.records)
+			}
+
+			flushCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			flush(flushCtx)
+			cancel()
+
+			return nil
+		case record := <-r.records:
+			batch = append(batch, record)
+
+			if len(batch) >= recorderBatchSize {
+				flush(ctx)
+			}
+		case <-ticker.C:
+			flush(ctx)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package history
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	// Registering the pgx driver for the postgres backend.
+	_ "github.com/jackc/pgx/v5/stdlib"
+	// Registering the pure Go sqlite driver for the sqlite backend, since Capsule is built without cgo.
+	_ "modernc.org/sqlite"
+)
+
+var sqlSchema = []string{
+	`CREATE TABLE IF NOT EXISTS capsule_history (
+		time BIGINT NOT NULL,
+		kind VARCHAR(16) NOT NULL,
+		tenant VARCHAR(253) NOT NULL,
+		record TEXT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS capsule_history_lookup ON capsule_history (kind, tenant, time)`,
+	`CREATE INDEX IF NOT EXISTS capsule_history_time ON capsule_history (time)`,
+}
+
+// SQLStore retains the records in the capsule_history table of a SQLite database, such as a file on a
+// PersistentVolume, or of an external PostgreSQL database: the table is created if missing.
+type SQLStore struct {
+	db       *sql.DB
+	postgres bool
+}
+
+func NewSQLStore(backend Backend, dsn string) (*SQLStore, error) {
+	driver := "sqlite"
+	if backend == BackendPostgres {
+		driver = "pgx"
+	}
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open the %s history database: %w", backend, err)
+	}
+
+	if backend == BackendSQLite {
+		// SQLite serializes the writes: a single connection avoids the database is locked errors.
+		db.SetMaxOpenConns(1)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	for _, statement := range sqlSchema {
+		if _, err = db.ExecContext(ctx, statement); err != nil {
+			_ = db.Close()
+
+			return nil, fmt.Errorf("cannot create the %s history schema: %w", backend, err)
+		}
+	}
+
+	return &SQLStore{db: db, postgres: backend == BackendPostgres}, nil
+}
+
+func (s *SQLStore) Append(ctx context.Context, records ...Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	statement, err := tx.PrepareContext(ctx, s.rebind("INSERT INTO capsule_history (time, kind, tenant, record) VALUES (?, ?, ?, ?)"))
+	if err != nil {
+		return err
+	}
+
+	defer statement.Close()
+
+	for _, record := range records {
+		encoded, marshalErr := json.Marshal(record)
+		if marshalErr != nil {
+			return marshalErr
+		}
+
+		if _, err = statement.ExecContext(ctx, record.Time.UnixNano(), string(record.Kind), record.Tenant, string(encoded)); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *SQLStore) List(ctx context.Context, query Query) ([]Record, error) {
+	var conditions []string
+
+	var args []interface{}
+
+	if len(query.Kind) > 0 {
+		conditions = append(conditions, "kind = ?")
+		args = append(args, string(query.Kind))
+	}
+
+	if len(query.Tenant) > 0 {
+		conditions = append(conditions, "tenant = ?")
+		args = append(args, query.Tenant)
+	}
+
+	if !query.Since.IsZero() {
+		conditions = append(conditions, "time >= ?")
+		args = append(args, query.Since.UnixNano())
+	}
+
+	if !query.Until.IsZero() {
+		conditions = append(conditions, "time < ?")
+		args = append(args, query.Until.UnixNano())
+	}
+
+	statement := "SELECT record FROM capsule_history"
+	if len(conditions) > 0 {
+		statement += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	// Selecting the most recent records first, in order to apply the limit.
+	statement += " ORDER BY time DESC"
+
+	if query.Limit > 0 {
+		statement += fmt.Sprintf(" LIMIT %d", query.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, s.rebind(statement), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var records []Record
+
+	for rows.Next() {
+		var encoded string
+		if err = rows.Scan(&encoded); err != nil {
+			return nil, err
+		}
+
+		var record Record
+		if err = json.Unmarshal([]byte(encoded), &record); err != nil {
+			return nil, fmt.Errorf("cannot decode history record: %w", err)
+		}
+
+		records = append(records, record)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sortByTime(records)
+
+	return records, nil
+}
+
+func (s *SQLStore) Prune(ctx context.Context, before time.Time) error {
+	_, err := s.db.ExecContext(ctx, s.rebind("DELETE FROM capsule_history WHERE time < ?"), before.UnixNano())
+
+	return err
+}
+
+func (s *SQLStore) Close() error {
+	return s.db.Close()
+}
+
+// rebind replaces the ? placeholders with the positional ones of PostgreSQL.
+func (s *SQLStore) rebind(statement string) string {
+	if !s.postgres {
+		return statement
+	}
+
+	var b strings.Builder
+
+	position := 0
+
+	for _, r := range statement {
+		if r != '?' {
+			b.WriteRune(r)
+
+			continue
+		}
+
+		position++
+
+		fmt.Fprintf(&b, "$%d", position)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,164 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package impact summarizes the resources deleted along with a Tenant, allowing a preview before its deletion.
+package impact
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+// ConfirmDeletionAnnotation must be set to the Tenant name to confirm the deletion of a Tenant exceeding the impact thresholds.
+const ConfirmDeletionAnnotation = "capsule.clastix.io/confirm-deletion"
+
+// Summary contains the resources deleted along with a Tenant.
+type Summary struct {
+	Tenant     string   `json:"tenant"`
+	Namespaces []string `json:"namespaces"`
+	// Workloads is the number of workloads per kind: standalone Pods are the ones not controlled by other objects.
+	Workloads              map[string]int    `json:"workloads"`
+	PersistentVolumeClaims int               `json:"persistentVolumeClaims"`
+	Storage                resource.Quantity `json:"storage"`
+	// LoadBalancers are the LoadBalancer Services, in the <namespace>/<name> format.
+	LoadBalancers []string `json:"loadBalancers"`
+}
+
+//nolint:gochecknoglobals
+var workloadLists = map[string]func() client.ObjectList{
+	"Deployment":  func() client.ObjectList { return &appsv1.DeploymentList{} },
+	"StatefulSet": func() client.ObjectList { return &appsv1.StatefulSetList{} },
+	"DaemonSet":   func() client.ObjectList { return &appsv1.DaemonSetList{} },
+	"Job":         func() client.ObjectList { return &batchv1.JobList{} },
+	"CronJob":     func() client.ObjectList { return &batchv1.CronJobList{} },
+	"Pod":         func() client.ObjectList { return &corev1.PodList{} },
+}
+
+// Compute returns the impact of the Tenant deletion: a non cached reader is suggested,
+// since the workloads are not required to be cached by Capsule.
+func Compute(ctx context.Context, c client.Reader, tnt *capsulev1beta2.Tenant) (*Summary, error) {
+	summary := &Summary{
+		Tenant:        tnt.GetName(),
+		Namespaces:    tnt.GetNamespaces(),
+		Workloads:     make(map[string]int, len(workloadLists)),
+		LoadBalancers: []string{},
+	}
+
+	sort.Strings(summary.Namespaces)
+
+	for _, ns := range summary.Namespaces {
+		for kind, newList := range workloadLists {
+			list := newList()
+			if err := c.List(ctx, list, client.InNamespace(ns)); err != nil {
+				return nil, fmt.Errorf("cannot list %s objects in Namespace %s: %w", kind, ns, err)
+			}
+
+			items, err := meta.ExtractList(list)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, item := range items {
+				obj, ok := item.(metav1.Object)
+				// Controlled objects, such as the Pods of a Deployment, are accounted with their controller.
+				if !ok || metav1.GetControllerOf(obj) != nil {
+					continue
+				}
+
+				summary.Workloads[kind]++
+			}
+		}
+
+		pvcList := &corev1.PersistentVolumeClaimList{}
+		if err := c.List(ctx, pvcList, client.InNamespace(ns)); err != nil {
+			return nil, fmt.Errorf("cannot list PersistentVolumeClaim objects in Namespace %s: %w", ns, err)
+		}
+
+		for _, pvc := range pvcList.Items {
+			summary.PersistentVolumeClaims++
+
+			if storage, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+				summary.Storage.Add(storage)
+			}
+		}
+
+		svcList := &corev1.ServiceList{}
+		if err := c.List(ctx, svcList, client.InNamespace(ns)); err != nil {
+			return nil, fmt.Errorf("cannot list Service objects in Namespace %s: %w", ns, err)
+		}
+
+		for _, svc := range svcList.Items {
+			if svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+				summary.LoadBalancers = append(summary.LoadBalancers, svc.GetNamespace()+"/"+svc.GetName())
+			}
+		}
+	}
+
+	return summary, nil
+}
+
+// WorkloadsCount returns the number of workloads of any kind.
+func (s *Summary) WorkloadsCount() (count int) {
+	for _, c := range s.Workloads {
+		count += c
+	}
+
+	return count
+}
+
+// Exceeded returns the description of the thresholds exceeded by the Tenant deletion, empty when none is.
+func (s *Summary) Exceeded(thresholds *capsulev1beta2.DeletionImpactThresholds) (exceeded []string) {
+	if thresholds == nil {
+		return nil
+	}
+
+	if thresholds.Namespaces != nil && len(s.Namespaces) > int(*thresholds.Namespaces) {
+		exceeded = append(exceeded, fmt.Sprintf("%d Namespaces exceed the threshold of %d", len(s.Namespaces), *thresholds.Namespaces))
+	}
+
+	if count := s.WorkloadsCount(); thresholds.Workloads != nil && count > int(*thresholds.Workloads) {
+		exceeded = append(exceeded, fmt.Sprintf("%d workloads exceed the threshold of %d", count, *thresholds.Workloads))
+	}
+
+	if thresholds.Storage != nil && s.Storage.Cmp(*thresholds.Storage) > 0 {
+		exceeded = append(exceeded, fmt.Sprintf("%s of storage exceed the threshold of %s", s.Storage.String(), thresholds.Storage.String()))
+	}
+
+	if thresholds.LoadBalancers != nil && len(s.LoadBalancers) > int(*thresholds.LoadBalancers) {
+		exceeded = append(exceeded, fmt.Sprintf("%d LoadBalancer Services exceed the threshold of %d", len(s.LoadBalancers), *thresholds.LoadBalancers))
+	}
+
+	return exceeded
+}
+
+func (s *Summary) String() string {
+	kinds := make([]string, 0, len(s.Workloads))
+
+	for kind, count := range s.Workloads {
+		if count > 0 {
+			kinds = append(kinds, fmt.Sprintf("%d %s", count, kind))
+		}
+	}
+
+	sort.Strings(kinds)
+
+	workloads := "none"
+	if len(kinds) > 0 {
+		workloads = strings.Join(kinds, ", ")
+	}
+
+	return fmt.Sprintf("Tenant %s: %d Namespaces, workloads: %s, %d PersistentVolumeClaims requesting %s, %d LoadBalancer Services",
+		s.Tenant, len(s.Namespaces), workloads, s.PersistentVolumeClaims, s.Storage.String(), len(s.LoadBalancers))
+}
@@ -0,0 +1,73 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package impact
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+func TestCompute(t *testing.T) {
+	tnt := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "oil"},
+		Status:     capsulev1beta2.TenantStatus{Namespaces: []string{"oil-production", "oil-development"}},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "oil-production"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:            "web-1",
+			Namespace:       "oil-production",
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web", UID: "1", Controller: ptr.To(true)}},
+		}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "oil-development"}},
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "oil-production"},
+			Spec: corev1.PersistentVolumeClaimSpec{Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			}},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "oil-production"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+		},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "internal", Namespace: "oil-production"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "gas-production"}},
+	).Build()
+
+	summary, err := Compute(context.Background(), c, tnt)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"oil-development", "oil-production"}, summary.Namespaces)
+	assert.Equal(t, 1, summary.Workloads["Deployment"])
+	assert.Equal(t, 1, summary.Workloads["Pod"])
+	assert.Equal(t, 2, summary.WorkloadsCount())
+	assert.Equal(t, 1, summary.PersistentVolumeClaims)
+	assert.Equal(t, "10Gi", summary.Storage.String())
+	assert.Equal(t, []string{"oil-production/web"}, summary.LoadBalancers)
+
+	assert.Empty(t, summary.Exceeded(nil))
+	assert.Empty(t, summary.Exceeded(&capsulev1beta2.DeletionImpactThresholds{
+		Namespaces:    ptr.To[int32](2),
+		Storage:       ptr.To(resource.MustParse("10Gi")),
+		LoadBalancers: ptr.To[int32](1),
+	}))
+	assert.Len(t, summary.Exceeded(&capsulev1beta2.DeletionImpactThresholds{
+		Namespaces: ptr.To[int32](1),
+		Workloads:  ptr.To[int32](1),
+		Storage:    ptr.To(resource.MustParse("5Gi")),
+	}), 3)
+}
@@ -0,0 +1,152 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package managedfields
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+	"sigs.k8s.io/structured-merge-diff/v4/value"
+)
+
+// Manager is the field manager of the objects generated by the Capsule controllers.
+const Manager = "capsule"
+
+// Owned returns the fields owned by the given field manager, nil when it owns none.
+func Owned(obj metav1.Object, manager string) (*fieldpath.Set, error) {
+	var owned *fieldpath.Set
+
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager != manager || entry.FieldsV1 == nil {
+			continue
+		}
+
+		set := &fieldpath.Set{}
+		if err := set.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			return nil, err
+		}
+
+		if owned == nil {
+			owned = set
+		} else {
+			owned = owned.Union(set)
+		}
+	}
+
+	return owned, nil
+}
+
+// Violations returns the sorted paths of the owned fields changed, or removed, by the new object:
+// the owned lists can only be appended, while any other owned value must be left unchanged.
+func Violations(owned *fieldpath.Set, oldObj, newObj map[string]interface{}) (violations []string) {
+	if owned == nil {
+		return nil
+	}
+
+	owned.Leaves().Iterate(func(path fieldpath.Path) {
+		oldValue, ok := lookup(oldObj, path)
+		if !ok {
+			return
+		}
+
+		newValue, ok := lookup(newObj, path)
+		if !ok || !preserved(oldValue, newValue) {
+			violations = append(violations, path.String())
+		}
+	})
+
+	sort.Strings(violations)
+
+	return violations
+}
+
+// preserved checks the new value keeps the old one, allowing further items appended to lists.
+func preserved(oldValue, newValue interface{}) bool {
+	oldList, ok := oldValue.([]interface{})
+	if !ok {
+		return reflect.DeepEqual(oldValue, newValue)
+	}
+
+	newList, ok := newValue.([]interface{})
+	if !ok || len(newList) < len(oldList) {
+		return false
+	}
+
+	return reflect.DeepEqual(oldList, newList[:len(oldList)])
+}
+
+// lookup returns the value at the given path of the unstructured object.
+func lookup(obj interface{}, path fieldpath.Path) (interface{}, bool) {
+	current := obj
+
+	for _, element := range path {
+		switch {
+		case element.FieldName != nil:
+			m, ok := current.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+
+			if current, ok = m[*element.FieldName]; !ok {
+				return nil, false
+			}
+		case element.Index != nil:
+			l, ok := current.([]interface{})
+			if !ok || *element.Index < 0 || *element.Index >= len(l) {
+				return nil, false
+			}
+
+			current = l[*element.Index]
+		default:
+			l, ok := current.([]interface{})
+			if !ok {
+				return nil, false
+			}
+
+			found := false
+
+			for _, item := range l {
+				if matches(item, element) {
+					current, found = item, true
+
+					break
+				}
+			}
+
+			if !found {
+				return nil, false
+			}
+		}
+	}
+
+	return current, true
+}
+
+// matches checks the list item against the key, or the value, of the path element.
+func matches(item interface{}, element fieldpath.PathElement) bool {
+	if element.Value != nil {
+		return value.Equals(value.NewValueInterface(item), *element.Value)
+	}
+
+	if element.Key == nil {
+		return false
+	}
+
+	m, ok := item.(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	for _, field := range *element.Key {
+		v, ok := m[field.Name]
+		if !ok || !value.Equals(value.NewValueInterface(v), field.Value) {
+			return false
+		}
+	}
+
+	return true
+}
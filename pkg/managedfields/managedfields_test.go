@@ -0,0 +1,130 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package managedfields
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func networkPolicy(ingress []interface{}, labels map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":   "capsule-oil-0",
+			"labels": labels,
+			"ownerReferences": []interface{}{
+				map[string]interface{}{"uid": "1234", "kind": "Tenant", "name": "oil"},
+			},
+		},
+		"spec": map[string]interface{}{
+			"podSelector": map[string]interface{}{},
+			"ingress":     ingress,
+		},
+	}
+}
+
+func TestOwned(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	obj.SetManagedFields([]metav1.ManagedFieldsEntry{
+		{
+			Manager:  Manager,
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{".":{},"f:capsule.clastix.io/tenant":{}}}}`)},
+		},
+		{
+			Manager:  Manager,
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:ingress":{}}}`)},
+		},
+		{
+			Manager:  "kubectl-edit",
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{"f:team":{}}}}`)},
+		},
+	})
+
+	owned, err := Owned(obj, Manager)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, owned.Leaves().Size())
+
+	owned, err = Owned(obj, "unknown")
+	assert.NoError(t, err)
+	assert.Nil(t, owned)
+
+	_, err = Owned(&metav1.ObjectMeta{
+		ManagedFields: []metav1.ManagedFieldsEntry{{Manager: Manager, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{`)}}},
+	}, Manager)
+	assert.Error(t, err)
+}
+
+func TestViolations(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	obj.SetManagedFields([]metav1.ManagedFieldsEntry{
+		{
+			Manager:  Manager,
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{".":{},"f:capsule.clastix.io/tenant":{}},"f:ownerReferences":{".":{},"k:{\"uid\":\"1234\"}":{}}},"f:spec":{"f:podSelector":{},"f:ingress":{}}}`)},
+		},
+	})
+
+	owned, err := Owned(obj, Manager)
+	assert.NoError(t, err)
+
+	rule := map[string]interface{}{"from": []interface{}{map[string]interface{}{"podSelector": map[string]interface{}{}}}}
+	extra := map[string]interface{}{"ports": []interface{}{map[string]interface{}{"port": int64(8080)}}}
+	labels := map[string]interface{}{"capsule.clastix.io/tenant": "oil"}
+
+	old := networkPolicy([]interface{}{rule}, labels)
+
+	testCases := []struct {
+		name     string
+		new      map[string]interface{}
+		expected []string
+	}{
+		{
+			name: "unchanged",
+			new:  networkPolicy([]interface{}{rule}, labels),
+		},
+		{
+			name: "appended rule",
+			new:  networkPolicy([]interface{}{rule, extra}, labels),
+		},
+		{
+			name: "added label",
+			new:  networkPolicy([]interface{}{rule}, map[string]interface{}{"capsule.clastix.io/tenant": "oil", "team": "a"}),
+		},
+		{
+			name:     "replaced rule",
+			new:      networkPolicy([]interface{}{extra}, labels),
+			expected: []string{".spec.ingress"},
+		},
+		{
+			name:     "prepended rule",
+			new:      networkPolicy([]interface{}{extra, rule}, labels),
+			expected: []string{".spec.ingress"},
+		},
+		{
+			name:     "removed rules and label",
+			new:      networkPolicy(nil, map[string]interface{}{}),
+			expected: []string{".metadata.labels.capsule.clastix.io/tenant", ".spec.ingress"},
+		},
+		{
+			name: "removed owner reference",
+			new: func() map[string]interface{} {
+				np := networkPolicy([]interface{}{rule}, labels)
+				delete(np["metadata"].(map[string]interface{}), "ownerReferences")
+
+				return np
+			}(),
+			expected: []string{`.metadata.ownerReferences[uid="1234"]`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Violations(owned, old, tc.new))
+		})
+	}
+
+	assert.Nil(t, Violations(nil, old, networkPolicy(nil, nil)))
+}
@@ -20,11 +20,41 @@ var (
 		Name: metricsPrefix + "tenant_resource_limit",
 		Help: "Current resource limit for a given resource in a tenant",
 	}, []string{"tenant", "resource", "resourcequotaindex"})
+
+	TenantPendingPods = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: metricsPrefix + "tenant_pending_pods",
+		Help: "Current number of Pending Pods in a tenant",
+	}, []string{"tenant"})
+
+	TenantUnschedulablePods = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: metricsPrefix + "tenant_unschedulable_pods",
+		Help: "Current number of Pods the scheduler cannot place in a tenant",
+	}, []string{"tenant"})
+
+	TenantUnschedulableRequests = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: metricsPrefix + "tenant_unschedulable_requests",
+		Help: "Current sum of the resource requests of the unschedulable Pods in a tenant",
+	}, []string{"tenant", "resource"})
+
+	TenantPolicyViolations = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: metricsPrefix + "tenant_policy_violations",
+		Help: "Current number of existing objects violating a policy in a tenant, by policy code",
+	}, []string{"tenant", "code"})
+
+	PolicyDenials = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: metricsPrefix + "policy_denials_total",
+		Help: "Total number of admission requests denied by a policy, by policy code",
+	}, []string{"code"})
 )
 
 func init() {
 	metrics.Registry.MustRegister(
 		TenantResourceUsage,
 		TenantResourceLimit,
+		TenantPendingPods,
+		TenantUnschedulablePods,
+		TenantUnschedulableRequests,
+		TenantPolicyViolations,
+		PolicyDenials,
 	)
 }
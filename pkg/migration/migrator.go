@@ -0,0 +1,156 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const DefaultBatchSize = 100
+
+// Progress is notified after each migrated batch.
+type Progress func(migrated int, remaining *int64)
+
+// Migrator rewrites all the stored objects of a CustomResourceDefinition to its current storage version, in batches,
+// and then removes the former versions from the CustomResourceDefinition stored versions:
+// this allows removing a version from the CustomResourceDefinition without losing objects stored with it.
+//
+// Objects are rewritten with a no-op update, letting the API server serialize them with the storage version:
+// this doesn't change the objects, and can be performed while the clients are using them.
+type Migrator struct {
+	// Client must not be backed by a cache, since objects are listed in pages.
+	Client    client.Client
+	CRDName   string
+	BatchSize int64
+	Progress  Progress
+}
+
+// Result describes the outcome of a migration.
+type Result struct {
+	StorageVersion string
+	// Versions dropped from the CustomResourceDefinition stored versions.
+	MigratedVersions []string
+	// Number of objects rewritten with the storage version.
+	Migrated int
+}
+
+// IsRequired returns true if the CustomResourceDefinition has objects stored with a version different from the storage one.
+func IsRequired(crd *apiextensionsv1.CustomResourceDefinition) bool {
+	storageVersion := StorageVersion(crd)
+
+	for _, version := range crd.Status.StoredVersions {
+		if version != storageVersion {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StorageVersion returns the version used to persist the objects of the given CustomResourceDefinition.
+func StorageVersion(crd *apiextensionsv1.CustomResourceDefinition) string {
+	for _, version := range crd.Spec.Versions {
+		if version.Storage {
+			return version.Name
+		}
+	}
+
+	return ""
+}
+
+func (m *Migrator) Migrate(ctx context.Context) (result Result, err error) {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err = m.Client.Get(ctx, types.NamespacedName{Name: m.CRDName}, crd); err != nil {
+		return result, fmt.Errorf("cannot retrieve CustomResourceDefinition %s: %w", m.CRDName, err)
+	}
+
+	result.StorageVersion = StorageVersion(crd)
+	if len(result.StorageVersion) == 0 {
+		return result, fmt.Errorf("CustomResourceDefinition %s has no storage version", m.CRDName)
+	}
+
+	if !IsRequired(crd) {
+		return result, nil
+	}
+
+	gvk := schema.GroupVersionKind{
+		Group:   crd.Spec.Group,
+		Version: result.StorageVersion,
+		Kind:    crd.Spec.Names.ListKind,
+	}
+
+	batchSize := m.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	continueToken := ""
+
+	for {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk)
+
+		if err = m.Client.List(ctx, list, client.Limit(batchSize), client.Continue(continueToken)); err != nil {
+			return result, fmt.Errorf("cannot list %s: %w", crd.Spec.Names.Plural, err)
+		}
+
+		for i := range list.Items {
+			if err = m.rewrite(ctx, &list.Items[i]); err != nil {
+				return result, fmt.Errorf("cannot migrate %s %s: %w", crd.Spec.Names.Kind, list.Items[i].GetName(), err)
+			}
+
+			result.Migrated++
+		}
+
+		if m.Progress != nil {
+			m.Progress(result.Migrated, list.GetRemainingItemCount())
+		}
+
+		if continueToken = list.GetContinue(); len(continueToken) == 0 {
+			break
+		}
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if err := m.Client.Get(ctx, types.NamespacedName{Name: m.CRDName}, crd); err != nil {
+			return err
+		}
+
+		result.MigratedVersions = nil
+
+		for _, version := range crd.Status.StoredVersions {
+			if version != result.StorageVersion {
+				result.MigratedVersions = append(result.MigratedVersions, version)
+			}
+		}
+
+		crd.Status.StoredVersions = []string{result.StorageVersion}
+
+		return m.Client.Status().Update(ctx, crd)
+	})
+	if err != nil {
+		return result, fmt.Errorf("cannot update the stored versions of CustomResourceDefinition %s: %w", m.CRDName, err)
+	}
+
+	return result, nil
+}
+
+// rewrite performs a no-op update of the object: a conflict means the object has been updated meanwhile,
+// and it's already stored with the storage version, as well as a deleted object doesn't need to be migrated.
+func (m *Migrator) rewrite(ctx context.Context, obj *unstructured.Unstructured) error {
+	if err := m.Client.Update(ctx, obj); err != nil && !apierrors.IsConflict(err) && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
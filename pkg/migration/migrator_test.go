@@ -0,0 +1,44 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package migration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestIsRequired(t *testing.T) {
+	newCRD := func(stored ...string) *apiextensionsv1.CustomResourceDefinition {
+		return &apiextensionsv1.CustomResourceDefinition{
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+					{Name: "v1beta1", Served: true},
+					{Name: "v1beta2", Served: true, Storage: true},
+				},
+			},
+			Status: apiextensionsv1.CustomResourceDefinitionStatus{
+				StoredVersions: stored,
+			},
+		}
+	}
+
+	type tc struct {
+		Stored   []string
+		Required bool
+	}
+
+	for _, tc := range []tc{
+		{[]string{"v1beta2"}, false},
+		{[]string{"v1beta1", "v1beta2"}, true},
+		{[]string{"v1beta1"}, true},
+		{nil, false},
+	} {
+		crd := newCRD(tc.Stored...)
+
+		assert.Equal(t, "v1beta2", StorageVersion(crd))
+		assert.Equal(t, tc.Required, IsRequired(crd), "stored versions %v", tc.Stored)
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import "sort"
+
+// Code identifies a validation rule enforced by Capsule: codes are stable across releases,
+// allowing automated tooling and runbooks to branch on them rather than on the denial messages.
+// A code must never be reused for a different rule, even if the original one is removed.
+type Code string
+
+// Namespace policies.
+const (
+	NamespaceQuotaExceeded       Code = "CAPS-NS-001"
+	NamespaceProtectedName       Code = "CAPS-NS-002"
+	NamespacePrefixMismatch      Code = "CAPS-NS-003"
+	NamespaceForbiddenLabel      Code = "CAPS-NS-004"
+	NamespaceForbiddenAnnotation Code = "CAPS-NS-005"
+	NamespaceNodeSelector        Code = "CAPS-NS-006"
+	NamespacePatchForbidden      Code = "CAPS-NS-007"
+	NamespaceTenantNotOwned      Code = "CAPS-NS-008"
+	NamespaceTenantMissing       Code = "CAPS-NS-009"
+	NamespaceTenantAmbiguous     Code = "CAPS-NS-010"
+	NamespaceImmutableLabel      Code = "CAPS-NS-011"
+	NamespaceImmutableAnnotation Code = "CAPS-NS-012"
+)
+
+// Tenant policies.
+const (
+	TenantCordoned                Code = "CAPS-TNT-001"
+	TenantProtected               Code = "CAPS-TNT-002"
+	TenantInvalidName             Code = "CAPS-TNT-003"
+	TenantInvalidRegex            Code = "CAPS-TNT-004"
+	TenantImmutableLabel          Code = "CAPS-TNT-005"
+	TenantInvalidOwner            Code = "CAPS-TNT-006"
+	TenantInvalidBindingSubject   Code = "CAPS-TNT-007"
+	TenantDeletionNotConfirmed    Code = "CAPS-TNT-008"
+	TenantNamespacedClusterAccess Code = "CAPS-TNT-009"
+)
+
+// Container registry policies.
+const (
+	RegistryForbidden         Code = "CAPS-REG-001"
+	RegistryNotFullyQualified Code = "CAPS-REG-002"
+)
+
+// Pod policies.
+const (
+	PodForbiddenPullPolicy    Code = "CAPS-POD-001"
+	PodForbiddenPriorityClass Code = "CAPS-POD-002"
+	PodForbiddenRuntimeClass  Code = "CAPS-POD-003"
+	PodWorkloadHygiene        Code = "CAPS-POD-004"
+	PodNodeSpreadBypassed     Code = "CAPS-POD-005"
+)
+
+// Secrets Store CSI driver policies.
+const (
+	SecretsStoreForbiddenProvider  Code = "CAPS-SEC-001"
+	SecretsStoreForbiddenParameter Code = "CAPS-SEC-002"
+	SecretsStoreClassNotFound      Code = "CAPS-SEC-003"
+)
+
+// Service policies.
+const (
+	ServiceNodePortForbidden     Code = "CAPS-SVC-001"
+	ServiceExternalNameForbidden Code = "CAPS-SVC-002"
+	ServiceLoadBalancerForbidden Code = "CAPS-SVC-003"
+	ServiceForbiddenExternalIP   Code = "CAPS-SVC-004"
+	ServiceForbiddenLabel        Code = "CAPS-SVC-005"
+	ServiceForbiddenAnnotation   Code = "CAPS-SVC-006"
+	ServiceForbiddenProtocol     Code = "CAPS-SVC-007"
+	ServiceForbiddenPort         Code = "CAPS-SVC-008"
+)
+
+// Ingress and Certificate policies.
+const (
+	IngressClassMissing      Code = "CAPS-ING-001"
+	IngressClassForbidden    Code = "CAPS-ING-002"
+	IngressHostnameForbidden Code = "CAPS-ING-003"
+	IngressHostnameCollision Code = "CAPS-ING-004"
+	IngressWildcardForbidden Code = "CAPS-ING-005"
+	IngressQuotaExceeded     Code = "CAPS-ING-006"
+	IngressClassInvalid      Code = "CAPS-ING-007"
+	CertificateQuotaExceeded Code = "CAPS-CRT-001"
+)
+
+// Storage policies.
+const (
+	StorageClassMissing         Code = "CAPS-STO-001"
+	StorageClassForbidden       Code = "CAPS-STO-002"
+	StorageClassInvalid         Code = "CAPS-STO-003"
+	PersistentVolumeSelector    Code = "CAPS-STO-004"
+	PersistentVolumeCrossTenant Code = "CAPS-STO-005"
+)
+
+// NetworkPolicy policies.
+const (
+	NetworkPolicyDeletion Code = "CAPS-NP-001"
+	NetworkPolicyUpdate   Code = "CAPS-NP-002"
+)
+
+// Node policies.
+const (
+	NodeForbiddenLabel      Code = "CAPS-NODE-001"
+	NodeForbiddenAnnotation Code = "CAPS-NODE-002"
+)
+
+// Operators installation policies.
+const (
+	OperatorInstallationForbidden Code = "CAPS-OPR-001"
+	OperatorCatalogForbidden      Code = "CAPS-OPR-002"
+	OperatorTargetForbidden       Code = "CAPS-OPR-003"
+)
+
+// Tenant resources policies.
+const (
+	ResourceQuotaExceeded   Code = "CAPS-RES-001"
+	ResourceSizeExceeded    Code = "CAPS-RES-002"
+	ResourceManagedByTenant Code = "CAPS-RES-003"
+)
+
+//nolint:gochecknoglobals
+var descriptions = map[Code]string{
+	NamespaceQuotaExceeded:       "The Tenant has reached its maximum number of Namespaces.",
+	NamespaceProtectedName:       "The Namespace name matches the protected Namespaces regular expression.",
+	NamespacePrefixMismatch:      "The Namespace name is not prefixed with the Tenant name.",
+	NamespaceForbiddenLabel:      "The Namespace has a label forbidden by the Tenant.",
+	NamespaceForbiddenAnnotation: "The Namespace has an annotation forbidden by the Tenant.",
+	NamespaceNodeSelector:        "The node selector annotation enforced by the Tenant cannot be changed.",
+	NamespacePatchForbidden:      "The Namespace can only be patched by the owners of its Tenant.",
+	NamespaceTenantNotOwned:      "The Namespace cannot be assigned to a Tenant not owned by the requester.",
+	NamespaceTenantMissing:       "The requester does not own any Tenant.",
+	NamespaceTenantAmbiguous:     "The Tenant of the Namespace cannot be selected, the Tenant label is required.",
+	NamespaceImmutableLabel:      "The label of the Namespace is immutable for the Tenant after the creation.",
+	NamespaceImmutableAnnotation: "The annotation of the Namespace is immutable for the Tenant after the creation.",
+
+	TenantCordoned:                "The Tenant is cordoned, its resources cannot be changed.",
+	TenantProtected:               "The Tenant is protected from deletion.",
+	TenantInvalidName:             "The Tenant name has forbidden characters.",
+	TenantInvalidRegex:            "A regular expression of the Tenant cannot be compiled.",
+	TenantImmutableLabel:          "The Tenant name label is immutable.",
+	TenantInvalidOwner:            "The Tenant owner is not a valid ServiceAccount name.",
+	TenantInvalidBindingSubject:   "A subject of the Tenant additional RoleBindings is not valid.",
+	TenantDeletionNotConfirmed:    "The Tenant deletion exceeds the impact thresholds and is not confirmed.",
+	TenantNamespacedClusterAccess: "A resource of the Tenant cluster access is not cluster scoped.",
+
+	RegistryForbidden:         "The container image is hosted on a registry not allowed by the Tenant.",
+	RegistryNotFullyQualified: "The container image is not fully qualified, its registry cannot be verified.",
+
+	PodForbiddenPullPolicy:    "The container image pull policy is not allowed by the Tenant.",
+	PodForbiddenPriorityClass: "The Pod PriorityClass is not allowed by the Tenant.",
+	PodForbiddenRuntimeClass:  "The Pod RuntimeClass is not allowed by the Tenant.",
+	PodWorkloadHygiene:        "The workload does not comply with the Tenant workload hygiene rules.",
+	PodNodeSpreadBypassed:     "The Pod bypasses the spreading of the Tenant Pods across the nodes.",
+
+	SecretsStoreForbiddenProvider:  "The SecretProviderClass provider is not allowed by the Tenant.",
+	SecretsStoreForbiddenParameter: "A SecretProviderClass parameter refers to a secret not allowed by the Tenant.",
+	SecretsStoreClassNotFound:      "The SecretProviderClass mounted by the Pod does not exist.",
+
+	ServiceNodePortForbidden:     "Services of type NodePort are not allowed by the Tenant.",
+	ServiceExternalNameForbidden: "Services of type ExternalName are not allowed by the Tenant.",
+	ServiceLoadBalancerForbidden: "Services of type LoadBalancer are not allowed by the Tenant.",
+	ServiceForbiddenExternalIP:   "The Service external IP is not allowed by the Tenant.",
+	ServiceForbiddenLabel:        "The Service has a label forbidden by the Tenant.",
+	ServiceForbiddenAnnotation:   "The Service has an annotation forbidden by the Tenant.",
+	ServiceForbiddenProtocol:     "The Service port protocol is not allowed by the Tenant.",
+	ServiceForbiddenPort:         "The Service port is out of the ranges allowed by the Tenant.",
+
+	IngressClassMissing:      "The Ingress has no IngressClass, while the Tenant restricts them.",
+	IngressClassForbidden:    "The IngressClass is not allowed by the Tenant.",
+	IngressHostnameForbidden: "The Ingress hostname is empty, or not allowed by the Tenant.",
+	IngressHostnameCollision: "The Ingress hostname is already used in the collision scope of the Tenant.",
+	IngressWildcardForbidden: "Wildcard hostnames are not allowed by the Tenant.",
+	IngressQuotaExceeded:     "The Tenant has reached its maximum number of Ingresses.",
+	IngressClassInvalid:      "The IngressClass cannot be retrieved to apply the Tenant default.",
+	CertificateQuotaExceeded: "The Tenant has reached its maximum number of cert-manager Certificates.",
+
+	StorageClassMissing:         "The PersistentVolumeClaim has no StorageClass, while the Tenant restricts them.",
+	StorageClassForbidden:       "The StorageClass is not allowed by the Tenant.",
+	StorageClassInvalid:         "The StorageClass cannot be retrieved to apply the Tenant default.",
+	PersistentVolumeSelector:    "PersistentVolumeClaims cannot select PersistentVolumes by labels.",
+	PersistentVolumeCrossTenant: "The PersistentVolume is not bound to the Tenant of the PersistentVolumeClaim.",
+
+	NetworkPolicyDeletion: "NetworkPolicies managed by Capsule cannot be deleted.",
+	NetworkPolicyUpdate:   "NetworkPolicies managed by Capsule cannot be updated, or their fields managed by Capsule cannot be changed.",
+
+	NodeForbiddenLabel:      "The Node label cannot be changed by the Tenant owners.",
+	NodeForbiddenAnnotation: "The Node annotation cannot be changed by the Tenant owners.",
+
+	OperatorInstallationForbidden: "The installation of namespaced operators is not allowed by the Tenant.",
+	OperatorCatalogForbidden:      "The Subscription catalog is not allowed by the Tenant.",
+	OperatorTargetForbidden:       "The OperatorGroup targets Namespaces outside the Tenant.",
+
+	ResourceQuotaExceeded:   "The Tenant has reached its custom quota for the resource.",
+	ResourceSizeExceeded:    "The object size exceeds the Tenant limit for its kind.",
+	ResourceManagedByTenant: "The object is managed by a TenantResource and cannot be changed.",
+}
+
+// Codes returns all the known policy codes, sorted.
+func Codes() []Code {
+	codes := make([]Code, 0, len(descriptions))
+
+	for code := range descriptions {
+		codes = append(codes, code)
+	}
+
+	sort.Slice(codes, func(i, j int) bool {
+		return codes[i] < codes[j]
+	})
+
+	return codes
+}
+
+// Description returns the human-readable description of the rule identified by the code.
+func (c Code) Description() string {
+	return descriptions[c]
+}
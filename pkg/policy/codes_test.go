@@ -0,0 +1,54 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestCodes(t *testing.T) {
+	format := regexp.MustCompile(`^CAPS-[A-Z]+-\d{3}$`)
+
+	for _, code := range Codes() {
+		assert.Regexp(t, format, string(code))
+		assert.NotEmpty(t, code.Description(), string(code))
+	}
+
+	assert.Empty(t, Code("CAPS-UNKNOWN-001").Description())
+}
+
+func TestDeny(t *testing.T) {
+	response := Deny(RegistryForbidden, "registry is forbidden")
+
+	assert.False(t, response.Allowed)
+	assert.Equal(t, "[CAPS-REG-001] registry is forbidden", response.Result.Message)
+	assert.Equal(t, "CAPS-REG-001", response.AuditAnnotations[CodeAnnotation])
+
+	if assert.NotNil(t, response.Result.Details) && assert.Len(t, response.Result.Details.Causes, 1) {
+		assert.Equal(t, CauseType, response.Result.Details.Causes[0].Type)
+		assert.Equal(t, "CAPS-REG-001", response.Result.Details.Causes[0].Message)
+	}
+
+	code, ok := CodeOf(response)
+	assert.True(t, ok)
+	assert.Equal(t, RegistryForbidden, code)
+
+	_, ok = CodeOf(admission.Denied("denied by another webhook"))
+	assert.False(t, ok)
+}
+
+func TestDenyViolations(t *testing.T) {
+	response := DenyViolations(TenantInvalidRegex, "invalid regex", Violation{Field: "spec.containerRegistries.allowedRegex", Value: "("})
+
+	code, ok := CodeOf(response)
+	assert.True(t, ok)
+	assert.Equal(t, TenantInvalidRegex, code)
+	assert.Equal(t, []Violation{{Field: "spec.containerRegistries.allowedRegex", Value: "("}}, ViolationsOf(response))
+
+	assert.Empty(t, ViolationsOf(Deny(TenantInvalidRegex, "invalid regex")))
+}
@@ -0,0 +1,105 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/projectcapsule/capsule/pkg/metrics"
+)
+
+const (
+	// CodeAnnotation is the audit annotation, and the Event annotation, reporting the code of the denying policy:
+	// the API server audit logs prefix it with the name of the webhook.
+	CodeAnnotation = "policy-code"
+	// CauseType is the type of the status cause carrying the code in the denial response.
+	CauseType metav1.CauseType = "CapsulePolicy"
+)
+
+// Deny returns a response denying the request on behalf of the policy identified by the code:
+// the code prefixes the message, and is reported as status cause and as audit annotation.
+// The denial is accounted in the metrics by Record, once returned to the API server.
+func Deny(code Code, message string) admission.Response {
+	response := admission.Denied(fmt.Sprintf("[%s] %s", code, message))
+	response.Result.Details = &metav1.StatusDetails{
+		Causes: []metav1.StatusCause{{
+			Type:    CauseType,
+			Message: string(code),
+		}},
+	}
+	response.AuditAnnotations = map[string]string{
+		CodeAnnotation: string(code),
+	}
+
+	return response
+}
+
+// Violation identifies the field, and its value, violating a policy.
+type Violation struct {
+	Field string `json:"field"`
+	Value string `json:"value"`
+}
+
+// DenyViolations returns a response denying the request as Deny does, reporting the violating fields as status causes.
+func DenyViolations(code Code, message string, violations ...Violation) admission.Response {
+	response := Deny(code, message)
+
+	for _, violation := range violations {
+		response.Result.Details.Causes = append(response.Result.Details.Causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   violation.Field,
+			Message: violation.Value,
+		})
+	}
+
+	return response
+}
+
+// ViolationsOf returns the violating fields reported by the denial response, if any.
+func ViolationsOf(response admission.Response) (violations []Violation) {
+	if response.Allowed || response.Result == nil || response.Result.Details == nil {
+		return nil
+	}
+
+	for _, cause := range response.Result.Details.Causes {
+		if cause.Type == metav1.CauseTypeFieldValueInvalid {
+			violations = append(violations, Violation{Field: cause.Field, Value: cause.Message})
+		}
+	}
+
+	return violations
+}
+
+// CodeOf returns the code of the policy denying the response, if any.
+func CodeOf(response admission.Response) (Code, bool) {
+	if response.Allowed || response.Result == nil || response.Result.Details == nil {
+		return "", false
+	}
+
+	for _, cause := range response.Result.Details.Causes {
+		if cause.Type == CauseType {
+			return Code(cause.Message), true
+		}
+	}
+
+	return "", false
+}
+
+// Record accounts the denial of the response in the metrics, if any.
+func Record(response admission.Response) {
+	if code, ok := CodeOf(response); ok {
+		metrics.PolicyDenials.WithLabelValues(string(code)).Inc()
+	}
+}
+
+// Eventf records an Event for a denial of the policy identified by the code:
+// the code prefixes the message, and is reported as Event annotation.
+func Eventf(recorder record.EventRecorder, object runtime.Object, code Code, eventtype, reason, messageFmt string, args ...interface{}) {
+	recorder.AnnotatedEventf(object, map[string]string{"capsule.clastix.io/" + CodeAnnotation: string(code)}, eventtype, reason, "[%s] %s", code, fmt.Sprintf(messageFmt, args...))
+}
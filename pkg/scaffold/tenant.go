@@ -0,0 +1,153 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package scaffold generates commented Tenant manifests from a policy profile, as a starting point for new adopters.
+package scaffold
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+const (
+	ProfileStrict   = "strict"
+	ProfileStandard = "standard"
+	ProfileSandbox  = "sandbox"
+)
+
+// profile holds the values of the policies applied by a Tenant profile.
+type profile struct {
+	Description       string
+	NamespaceQuota    int
+	RequestsCPU       string
+	RequestsMemory    string
+	LimitsCPU         string
+	LimitsMemory      string
+	Pods              int
+	Storage           string
+	DefaultCPU        string
+	DefaultMemory     string
+	PodSecurity       string
+	PodSecurityWarn   string
+	PullPolicies      []string
+	NodePort          bool
+	LoadBalancer      bool
+	ExternalName      bool
+	WildcardHostnames bool
+	EgressInternet    bool
+	HygieneMode       string
+	PreventDeletion   bool
+}
+
+//nolint:gochecknoglobals
+var profiles = map[string]profile{
+	ProfileStrict: {
+		Description:     "production workloads of teams sharing the cluster, with tight isolation and enforced policies",
+		NamespaceQuota:  5,
+		RequestsCPU:     "8",
+		RequestsMemory:  "16Gi",
+		LimitsCPU:       "16",
+		LimitsMemory:    "32Gi",
+		Pods:            100,
+		Storage:         "100Gi",
+		DefaultCPU:      "500m",
+		DefaultMemory:   "512Mi",
+		PodSecurity:     "restricted",
+		PodSecurityWarn: "restricted",
+		PullPolicies:    []string{"Always"},
+		HygieneMode:     "Enforce",
+		PreventDeletion: true,
+	},
+	ProfileStandard: {
+		Description:     "most of the teams, with sensible defaults reporting rather than blocking the non compliant workloads",
+		NamespaceQuota:  10,
+		RequestsCPU:     "16",
+		RequestsMemory:  "32Gi",
+		LimitsCPU:       "32",
+		LimitsMemory:    "64Gi",
+		Pods:            250,
+		Storage:         "500Gi",
+		DefaultCPU:      "250m",
+		DefaultMemory:   "256Mi",
+		PodSecurity:     "baseline",
+		PodSecurityWarn: "restricted",
+		PullPolicies:    []string{"Always", "IfNotPresent"},
+		LoadBalancer:    true,
+		EgressInternet:  true,
+		HygieneMode:     "Warn",
+	},
+	ProfileSandbox: {
+		Description:     "short-lived experiments, with a small budget and no exposure outside the cluster",
+		NamespaceQuota:  2,
+		RequestsCPU:     "2",
+		RequestsMemory:  "4Gi",
+		LimitsCPU:       "4",
+		LimitsMemory:    "8Gi",
+		Pods:            20,
+		Storage:         "10Gi",
+		DefaultCPU:      "100m",
+		DefaultMemory:   "128Mi",
+		PodSecurity:     "restricted",
+		PodSecurityWarn: "restricted",
+		PullPolicies:    []string{"Always", "IfNotPresent"},
+		EgressInternet:  true,
+		HygieneMode:     "Warn",
+	},
+}
+
+//go:embed tenant.yaml.tmpl
+var tenantTemplate string
+
+// Options are the values of the generated Tenant manifest.
+type Options struct {
+	// Name of the Tenant.
+	Name string
+	// Owner of the Tenant, with the given kind, such as User or Group.
+	Owner     string
+	OwnerKind string
+	// Regular expression of the allowed container registries.
+	RegistryRegex string
+	// Profile is the policy profile, one of Profiles.
+	Profile string
+}
+
+// Profiles returns the names of the available profiles.
+func Profiles() []string {
+	names := make([]string, 0, len(profiles))
+
+	for name := range profiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Tenant returns the commented Tenant manifest for the given options.
+func Tenant(opts Options) (string, error) {
+	p, ok := profiles[opts.Profile]
+	if !ok {
+		return "", fmt.Errorf("unknown profile %s, use one of %s", opts.Profile, strings.Join(Profiles(), ", "))
+	}
+
+	tmpl, err := template.New("tenant").Parse(tenantTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	buf := new(bytes.Buffer)
+
+	if err = tmpl.Execute(buf, struct {
+		Options
+		profile
+	}{opts, p}); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package scaffold
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+func TestTenant(t *testing.T) {
+	for _, profile := range Profiles() {
+		t.Run(profile, func(t *testing.T) {
+			manifest, err := Tenant(Options{
+				Name:          "solar",
+				Owner:         "alice",
+				OwnerKind:     "User",
+				RegistryRegex: `^harbor\.example\.com/.+$`,
+				Profile:       profile,
+			})
+			require.NoError(t, err)
+
+			data, err := yaml.YAMLToJSON([]byte(manifest))
+			require.NoError(t, err)
+
+			decoder := json.NewDecoder(bytes.NewReader(data))
+			decoder.DisallowUnknownFields()
+
+			tnt := &capsulev1beta2.Tenant{}
+			require.NoError(t, decoder.Decode(tnt))
+
+			assert.Equal(t, "solar", tnt.Name)
+			assert.Equal(t, "alice", tnt.Spec.Owners[0].Name)
+			assert.Equal(t, `^harbor\.example\.com/.+$`, tnt.Spec.ContainerRegistries.Regex)
+			assert.NotEmpty(t, tnt.Spec.NamespaceOptions.AdditionalMetadata.Labels["pod-security.kubernetes.io/enforce"])
+			assert.Len(t, tnt.Spec.ResourceQuota.Items, 1)
+			assert.Len(t, tnt.Spec.NetworkPolicies.Items, 1)
+		})
+	}
+}
+
+func TestTenantUnknownProfile(t *testing.T) {
+	_, err := Tenant(Options{Name: "solar", Owner: "alice", OwnerKind: "User", Profile: "lax"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,25 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// IsClusterScopedResource returns true when the resource of the given API group is cluster scoped:
+// an error is returned when the resource cannot be resolved, such as its CustomResourceDefinition not being installed.
+func IsClusterScopedResource(mapper meta.RESTMapper, group, resource string) (bool, error) {
+	gvk, err := mapper.KindFor(schema.GroupVersionResource{Group: group, Resource: resource})
+	if err != nil {
+		return false, err
+	}
+
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return false, err
+	}
+
+	return mapping.Scope.Name() == meta.RESTScopeNameRoot, nil
+}
@@ -0,0 +1,34 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestIsClusterScopedResource(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Node"}, meta.RESTScopeRoot)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "ClusterIssuer"}, meta.RESTScopeRoot)
+
+	clusterScoped, err := IsClusterScopedResource(mapper, "", "nodes")
+	assert.NoError(t, err)
+	assert.True(t, clusterScoped)
+
+	clusterScoped, err = IsClusterScopedResource(mapper, "cert-manager.io", "clusterissuers")
+	assert.NoError(t, err)
+	assert.True(t, clusterScoped)
+
+	clusterScoped, err = IsClusterScopedResource(mapper, "", "secrets")
+	assert.NoError(t, err)
+	assert.False(t, clusterScoped)
+
+	_, err = IsClusterScopedResource(mapper, "", "*")
+	assert.True(t, meta.IsNoMatchError(err))
+}
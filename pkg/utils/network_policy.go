@@ -0,0 +1,40 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GeneratedRulesAnnotation records the number of rules generated by Capsule in the NetworkPolicies protected at field level.
+const GeneratedRulesAnnotation = "capsule.clastix.io/generated-rules"
+
+// GeneratedRules is the number of the ingress and egress rules generated by Capsule in a NetworkPolicy protected
+// at field level, preceding the rules appended by the Tenant owners.
+type GeneratedRules struct {
+	Ingress int `json:"ingress"`
+	Egress  int `json:"egress"`
+}
+
+func (in GeneratedRules) String() string {
+	value, _ := json.Marshal(in)
+
+	return string(value)
+}
+
+// GetGeneratedRules returns the generated rules recorded by the NetworkPolicy, false when missing or not valid.
+func GetGeneratedRules(obj metav1.Object) (rules GeneratedRules, ok bool) {
+	value, ok := obj.GetAnnotations()[GeneratedRulesAnnotation]
+	if !ok {
+		return rules, false
+	}
+
+	if err := json.Unmarshal([]byte(value), &rules); err != nil || rules.Ingress < 0 || rules.Egress < 0 {
+		return rules, false
+	}
+
+	return rules, true
+}
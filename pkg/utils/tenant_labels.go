@@ -15,6 +15,10 @@ import (
 	"github.com/projectcapsule/capsule/api/v1beta2"
 )
 
+// IngressNetworkPolicyLabel marks the NetworkPolicies generated from the Tenant Ingress resources,
+// holding the name of the Service the traffic is allowed to.
+const IngressNetworkPolicyLabel = "capsule.clastix.io/ingress-network-policy"
+
 func GetTypeLabel(t runtime.Object) (label string, err error) {
 	switch v := t.(type) {
 	case *v1beta1.Tenant, *v1beta2.Tenant:
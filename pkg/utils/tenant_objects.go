@@ -0,0 +1,49 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+//nolint:gochecknoglobals
+var CertificateGroupVersionKind = schema.GroupVersionKind{
+	Group:   "cert-manager.io",
+	Version: "v1",
+	Kind:    "Certificate",
+}
+
+// CountTenantObjects returns the number of objects of the given list type across all the Tenant Namespaces.
+func CountTenantObjects(ctx context.Context, c client.Reader, tnt *v1beta2.Tenant, newList func() client.ObjectList) (count int, err error) {
+	for _, ns := range tnt.Status.Namespaces {
+		list := newList()
+
+		if err = c.List(ctx, list, client.InNamespace(ns)); err != nil {
+			return 0, err
+		}
+
+		count += meta.LenList(list)
+	}
+
+	return count, nil
+}
+
+func NewIngressList() client.ObjectList {
+	return &networkingv1.IngressList{}
+}
+
+func NewCertificateList() client.ObjectList {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(CertificateGroupVersionKind.GroupVersion().WithKind(CertificateGroupVersionKind.Kind + "List"))
+
+	return list
+}
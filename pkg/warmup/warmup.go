@@ -0,0 +1,70 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package warmup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+// Runnable starts the informers the admission decisions are based on, waiting for their sync: the informers
+// maintain their indexes, such as the Tenant one by Namespace, as the objects are added, thus these are complete
+// once synced. Until then, the readiness check fails, keeping the webhook endpoints out of the Service
+// and preventing admission decisions on empty caches after a restart.
+type Runnable struct {
+	Cache cache.Cache
+	Log   logr.Logger
+	// Objects whose informers must be synced, such as Tenants, Namespaces, and the CapsuleConfiguration.
+	Objects []client.Object
+
+	ready atomic.Bool
+}
+
+// NeedLeaderElection returns false, since all the replicas serve the webhooks.
+func (r *Runnable) NeedLeaderElection() bool {
+	return false
+}
+
+func (r *Runnable) Start(ctx context.Context) error {
+	for _, obj := range r.Objects {
+		if _, err := r.Cache.GetInformer(ctx, obj); err != nil {
+			return fmt.Errorf("cannot start informer for %T: %w", obj, err)
+		}
+	}
+
+	r.Log.Info("waiting for the caches to be synced")
+
+	if !r.Cache.WaitForCacheSync(ctx) {
+		return errors.New("caches cannot be synced")
+	}
+
+	tntList := &capsulev1beta2.TenantList{}
+	if err := r.Cache.List(ctx, tntList); err != nil {
+		return fmt.Errorf("cannot list Tenants: %w", err)
+	}
+
+	r.ready.Store(true)
+
+	r.Log.Info("caches are warmed up", "tenants", len(tntList.Items))
+
+	return nil
+}
+
+// Check is the readiness check, failing until the caches are warmed up.
+func (r *Runnable) Check(*http.Request) error {
+	if !r.ready.Load() {
+		return errors.New("caches are not warmed up yet")
+	}
+
+	return nil
+}
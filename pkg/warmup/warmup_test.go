@@ -0,0 +1,49 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package warmup
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+func TestCheck(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, capsulev1beta2.AddToScheme(scheme))
+
+	synced := false
+
+	r := &Runnable{
+		Cache:   &informertest.FakeInformers{Scheme: scheme, Synced: &synced},
+		Log:     logr.Discard(),
+		Objects: []client.Object{&capsulev1beta2.Tenant{}, &corev1.Namespace{}, &capsulev1beta2.CapsuleConfiguration{}},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+
+	assert.Error(t, r.Check(req))
+
+	// Caches not synced, such as upon the context cancellation: the replica stays not ready.
+	assert.Error(t, r.Start(context.Background()))
+	assert.Error(t, r.Check(req))
+
+	synced = true
+
+	require.NoError(t, r.Start(context.Background()))
+	assert.NoError(t, r.Check(req))
+}
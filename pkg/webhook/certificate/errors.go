@@ -0,0 +1,20 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package certificate
+
+import (
+	"fmt"
+)
+
+type certificateQuotaExceededError struct {
+	limit int32
+}
+
+func NewCertificateQuotaExceededError(limit int32) error {
+	return &certificateQuotaExceededError{limit: limit}
+}
+
+func (c certificateQuotaExceededError) Error() string {
+	return fmt.Sprintf("Cannot exceed the Certificate quota of %d for the current Tenant: please, reach out to the system administrators", c.limit)
+}
@@ -16,6 +16,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsuleingress "github.com/projectcapsule/capsule/pkg/webhook/ingress"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
@@ -51,7 +52,7 @@ func mutateIngressDefaults(ctx context.Context, req admission.Request, version *
 
 	if ingressClassName := ingress.IngressClass(); ingressClassName != nil && *ingressClassName != allowed.Default {
 		if ingressClass, err = utils.GetIngressClassByName(ctx, version, c, ingressClassName); err != nil && !k8serrors.IsNotFound(err) {
-			response := admission.Denied(NewIngressClassError(*ingressClassName, err).Error())
+			response := policy.Deny(policy.IngressClassInvalid, NewIngressClassError(*ingressClassName, err).Error())
 
 			return &response
 		}
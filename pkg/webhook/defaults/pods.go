@@ -7,14 +7,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math/rand/v2"
+	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
 	schedulev1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
 	"github.com/projectcapsule/capsule/pkg/api"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
@@ -56,7 +60,18 @@ func mutatePodDefaults(ctx context.Context, req admission.Request, c client.Clie
 		}()
 	}
 
-	if !rcMutated && !pcMutated {
+	slotMutated, slotErr := handleMaxPodsPerNodeDefault(ctx, c, tnt, &pod)
+	if slotErr != nil {
+		return utils.ErroredResponse(slotErr)
+	} else if slotMutated {
+		defer func() {
+			if err == nil {
+				recorder.Eventf(tnt, corev1.EventTypeNormal, "TenantDefault", "Assigned Tenant node slot %s to %s/%s", pod.GetLabels()[api.NodeSlotLabel], pod.Namespace, pod.Name)
+			}
+		}()
+	}
+
+	if !rcMutated && !pcMutated && !slotMutated {
 		return nil
 	}
 
@@ -69,6 +84,81 @@ func mutatePodDefaults(ctx context.Context, req admission.Request, c client.Clie
 	return ptr.To(admission.PatchResponseFromRaw(req.Object.Raw, marshaled))
 }
 
+// handleMaxPodsPerNodeDefault labels the Pod with the node slot having the fewest Pods of the Tenant, and injects a
+// required Pod anti-affinity on the hostname topology key towards the Tenant Pods of the same slot: the scheduler
+// places at most one Pod per slot on each node, thus no more Pods than the slots.
+func handleMaxPodsPerNodeDefault(ctx context.Context, c client.Client, tnt *capsulev1beta2.Tenant, pod *corev1.Pod) (mutated bool, err error) {
+	if tnt.Spec.PodOptions == nil || tnt.Spec.PodOptions.MaxPodsPerNode == nil {
+		return false, nil
+	}
+
+	usage := make([]int, *tnt.Spec.PodOptions.MaxPodsPerNode)
+
+	for _, ns := range tnt.Status.Namespaces {
+		list := &corev1.PodList{}
+		if err = c.List(ctx, list, client.InNamespace(ns), client.HasLabels{api.NodeSlotLabel}); err != nil {
+			return false, err
+		}
+
+		for _, item := range list.Items {
+			if item.Status.Phase == corev1.PodSucceeded || item.Status.Phase == corev1.PodFailed {
+				continue
+			}
+
+			if slot, convErr := strconv.Atoi(item.GetLabels()[api.NodeSlotLabel]); convErr == nil && slot >= 0 && slot < len(usage) {
+				usage[slot]++
+			}
+		}
+	}
+
+	tenantLabel, err := capsulev1beta2.GetTypeLabel(tnt)
+	if err != nil {
+		return false, err
+	}
+
+	slot := strconv.Itoa(leastUsedSlot(usage))
+
+	labels := pod.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+
+	labels[api.NodeSlotLabel] = slot
+	pod.SetLabels(labels)
+
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &corev1.Affinity{}
+	}
+
+	if pod.Spec.Affinity.PodAntiAffinity == nil {
+		pod.Spec.Affinity.PodAntiAffinity = &corev1.PodAntiAffinity{}
+	}
+
+	pod.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(pod.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, corev1.PodAffinityTerm{
+		LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{api.NodeSlotLabel: slot}},
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{tenantLabel: tnt.GetName()}},
+		TopologyKey:       corev1.LabelHostname,
+	})
+
+	return true, nil
+}
+
+// leastUsedSlot returns one of the slots with the fewest Pods, picked randomly to spread the concurrent creations.
+func leastUsedSlot(usage []int) int {
+	var candidates []int
+
+	for slot, count := range usage {
+		switch {
+		case len(candidates) == 0 || count < usage[candidates[0]]:
+			candidates = []int{slot}
+		case count == usage[candidates[0]]:
+			candidates = append(candidates, slot)
+		}
+	}
+
+	return candidates[rand.IntN(len(candidates))] //nolint:gosec
+}
+
 func handleRuntimeClassDefault(allowed *api.DefaultAllowedListSpec, pod *corev1.Pod) (mutated bool) {
 	if allowed == nil || allowed.Default == "" {
 		return false
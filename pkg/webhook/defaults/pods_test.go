@@ -0,0 +1,73 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package defaults
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+func slotPod(namespace, name, slot string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: map[string]string{api.NodeSlotLabel: slot}},
+		Status:     corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestHandleMaxPodsPerNodeDefault(t *testing.T) {
+	tnt := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "oil"},
+		Spec:       capsulev1beta2.TenantSpec{PodOptions: &api.PodOptions{MaxPodsPerNode: ptr.To[int32](3)}},
+		Status:     capsulev1beta2.TenantStatus{Namespaces: []string{"oil-production", "oil-development"}},
+	}
+	// The slots 0 and 1 are used across the Tenant Namespaces, while the slot 2 only by a completed Pod,
+	// and by a Pod of another Tenant.
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		slotPod("oil-production", "web-1", "0", corev1.PodRunning),
+		slotPod("oil-development", "web-2", "1", corev1.PodPending),
+		slotPod("oil-development", "job-1", "2", corev1.PodSucceeded),
+		slotPod("gas-production", "web-1", "2", corev1.PodRunning),
+	).Build()
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "oil-production", GenerateName: "web-"}}
+
+	mutated, err := handleMaxPodsPerNodeDefault(context.Background(), c, tnt, pod)
+	require.NoError(t, err)
+	assert.True(t, mutated)
+	assert.Equal(t, "2", pod.GetLabels()[api.NodeSlotLabel])
+
+	require.NotNil(t, pod.Spec.Affinity)
+	require.NotNil(t, pod.Spec.Affinity.PodAntiAffinity)
+	assert.Equal(t, []corev1.PodAffinityTerm{{
+		LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{api.NodeSlotLabel: "2"}},
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"capsule.clastix.io/tenant": "oil"}},
+		TopologyKey:       corev1.LabelHostname,
+	}}, pod.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution)
+
+	tnt.Spec.PodOptions = nil
+
+	mutated, err = handleMaxPodsPerNodeDefault(context.Background(), c, tnt, &corev1.Pod{})
+	require.NoError(t, err)
+	assert.False(t, mutated)
+}
+
+func TestLeastUsedSlot(t *testing.T) {
+	assert.Equal(t, 0, leastUsedSlot([]int{0}))
+	assert.Equal(t, 1, leastUsedSlot([]int{2, 0, 1}))
+
+	for i := 0; i < 10; i++ {
+		assert.Contains(t, []int{1, 3}, leastUsedSlot([]int{2, 1, 4, 1}))
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
 
@@ -52,7 +53,7 @@ func mutatePVCDefaults(ctx context.Context, req admission.Request, c client.Clie
 	if storageClassName := pvc.Spec.StorageClassName; storageClassName != nil && *storageClassName != allowed.Default {
 		csc, err = utils.GetStorageClassByName(ctx, c, *storageClassName)
 		if err != nil && !k8serrors.IsNotFound(err) {
-			response := admission.Denied(NewStorageClassError(*storageClassName, err).Error())
+			response := policy.Deny(policy.StorageClassInvalid, NewStorageClassError(*storageClassName, err).Error())
 
 			return &response
 		}
@@ -114,3 +114,15 @@ func appendHostnameError(spec api.AllowedListSpec) (append string) {
 
 	return
 }
+
+type ingressQuotaExceededError struct {
+	limit int32
+}
+
+func NewIngressQuotaExceededError(limit int32) error {
+	return &ingressQuotaExceededError{limit: limit}
+}
+
+func (i ingressQuotaExceededError) Error() string {
+	return fmt.Sprintf("Cannot exceed the Ingress quota of %d for the current Tenant: please, reach out to the system administrators", i.limit)
+}
@@ -16,6 +16,7 @@ import (
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
 	"github.com/projectcapsule/capsule/pkg/configuration"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
@@ -76,9 +77,9 @@ func (r *class) validate(ctx context.Context, version *version.Version, client c
 	ingressClass := ingress.IngressClass()
 
 	if ingressClass == nil {
-		recorder.Eventf(tnt, corev1.EventTypeWarning, "MissingIngressClass", "Ingress %s/%s is missing IngressClass", req.Namespace, req.Name)
+		policy.Eventf(recorder, tnt, policy.IngressClassMissing, corev1.EventTypeWarning, "MissingIngressClass", "Ingress %s/%s is missing IngressClass", req.Namespace, req.Name)
 
-		response := admission.Denied(NewIngressClassUndefined(*allowed).Error())
+		response := policy.Deny(policy.IngressClassMissing, NewIngressClassUndefined(*allowed).Error())
 
 		return &response
 	}
@@ -106,9 +107,9 @@ func (r *class) validate(ctx context.Context, version *version.Version, client c
 	case allowed.Match(*ingressClass) || selector:
 		return nil
 	default:
-		recorder.Eventf(tnt, corev1.EventTypeWarning, "ForbiddenIngressClass", "Ingress %s/%s IngressClass %s is forbidden for the current Tenant", req.Namespace, req.Name, &ingressClass)
+		policy.Eventf(recorder, tnt, policy.IngressClassForbidden, corev1.EventTypeWarning, "ForbiddenIngressClass", "Ingress %s/%s IngressClass %s is forbidden for the current Tenant", req.Namespace, req.Name, *ingressClass)
 
-		response := admission.Denied(NewIngressClassForbidden(*ingressClass, *allowed).Error())
+		response := policy.Deny(policy.IngressClassForbidden, NewIngressClassForbidden(*ingressClass, *allowed).Error())
 
 		return &response
 	}
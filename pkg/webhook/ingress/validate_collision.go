@@ -22,6 +22,7 @@ import (
 	"github.com/projectcapsule/capsule/pkg/api"
 	"github.com/projectcapsule/capsule/pkg/configuration"
 	"github.com/projectcapsule/capsule/pkg/indexer/ingress"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
@@ -76,10 +77,10 @@ func (r *collision) validate(ctx context.Context, client client.Client, req admi
 	var collisionErr *ingressHostnameCollisionError
 
 	if errors.As(err, &collisionErr) {
-		recorder.Eventf(tenant, corev1.EventTypeWarning, "IngressHostnameCollision", "Ingress %s/%s hostname is colliding", ing.Namespace(), ing.Name())
+		policy.Eventf(recorder, tenant, policy.IngressHostnameCollision, corev1.EventTypeWarning, "IngressHostnameCollision", "Ingress %s/%s hostname is colliding", ing.Namespace(), ing.Name())
 	}
 
-	response := admission.Denied(err.Error())
+	response := policy.Deny(policy.IngressHostnameCollision, err.Error())
 
 	return &response
 }
@@ -16,6 +16,7 @@ import (
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
 	"github.com/projectcapsule/capsule/pkg/configuration"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
@@ -67,9 +68,11 @@ func (r *hostnames) validate(ctx context.Context, client client.Client, req admi
 
 	for hostname := range ingress.HostnamePathsPairs() {
 		if len(hostname) == 0 {
-			recorder.Eventf(tenant, corev1.EventTypeWarning, "IngressHostnameEmpty", "Ingress %s/%s hostname is empty", ingress.Namespace(), ingress.Name())
+			policy.Eventf(recorder, tenant, policy.IngressHostnameForbidden, corev1.EventTypeWarning, "IngressHostnameEmpty", "Ingress %s/%s hostname is empty", ingress.Namespace(), ingress.Name())
 
-			return utils.ErroredResponse(NewEmptyIngressHostname(*tenant.Spec.IngressOptions.AllowedHostnames))
+			response := policy.Deny(policy.IngressHostnameForbidden, NewEmptyIngressHostname(*tenant.Spec.IngressOptions.AllowedHostnames).Error())
+
+			return &response
 		}
 
 		hostnameList.Insert(hostname)
@@ -82,9 +85,9 @@ func (r *hostnames) validate(ctx context.Context, client client.Client, req admi
 	var hostnameNotValidErr *ingressHostnameNotValidError
 
 	if errors.As(err, &hostnameNotValidErr) {
-		recorder.Eventf(tenant, corev1.EventTypeWarning, "IngressHostnameNotValid", "Ingress %s/%s hostname is not valid", ingress.Namespace(), ingress.Name())
+		policy.Eventf(recorder, tenant, policy.IngressHostnameForbidden, corev1.EventTypeWarning, "IngressHostnameNotValid", "Ingress %s/%s hostname is not valid", ingress.Namespace(), ingress.Name())
 
-		response := admission.Denied(err.Error())
+		response := policy.Deny(policy.IngressHostnameForbidden, err.Error())
 
 		return &response
 	}
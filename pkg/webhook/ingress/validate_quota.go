@@ -0,0 +1,64 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package ingress
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/projectcapsule/capsule/pkg/policy"
+	capsuleutils "github.com/projectcapsule/capsule/pkg/utils"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type quota struct{}
+
+func Quota() capsulewebhook.Handler {
+	return &quota{}
+}
+
+func (h *quota) OnCreate(c client.Client, _ admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		tnt, err := utils.TenantByStatusNamespace(ctx, c, req.Namespace)
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		if tnt.Spec.IngressOptions.Quota == nil {
+			return nil
+		}
+
+		count, err := capsuleutils.CountTenantObjects(ctx, c, tnt, capsuleutils.NewIngressList)
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		if limit := *tnt.Spec.IngressOptions.Quota; int32(count) >= limit { //nolint:gosec
+			policy.Eventf(recorder, tnt, policy.IngressQuotaExceeded, corev1.EventTypeWarning, "IngressQuotaExceeded", "Ingress %s/%s cannot be created, quota of %d exceeded for the current Tenant", req.Namespace, req.Name, limit)
+
+			response := policy.Deny(policy.IngressQuotaExceeded, NewIngressQuotaExceededError(limit).Error())
+
+			return &response
+		}
+
+		return nil
+	}
+}
+
+func (h *quota) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *quota) OnUpdate(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
@@ -15,6 +15,7 @@ import (
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
 	"github.com/projectcapsule/capsule/pkg/configuration"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsuleutils "github.com/projectcapsule/capsule/pkg/utils"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
@@ -47,9 +48,9 @@ func (r *freezedHandler) OnCreate(client client.Client, decoder admission.Decode
 			}
 
 			if tnt.Spec.Cordoned {
-				recorder.Eventf(tnt, corev1.EventTypeWarning, "TenantFreezed", "Namespace %s cannot be attached, the current Tenant is freezed", ns.GetName())
+				policy.Eventf(recorder, tnt, policy.TenantCordoned, corev1.EventTypeWarning, "TenantFreezed", "Namespace %s cannot be attached, the current Tenant is freezed", ns.GetName())
 
-				response := admission.Denied("the selected Tenant is freezed")
+				response := policy.Deny(policy.TenantCordoned, "the selected Tenant is freezed")
 
 				return &response
 			}
@@ -75,9 +76,9 @@ func (r *freezedHandler) OnDelete(c client.Client, _ admission.Decoder, recorder
 		tnt := tntList.Items[0]
 
 		if tnt.Spec.Cordoned && utils.IsCapsuleUser(ctx, req, c, r.configuration.UserGroups()) {
-			recorder.Eventf(&tnt, corev1.EventTypeWarning, "TenantFreezed", "Namespace %s cannot be deleted, the current Tenant is freezed", req.Name)
+			policy.Eventf(recorder, &tnt, policy.TenantCordoned, corev1.EventTypeWarning, "TenantFreezed", "Namespace %s cannot be deleted, the current Tenant is freezed", req.Name)
 
-			response := admission.Denied("the selected Tenant is freezed")
+			response := policy.Deny(policy.TenantCordoned, "the selected Tenant is freezed")
 
 			return &response
 		}
@@ -107,9 +108,9 @@ func (r *freezedHandler) OnUpdate(c client.Client, decoder admission.Decoder, re
 		tnt := tntList.Items[0]
 
 		if tnt.Spec.Cordoned && utils.IsCapsuleUser(ctx, req, c, r.configuration.UserGroups()) {
-			recorder.Eventf(&tnt, corev1.EventTypeWarning, "TenantFreezed", "Namespace %s cannot be updated, the current Tenant is freezed", ns.GetName())
+			policy.Eventf(recorder, &tnt, policy.TenantCordoned, corev1.EventTypeWarning, "TenantFreezed", "Namespace %s cannot be updated, the current Tenant is freezed", ns.GetName())
 
-			response := admission.Denied("the selected Tenant is freezed")
+			response := policy.Deny(policy.TenantCordoned, "the selected Tenant is freezed")
 
 			return &response
 		}
@@ -15,6 +15,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsuleutils "github.com/projectcapsule/capsule/pkg/utils"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
@@ -67,8 +68,8 @@ func (r *patchHandler) OnUpdate(c client.Client, decoder admission.Decoder, reco
 			}
 
 			if !utils.IsTenantOwner(tnt.Spec.Owners, req.UserInfo) {
-				recorder.Eventf(tnt, corev1.EventTypeWarning, "NamespacePatch", e)
-				response := admission.Denied(e)
+				policy.Eventf(recorder, tnt, policy.NamespacePatchForbidden, corev1.EventTypeWarning, "NamespacePatch", e)
+				response := policy.Deny(policy.NamespacePatchForbidden, e)
 
 				return &response
 			}
@@ -16,6 +16,7 @@ import (
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
 	"github.com/projectcapsule/capsule/pkg/configuration"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsuleutils "github.com/projectcapsule/capsule/pkg/utils"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
@@ -40,7 +41,7 @@ func (r *prefixHandler) OnCreate(clt client.Client, decoder admission.Decoder, r
 
 		if exp, _ := r.configuration.ProtectedNamespaceRegexp(); exp != nil {
 			if matched := exp.MatchString(ns.GetName()); matched {
-				response := admission.Denied(fmt.Sprintf("Creating namespaces with name matching %s regexp is not allowed; please, reach out to the system administrators", exp.String()))
+				response := policy.Deny(policy.NamespaceProtectedName, fmt.Sprintf("Creating namespaces with name matching %s regexp is not allowed; please, reach out to the system administrators", exp.String()))
 
 				return &response
 			}
@@ -65,9 +66,9 @@ func (r *prefixHandler) OnCreate(clt client.Client, decoder admission.Decoder, r
 				}
 
 				if e := fmt.Sprintf("%s-%s", tnt.GetName(), ns.GetName()); !strings.HasPrefix(ns.GetName(), fmt.Sprintf("%s-", tnt.GetName())) {
-					recorder.Eventf(tnt, corev1.EventTypeWarning, "InvalidTenantPrefix", "Namespace %s does not match the expected prefix for the current Tenant", ns.GetName())
+					policy.Eventf(recorder, tnt, policy.NamespacePrefixMismatch, corev1.EventTypeWarning, "InvalidTenantPrefix", "Namespace %s does not match the expected prefix for the current Tenant", ns.GetName())
 
-					response := admission.Denied(fmt.Sprintf("The namespace doesn't match the tenant prefix, expected %s", e))
+					response := policy.Deny(policy.NamespacePrefixMismatch, fmt.Sprintf("The namespace doesn't match the tenant prefix, expected %s", e))
 
 					return &response
 				}
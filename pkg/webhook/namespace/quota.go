@@ -13,6 +13,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsuleutils "github.com/projectcapsule/capsule/pkg/utils"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
@@ -51,9 +52,9 @@ func (r *quotaHandler) OnCreate(client client.Client, decoder admission.Decoder,
 					return nil
 				}
 
-				recorder.Eventf(tnt, corev1.EventTypeWarning, "NamespaceQuotaExceded", "Namespace %s cannot be attached, quota exceeded for the current Tenant", ns.GetName())
+				policy.Eventf(recorder, tnt, policy.NamespaceQuotaExceeded, corev1.EventTypeWarning, "NamespaceQuotaExceded", "Namespace %s cannot be attached, quota exceeded for the current Tenant", ns.GetName())
 
-				response := admission.Denied(NewNamespaceQuotaExceededError().Error())
+				response := policy.Deny(policy.NamespaceQuotaExceeded, NewNamespaceQuotaExceededError().Error())
 
 				return &response
 			}
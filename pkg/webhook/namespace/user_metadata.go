@@ -15,6 +15,7 @@ import (
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
 	"github.com/projectcapsule/capsule/pkg/api"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsuleutils "github.com/projectcapsule/capsule/pkg/utils"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
@@ -50,8 +51,8 @@ func (r *userMetadataHandler) OnCreate(client client.Client, decoder admission.D
 			err := api.ValidateForbidden(ns.ObjectMeta.Annotations, tnt.Spec.NamespaceOptions.ForbiddenAnnotations)
 			if err != nil {
 				err = errors.Wrap(err, "namespace annotations validation failed")
-				recorder.Eventf(tnt, corev1.EventTypeWarning, api.ForbiddenAnnotationReason, err.Error())
-				response := admission.Denied(err.Error())
+				policy.Eventf(recorder, tnt, policy.NamespaceForbiddenAnnotation, corev1.EventTypeWarning, api.ForbiddenAnnotationReason, err.Error())
+				response := policy.Deny(policy.NamespaceForbiddenAnnotation, err.Error())
 
 				return &response
 			}
@@ -59,8 +60,8 @@ func (r *userMetadataHandler) OnCreate(client client.Client, decoder admission.D
 			err = api.ValidateForbidden(ns.ObjectMeta.Labels, tnt.Spec.NamespaceOptions.ForbiddenLabels)
 			if err != nil {
 				err = errors.Wrap(err, "namespace labels validation failed")
-				recorder.Eventf(tnt, corev1.EventTypeWarning, api.ForbiddenLabelReason, err.Error())
-				response := admission.Denied(err.Error())
+				policy.Eventf(recorder, tnt, policy.NamespaceForbiddenLabel, corev1.EventTypeWarning, api.ForbiddenLabelReason, err.Error())
+				response := policy.Deny(policy.NamespaceForbiddenLabel, err.Error())
 
 				return &response
 			}
@@ -104,17 +105,41 @@ func (r *userMetadataHandler) OnUpdate(client client.Client, decoder admission.D
 		if len(tnt.Spec.NodeSelector) > 0 {
 			v, ok := newNs.GetAnnotations()["scheduler.alpha.kubernetes.io/node-selector"]
 			if !ok {
-				response := admission.Denied("the node-selector annotation is enforced, cannot be removed")
+				response := policy.Deny(policy.NamespaceNodeSelector, "the node-selector annotation is enforced, cannot be removed")
 
-				recorder.Eventf(tnt, corev1.EventTypeWarning, "ForbiddenNodeSelectorDeletion", string(response.Result.Reason))
+				policy.Eventf(recorder, tnt, policy.NamespaceNodeSelector, corev1.EventTypeWarning, "ForbiddenNodeSelectorDeletion", string(response.Result.Reason))
 
 				return &response
 			}
 
 			if v != oldNs.GetAnnotations()["scheduler.alpha.kubernetes.io/node-selector"] {
-				response := admission.Denied("the node-selector annotation is enforced, cannot be updated")
+				response := policy.Deny(policy.NamespaceNodeSelector, "the node-selector annotation is enforced, cannot be updated")
 
-				recorder.Eventf(tnt, corev1.EventTypeWarning, "ForbiddenNodeSelectorUpdate", string(response.Result.Reason))
+				policy.Eventf(recorder, tnt, policy.NamespaceNodeSelector, corev1.EventTypeWarning, "ForbiddenNodeSelectorUpdate", string(response.Result.Reason))
+
+				return &response
+			}
+		}
+
+		if tnt.Spec.NamespaceOptions != nil {
+			if err := api.ValidateImmutable("label", oldNs.GetLabels(), newNs.GetLabels(), tnt.Spec.NamespaceOptions.ImmutableLabels); err != nil {
+				if !errors.As(err, &api.ImmutableError{}) {
+					return utils.ErroredResponse(err)
+				}
+
+				policy.Eventf(recorder, tnt, policy.NamespaceImmutableLabel, corev1.EventTypeWarning, api.ImmutableLabelReason, err.Error())
+				response := policy.Deny(policy.NamespaceImmutableLabel, err.Error())
+
+				return &response
+			}
+
+			if err := api.ValidateImmutable("annotation", oldNs.GetAnnotations(), newNs.GetAnnotations(), tnt.Spec.NamespaceOptions.ImmutableAnnotations); err != nil {
+				if !errors.As(err, &api.ImmutableError{}) {
+					return utils.ErroredResponse(err)
+				}
+
+				policy.Eventf(recorder, tnt, policy.NamespaceImmutableAnnotation, corev1.EventTypeWarning, api.ImmutableAnnotationReason, err.Error())
+				response := policy.Deny(policy.NamespaceImmutableAnnotation, err.Error())
 
 				return &response
 			}
@@ -164,8 +189,8 @@ func (r *userMetadataHandler) OnUpdate(client client.Client, decoder admission.D
 			err := api.ValidateForbidden(annotations, tnt.Spec.NamespaceOptions.ForbiddenAnnotations)
 			if err != nil {
 				err = errors.Wrap(err, "namespace annotations validation failed")
-				recorder.Eventf(tnt, corev1.EventTypeWarning, api.ForbiddenAnnotationReason, err.Error())
-				response := admission.Denied(err.Error())
+				policy.Eventf(recorder, tnt, policy.NamespaceForbiddenAnnotation, corev1.EventTypeWarning, api.ForbiddenAnnotationReason, err.Error())
+				response := policy.Deny(policy.NamespaceForbiddenAnnotation, err.Error())
 
 				return &response
 			}
@@ -173,8 +198,8 @@ func (r *userMetadataHandler) OnUpdate(client client.Client, decoder admission.D
 			err = api.ValidateForbidden(labels, tnt.Spec.NamespaceOptions.ForbiddenLabels)
 			if err != nil {
 				err = errors.Wrap(err, "namespace labels validation failed")
-				recorder.Eventf(tnt, corev1.EventTypeWarning, api.ForbiddenLabelReason, err.Error())
-				response := admission.Denied(err.Error())
+				policy.Eventf(recorder, tnt, policy.NamespaceForbiddenLabel, corev1.EventTypeWarning, api.ForbiddenLabelReason, err.Error())
+				response := policy.Deny(policy.NamespaceForbiddenLabel, err.Error())
 
 				return &response
 			}
@@ -5,13 +5,23 @@ package networkpolicy
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 
 	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
 
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/managedfields"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsuleutils "github.com/projectcapsule/capsule/pkg/utils"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
@@ -37,7 +47,7 @@ func (r *handler) OnDelete(client client.Client, decoder admission.Decoder, _ re
 		}
 
 		if !allowed {
-			response := admission.Denied("Capsule Network Policies cannot be deleted: please, reach out to the system administrators")
+			response := policy.Deny(policy.NetworkPolicyDeletion, "Capsule Network Policies cannot be deleted: please, reach out to the system administrators")
 
 			return &response
 		}
@@ -53,8 +63,23 @@ func (r *handler) OnUpdate(client client.Client, decoder admission.Decoder, _ re
 			return utils.ErroredResponse(err)
 		}
 
-		if !allowed {
-			response := admission.Denied("Capsule Network Policies cannot be updated: please, reach out to the system administrators")
+		if allowed {
+			return nil
+		}
+
+		violations, fields, err := r.fieldViolations(ctx, req, client, decoder)
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		if !fields {
+			response := policy.Deny(policy.NetworkPolicyUpdate, "Capsule Network Policies cannot be updated: please, reach out to the system administrators")
+
+			return &response
+		}
+
+		if len(violations) > 0 {
+			response := policy.Deny(policy.NetworkPolicyUpdate, fmt.Sprintf("the fields managed by Capsule cannot be changed, only further entries can be appended: %s", strings.Join(violations, ", ")))
 
 			return &response
 		}
@@ -63,6 +88,74 @@ func (r *handler) OnUpdate(client client.Client, decoder admission.Decoder, _ re
 	}
 }
 
+// fieldViolations returns the fields managed by Capsule changed by the update, when the Tenant of the NetworkPolicy
+// enables the field level protection, and Capsule manages any of its fields: otherwise, the whole object is protected.
+func (r *handler) fieldViolations(ctx context.Context, req admission.Request, c client.Client, decoder admission.Decoder) (violations []string, fields bool, err error) {
+	oldNp, newNp := &unstructured.Unstructured{}, &unstructured.Unstructured{}
+
+	if err = decoder.DecodeRaw(req.OldObject, oldNp); err != nil {
+		return nil, false, err
+	}
+
+	if err = decoder.DecodeRaw(req.Object, newNp); err != nil {
+		return nil, false, err
+	}
+
+	tenantLabel, err := capsuleutils.GetTypeLabel(&capsulev1beta2.Tenant{})
+	if err != nil {
+		return nil, false, err
+	}
+
+	tnt := &capsulev1beta2.Tenant{}
+	if err = c.Get(ctx, types.NamespacedName{Name: oldNp.GetLabels()[tenantLabel]}, tnt); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	if !tnt.Spec.NetworkPolicies.FieldsProtection() {
+		return nil, false, nil
+	}
+
+	owned, err := managedfields.Owned(oldNp, managedfields.Manager)
+	if err != nil {
+		return nil, false, err
+	}
+	// The NetworkPolicies not yet written by the Capsule field manager, such as the ones created by former versions,
+	// or not recording the generated rules, are protected as a whole.
+	generated, ok := capsuleutils.GetGeneratedRules(oldNp)
+	if owned == nil || owned.Empty() || !ok {
+		return nil, false, nil
+	}
+	// The rules lists are atomic, owned as a whole by their last writer: the generated rules are protected as the
+	// lists prefix, letting the Tenant owners change, or remove, the rules they appended.
+	owned = owned.Difference(fieldpath.NewSet(fieldpath.MakePathOrDie("spec", "ingress"), fieldpath.MakePathOrDie("spec", "egress")))
+
+	violations = managedfields.Violations(owned, oldNp.Object, newNp.Object)
+	violations = append(violations, rulesViolations(oldNp.Object, newNp.Object, "ingress", generated.Ingress)...)
+	violations = append(violations, rulesViolations(oldNp.Object, newNp.Object, "egress", generated.Egress)...)
+
+	sort.Strings(violations)
+
+	return violations, true, nil
+}
+
+// rulesViolations returns the path of the rules list when the update changes, or removes, its first rules generated by Capsule.
+func rulesViolations(oldObj, newObj map[string]interface{}, field string, generated int) []string {
+	oldRules, _, _ := unstructured.NestedSlice(oldObj, "spec", field)
+	newRules, _, _ := unstructured.NestedSlice(newObj, "spec", field)
+
+	generated = min(generated, len(oldRules))
+
+	if len(newRules) < generated || !reflect.DeepEqual(oldRules[:generated], newRules[:generated]) {
+		return []string{".spec." + field}
+	}
+
+	return nil
+}
+
 func (r *handler) handle(ctx context.Context, req admission.Request, client client.Client, _ admission.Decoder) (allowed bool, err error) {
 	allowed = true
 
@@ -81,5 +174,9 @@ func (r *handler) handle(ctx context.Context, req admission.Request, client clie
 		allowed = false
 	}
 
+	if _, ok := labels[capsuleutils.IngressNetworkPolicyLabel]; ok {
+		allowed = false
+	}
+
 	return
 }
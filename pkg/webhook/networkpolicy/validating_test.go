@@ -0,0 +1,155 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package networkpolicy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+	"github.com/projectcapsule/capsule/pkg/managedfields"
+	capsuleutils "github.com/projectcapsule/capsule/pkg/utils"
+)
+
+func ingressRule(namespace string) networkingv1.NetworkPolicyIngressRule {
+	return networkingv1.NetworkPolicyIngressRule{
+		From: []networkingv1.NetworkPolicyPeer{{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": namespace}},
+		}},
+	}
+}
+
+func TestOnUpdateFieldsProtection(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, capsulev1beta2.AddToScheme(scheme))
+
+	tnt := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "oil"},
+		Spec: capsulev1beta2.TenantSpec{
+			NetworkPolicies: api.NetworkPolicySpec{Protection: api.NetworkPolicyProtectionFields},
+		},
+	}
+	// The rule appended by the owner is owned by Capsule too, having written the whole rules list after the owner.
+	current := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "capsule-oil-0",
+			Namespace: "oil-production",
+			Labels: map[string]string{
+				"capsule.clastix.io/tenant":         "oil",
+				"capsule.clastix.io/network-policy": "0",
+			},
+			Annotations: map[string]string{
+				capsuleutils.GeneratedRulesAnnotation: capsuleutils.GeneratedRules{Ingress: 1}.String(),
+			},
+			ManagedFields: []metav1.ManagedFieldsEntry{{
+				Manager:   managedfields.Manager,
+				Operation: metav1.ManagedFieldsOperationUpdate,
+				FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:annotations":{".":{},"f:capsule.clastix.io/generated-rules":{}},` +
+					`"f:labels":{".":{},"f:capsule.clastix.io/network-policy":{},"f:capsule.clastix.io/tenant":{}}},` +
+					`"f:spec":{"f:ingress":{},"f:podSelector":{},"f:policyTypes":{}}}`)},
+			}},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress:     []networkingv1.NetworkPolicyIngressRule{ingressRule("oil-production"), ingressRule("monitoring")},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tnt, current.DeepCopy()).Build()
+
+	update := func(mutate func(np *networkingv1.NetworkPolicy)) *admission.Response {
+		updated := current.DeepCopy()
+		mutate(updated)
+
+		oldRaw, err := json.Marshal(current)
+		require.NoError(t, err)
+
+		newRaw, err := json.Marshal(updated)
+		require.NoError(t, err)
+
+		req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Name:      current.GetName(),
+			Namespace: current.GetNamespace(),
+			Operation: admissionv1.Update,
+			OldObject: runtime.RawExtension{Raw: oldRaw},
+			Object:    runtime.RawExtension{Raw: newRaw},
+		}}
+
+		return Handler().OnUpdate(c, admission.NewDecoder(scheme), nil)(context.Background(), req)
+	}
+
+	testCases := []struct {
+		name    string
+		mutate  func(np *networkingv1.NetworkPolicy)
+		allowed bool
+	}{
+		{
+			name: "removing the appended rule",
+			mutate: func(np *networkingv1.NetworkPolicy) {
+				np.Spec.Ingress = np.Spec.Ingress[:1]
+			},
+			allowed: true,
+		},
+		{
+			name: "changing the appended rule",
+			mutate: func(np *networkingv1.NetworkPolicy) {
+				np.Spec.Ingress[1] = ingressRule("logging")
+			},
+			allowed: true,
+		},
+		{
+			name: "appending a further rule",
+			mutate: func(np *networkingv1.NetworkPolicy) {
+				np.Spec.Ingress = append(np.Spec.Ingress, ingressRule("logging"))
+			},
+			allowed: true,
+		},
+		{
+			name: "removing the generated rule",
+			mutate: func(np *networkingv1.NetworkPolicy) {
+				np.Spec.Ingress = np.Spec.Ingress[1:]
+			},
+		},
+		{
+			name: "changing the pod selector",
+			mutate: func(np *networkingv1.NetworkPolicy) {
+				np.Spec.PodSelector = metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+			},
+		},
+		{
+			name: "changing the generated rules count",
+			mutate: func(np *networkingv1.NetworkPolicy) {
+				np.Annotations[capsuleutils.GeneratedRulesAnnotation] = capsuleutils.GeneratedRules{}.String()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := update(tc.mutate)
+			if tc.allowed {
+				assert.Nil(t, response)
+
+				return
+			}
+
+			if assert.NotNil(t, response) {
+				assert.False(t, response.Allowed)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+	"github.com/projectcapsule/capsule/pkg/policy"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+// operatorGroup is the subset of the OLM resource Capsule is interested in, avoiding a dependency on the OLM module.
+type operatorGroup struct {
+	Spec struct {
+		TargetNamespaces []string         `json:"targetNamespaces,omitempty"`
+		Selector         *json.RawMessage `json:"selector,omitempty"`
+	} `json:"spec,omitempty"`
+}
+
+// subscription is the subset of the OLM resource Capsule is interested in, avoiding a dependency on the OLM module.
+type subscription struct {
+	Spec struct {
+		Package         string `json:"name,omitempty"`
+		Source          string `json:"source,omitempty"`
+		SourceNamespace string `json:"sourceNamespace,omitempty"`
+	} `json:"spec,omitempty"`
+}
+
+type handler struct{}
+
+// Handler governs the installation of namespaced operators in the Tenant namespaces.
+func Handler() capsulewebhook.Handler {
+	return &handler{}
+}
+
+func (h *handler) OnCreate(c client.Client, _ admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, c, recorder, req)
+	}
+}
+
+func (h *handler) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *handler) OnUpdate(c client.Client, _ admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, c, recorder, req)
+	}
+}
+
+func (h *handler) validate(ctx context.Context, c client.Client, recorder record.EventRecorder, req admission.Request) *admission.Response {
+	if len(req.Namespace) == 0 {
+		return nil
+	}
+
+	gk := schema.GroupKind{Group: req.Kind.Group, Kind: req.Kind.Kind}
+
+	tnt, err := utils.TenantByStatusNamespace(ctx, c, req.Namespace)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if tnt.Spec.Operators == nil || !tnt.Spec.Operators.IsOperatorResource(gk) {
+		return nil
+	}
+
+	if !tnt.Spec.Operators.AllowInstallation {
+		policy.Eventf(recorder, tnt, policy.OperatorInstallationForbidden, corev1.EventTypeWarning, "ForbiddenOperatorInstallation", "%s %s/%s cannot be created, operators installation is forbidden for the current Tenant", req.Kind.Kind, req.Namespace, req.Name)
+
+		response := policy.Deny(policy.OperatorInstallationForbidden, fmt.Sprintf("%s cannot be created: operators installation is forbidden for the current Tenant", gk.String()))
+
+		return &response
+	}
+
+	switch gk {
+	case api.OLMOperatorGroupKind:
+		return h.validateOperatorGroup(tnt, recorder, req)
+	case api.OLMSubscriptionKind:
+		return h.validateSubscription(tnt, recorder, req)
+	default:
+		return nil
+	}
+}
+
+// validateOperatorGroup ensures the OperatorGroup only targets the Tenant namespaces:
+// OLM grants the installed operators the permissions on the target namespaces,
+// a selector, or no target at all, could extend them to the namespaces of other Tenants.
+func (h *handler) validateOperatorGroup(tnt *capsulev1beta2.Tenant, recorder record.EventRecorder, req admission.Request) *admission.Response {
+	og := &operatorGroup{}
+	if err := json.Unmarshal(req.Object.Raw, og); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	var denial string
+
+	switch {
+	case og.Spec.Selector != nil:
+		denial = "OperatorGroup namespace selector is forbidden for the current Tenant, use targetNamespaces"
+	case len(og.Spec.TargetNamespaces) == 0:
+		denial = "OperatorGroup must declare its targetNamespaces, targeting all namespaces is forbidden for the current Tenant"
+	default:
+		namespaces := make(map[string]struct{}, len(tnt.Status.Namespaces))
+		for _, ns := range tnt.Status.Namespaces {
+			namespaces[ns] = struct{}{}
+		}
+
+		for _, ns := range og.Spec.TargetNamespaces {
+			if _, ok := namespaces[ns]; !ok {
+				denial = fmt.Sprintf("OperatorGroup cannot target Namespace %s, not part of the current Tenant", ns)
+
+				break
+			}
+		}
+	}
+
+	if len(denial) == 0 {
+		return nil
+	}
+
+	policy.Eventf(recorder, tnt, policy.OperatorTargetForbidden, corev1.EventTypeWarning, "ForbiddenOperatorTarget", "OperatorGroup %s/%s: %s", req.Namespace, req.Name, denial)
+
+	response := policy.Deny(policy.OperatorTargetForbidden, denial)
+
+	return &response
+}
+
+func (h *handler) validateSubscription(tnt *capsulev1beta2.Tenant, recorder record.EventRecorder, req admission.Request) *admission.Response {
+	sub := &subscription{}
+	if err := json.Unmarshal(req.Object.Raw, sub); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if err := tnt.Spec.Operators.ValidateCatalog(sub.Spec.SourceNamespace, sub.Spec.Source); err != nil {
+		policy.Eventf(recorder, tnt, policy.OperatorCatalogForbidden, corev1.EventTypeWarning, "ForbiddenOperatorCatalog", "Subscription %s/%s to package %s is using the forbidden catalog %s/%s", req.Namespace, req.Name, sub.Spec.Package, sub.Spec.SourceNamespace, sub.Spec.Source)
+
+		response := policy.Deny(policy.OperatorCatalogForbidden, err.Error())
+
+		return &response
+	}
+
+	return nil
+}
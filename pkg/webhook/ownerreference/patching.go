@@ -23,6 +23,7 @@ import (
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
 	"github.com/projectcapsule/capsule/pkg/configuration"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsuleutils "github.com/projectcapsule/capsule/pkg/utils"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
@@ -65,9 +66,9 @@ func (h *handler) OnUpdate(c client.Client, decoder admission.Decoder, recorder
 		}
 
 		if !h.namespaceIsOwned(oldNs, tntList, req) {
-			recorder.Eventf(oldNs, corev1.EventTypeWarning, "OfflimitNamespace", "Namespace %s can not be patched", oldNs.GetName())
+			policy.Eventf(recorder, oldNs, policy.NamespacePatchForbidden, corev1.EventTypeWarning, "OfflimitNamespace", "Namespace %s can not be patched", oldNs.GetName())
 
-			response := admission.Denied("Denied patch request for this namespace")
+			response := policy.Deny(policy.NamespacePatchForbidden, "Denied patch request for this namespace")
 
 			return &response
 		}
@@ -155,9 +156,9 @@ func (h *handler) setOwnerRef(ctx context.Context, req admission.Request, client
 		}
 		// Tenant owner must adhere to user that asked for NS creation
 		if !utils.IsTenantOwner(tnt.Spec.Owners, req.UserInfo) {
-			recorder.Eventf(tnt, corev1.EventTypeWarning, "NonOwnedTenant", "Namespace %s cannot be assigned to the current Tenant", ns.GetName())
+			policy.Eventf(recorder, tnt, policy.NamespaceTenantNotOwned, corev1.EventTypeWarning, "NonOwnedTenant", "Namespace %s cannot be assigned to the current Tenant", ns.GetName())
 
-			response := admission.Denied("Cannot assign the desired namespace to a non-owned Tenant")
+			response := policy.Deny(policy.NamespaceTenantNotOwned, "Cannot assign the desired namespace to a non-owned Tenant")
 
 			return &response
 		}
@@ -220,7 +221,7 @@ func (h *handler) setOwnerRef(ctx context.Context, req admission.Request, client
 	sort.Sort(sort.Reverse(tenants))
 
 	if len(tenants) == 0 {
-		response := admission.Denied("You do not have any Tenant assigned: please, reach out to the system administrators")
+		response := policy.Deny(policy.NamespaceTenantMissing, "You do not have any Tenant assigned: please, reach out to the system administrators")
 
 		return &response
 	}
@@ -245,12 +246,12 @@ func (h *handler) setOwnerRef(ctx context.Context, req admission.Request, client
 			}
 		}
 
-		response := admission.Denied("The Namespace prefix used doesn't match any available Tenant")
+		response := policy.Deny(policy.NamespacePrefixMismatch, "The Namespace prefix used doesn't match any available Tenant")
 
 		return &response
 	}
 
-	response := admission.Denied("Unable to assign namespace to tenant. Please use " + ln + " label when creating a namespace")
+	response := policy.Deny(policy.NamespaceTenantAmbiguous, "Unable to assign namespace to tenant. Please use "+ln+" label when creating a namespace")
 
 	return &response
 }
@@ -295,7 +296,7 @@ func (h *handler) validateNamespacePrefix(ns *corev1.Namespace, tenant *capsulev
 	// Check if ForceTenantPrefix is true
 	if tenant.Spec.ForceTenantPrefix != nil && *tenant.Spec.ForceTenantPrefix {
 		if !strings.HasPrefix(ns.GetName(), fmt.Sprintf("%s-", tenant.GetName())) {
-			response := admission.Denied(fmt.Sprintf("The Namespace name must start with '%s-' when ForceTenantPrefix is enabled in the Tenant.", tenant.GetName()))
+			response := policy.Deny(policy.NamespacePrefixMismatch, fmt.Sprintf("The Namespace name must start with '%s-' when ForceTenantPrefix is enabled in the Tenant.", tenant.GetName()))
 
 			return &response
 		}
@@ -13,6 +13,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
@@ -86,9 +87,9 @@ func (h *containerRegistryHandler) VerifyContainerRegistry(recorder record.Event
 	reg := NewRegistry(container.Image)
 
 	if len(reg.Registry()) == 0 {
-		recorder.Eventf(&tnt, corev1.EventTypeWarning, "MissingFQCI", "Pod %s/%s is not using a fully qualified container image, cannot enforce registry the current Tenant", req.Namespace, req.Name, reg.Registry())
+		policy.Eventf(recorder, &tnt, policy.RegistryNotFullyQualified, corev1.EventTypeWarning, "MissingFQCI", "Pod %s/%s is not using a fully qualified container image, cannot enforce registry the current Tenant", req.Namespace, req.Name)
 
-		response := admission.Denied(NewContainerRegistryForbidden(container.Image, *tnt.Spec.ContainerRegistries).Error())
+		response := policy.Deny(policy.RegistryNotFullyQualified, NewContainerRegistryForbidden(container.Image, *tnt.Spec.ContainerRegistries).Error())
 
 		return &response
 	}
@@ -98,9 +99,9 @@ func (h *containerRegistryHandler) VerifyContainerRegistry(recorder record.Event
 	matched = tnt.Spec.ContainerRegistries.RegexMatch(reg.Registry())
 
 	if !valid && !matched {
-		recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenContainerRegistry", "Pod %s/%s is using a container hosted on registry %s that is forbidden for the current Tenant", req.Namespace, req.Name, reg.Registry())
+		policy.Eventf(recorder, &tnt, policy.RegistryForbidden, corev1.EventTypeWarning, "ForbiddenContainerRegistry", "Pod %s/%s is using a container hosted on registry %s that is forbidden for the current Tenant", req.Namespace, req.Name, reg.Registry())
 
-		response := admission.Denied(NewContainerRegistryForbidden(container.Image, *tnt.Spec.ContainerRegistries).Error())
+		response := policy.Deny(policy.RegistryForbidden, NewContainerRegistryForbidden(container.Image, *tnt.Spec.ContainerRegistries).Error())
 
 		return &response
 	}
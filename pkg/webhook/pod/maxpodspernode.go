@@ -0,0 +1,104 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+	"github.com/projectcapsule/capsule/pkg/policy"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type maxPodsPerNode struct{}
+
+// MaxPodsPerNode protects the node slots spreading the Tenant Pods across the nodes, enforced by the scheduler with the
+// Pod anti-affinity injected upon the Pod creation: the Pods with an assigned node bypass the scheduler, and are denied,
+// as well as the changes of the node slot label escaping the anti-affinity of the other Pods.
+func MaxPodsPerNode() capsulewebhook.Handler {
+	return &maxPodsPerNode{}
+}
+
+func (h *maxPodsPerNode) tenant(ctx context.Context, c client.Client, namespace string) (*capsulev1beta2.Tenant, error) {
+	tnt, err := utils.TenantByStatusNamespace(ctx, c, namespace)
+	if err != nil || tnt == nil || len(tnt.GetName()) == 0 || tnt.Spec.PodOptions == nil || tnt.Spec.PodOptions.MaxPodsPerNode == nil {
+		return nil, err
+	}
+
+	return tnt, nil
+}
+
+func (h *maxPodsPerNode) OnCreate(c client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		tnt, err := h.tenant(ctx, c, req.Namespace)
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		if tnt == nil {
+			return nil
+		}
+
+		pod := &corev1.Pod{}
+		if err = decoder.Decode(req, pod); err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		if node := pod.Spec.NodeName; len(node) > 0 {
+			policy.Eventf(recorder, tnt, policy.PodNodeSpreadBypassed, corev1.EventTypeWarning, "PodNodeSpreadBypassed", "Pod %s/%s cannot be assigned to node %s, bypassing the scheduler spreading the Tenant Pods", req.Namespace, req.Name, node)
+
+			response := policy.Deny(policy.PodNodeSpreadBypassed, NewPodNodeAssignedError(node, *tnt.Spec.PodOptions.MaxPodsPerNode).Error())
+
+			return &response
+		}
+
+		return nil
+	}
+}
+
+func (h *maxPodsPerNode) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *maxPodsPerNode) OnUpdate(c client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		oldPod, newPod := &corev1.Pod{}, &corev1.Pod{}
+		if err := decoder.DecodeRaw(req.OldObject, oldPod); err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		if err := decoder.Decode(req, newPod); err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		slot, ok := oldPod.GetLabels()[api.NodeSlotLabel]
+		if !ok || newPod.GetLabels()[api.NodeSlotLabel] == slot {
+			return nil
+		}
+
+		tnt, err := h.tenant(ctx, c, req.Namespace)
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		if tnt == nil {
+			return nil
+		}
+
+		policy.Eventf(recorder, tnt, policy.PodNodeSpreadBypassed, corev1.EventTypeWarning, "PodNodeSpreadBypassed", "Pod %s/%s cannot change its node slot label %s", req.Namespace, req.Name, api.NodeSlotLabel)
+
+		response := policy.Deny(policy.PodNodeSpreadBypassed, NewPodNodeSlotChangedError().Error())
+
+		return &response
+	}
+}
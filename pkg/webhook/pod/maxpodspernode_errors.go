@@ -0,0 +1,33 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"fmt"
+
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+type podNodeAssignedError struct {
+	node  string
+	limit int32
+}
+
+func NewPodNodeAssignedError(node string, limit int32) error {
+	return &podNodeAssignedError{node: node, limit: limit}
+}
+
+func (p podNodeAssignedError) Error() string {
+	return fmt.Sprintf("Cannot assign the node %s bypassing the scheduler, enforcing the quota of %d Pods per node for the current Tenant: please, remove the spec.nodeName field", p.node, p.limit)
+}
+
+type podNodeSlotChangedError struct{}
+
+func NewPodNodeSlotChangedError() error {
+	return &podNodeSlotChangedError{}
+}
+
+func (podNodeSlotChangedError) Error() string {
+	return fmt.Sprintf("Cannot change the %s label, spreading the Pods of the current Tenant across the nodes", api.NodeSlotLabel)
+}
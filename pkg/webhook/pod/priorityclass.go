@@ -12,6 +12,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
@@ -75,9 +76,9 @@ func (h *priorityClass) OnCreate(c client.Client, decoder admission.Decoder, rec
 		case allowed.Match(priorityClassName) || selector:
 			return nil
 		default:
-			recorder.Eventf(tnt, corev1.EventTypeWarning, "ForbiddenPriorityClass", "Pod %s/%s is using Priority Class %s is forbidden for the current Tenant", pod.Namespace, pod.Name, priorityClassName)
+			policy.Eventf(recorder, tnt, policy.PodForbiddenPriorityClass, corev1.EventTypeWarning, "ForbiddenPriorityClass", "Pod %s/%s is using Priority Class %s is forbidden for the current Tenant", pod.Namespace, pod.Name, priorityClassName)
 
-			response := admission.Denied(NewPodPriorityClassForbidden(priorityClassName, *allowed).Error())
+			response := policy.Deny(policy.PodForbiddenPriorityClass, NewPodPriorityClassForbidden(priorityClassName, *allowed).Error())
 
 			return &response
 		}
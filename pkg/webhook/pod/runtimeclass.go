@@ -14,6 +14,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
@@ -92,9 +93,9 @@ func (h *runtimeClass) validate(ctx context.Context, c client.Client, decoder ad
 		// Delegating mutating webhook to specify a default RuntimeClass
 		return nil
 	case !allowed.MatchSelectByName(class):
-		recorder.Eventf(tnt, corev1.EventTypeWarning, "ForbiddenRuntimeClass", "Pod %s/%s is using Runtime Class %s is forbidden for the current Tenant", pod.Namespace, pod.Name, runtimeClassName)
+		policy.Eventf(recorder, tnt, policy.PodForbiddenRuntimeClass, corev1.EventTypeWarning, "ForbiddenRuntimeClass", "Pod %s/%s is using Runtime Class %s is forbidden for the current Tenant", pod.Namespace, pod.Name, runtimeClassName)
 
-		response := admission.Denied(NewPodRuntimeClassForbidden(runtimeClassName, *allowed).Error())
+		response := policy.Deny(policy.PodForbiddenRuntimeClass, NewPodRuntimeClassForbidden(runtimeClassName, *allowed).Error())
 
 		return &response
 	default:
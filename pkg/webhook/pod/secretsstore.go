@@ -0,0 +1,100 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/projectcapsule/capsule/pkg/policy"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type secretsStore struct{}
+
+// SecretsStore validates the SecretProviderClass resources mounted by Pods using the Secrets Store CSI driver:
+// objects created before the Tenant restrictions were in place are still checked before being mounted.
+func SecretsStore() capsulewebhook.Handler {
+	return &secretsStore{}
+}
+
+func (h *secretsStore) OnCreate(c client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, c, decoder, recorder, req)
+	}
+}
+
+func (h *secretsStore) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *secretsStore) OnUpdate(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *secretsStore) validate(ctx context.Context, c client.Client, decoder admission.Decoder, recorder record.EventRecorder, req admission.Request) *admission.Response {
+	pod := &corev1.Pod{}
+	if err := decoder.Decode(req, pod); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	tnt, err := utils.TenantByStatusNamespace(ctx, c, req.Namespace)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if len(tnt.GetName()) == 0 || tnt.Spec.SecretsStore == nil {
+		return nil
+	}
+
+	for _, volume := range pod.Spec.Volumes {
+		if volume.CSI == nil || volume.CSI.Driver != utils.SecretsStoreCSIDriver {
+			continue
+		}
+
+		name := volume.CSI.VolumeAttributes[utils.SecretProviderClassAttribute]
+
+		spc, err := utils.GetSecretProviderClass(ctx, c, req.Namespace, name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				response := policy.Deny(policy.SecretsStoreClassNotFound, NewSecretProviderClassNotFoundError(volume.Name, name).Error())
+
+				return &response
+			}
+
+			return utils.ErroredResponse(err)
+		}
+
+		if err = tnt.Spec.SecretsStore.ValidateProvider(spc.Spec.Provider); err != nil {
+			policy.Eventf(recorder, tnt, policy.SecretsStoreForbiddenProvider, corev1.EventTypeWarning, "ForbiddenSecretProvider", "Pod %s/%s is mounting SecretProviderClass %s using the forbidden provider %s", req.Namespace, pod.GetName(), name, spc.Spec.Provider)
+
+			response := policy.Deny(policy.SecretsStoreForbiddenProvider, err.Error())
+
+			return &response
+		}
+
+		if err = tnt.Spec.SecretsStore.Validate(spc.Spec.Parameters); err != nil {
+			err = fmt.Errorf("volume %s cannot be mounted: %w", volume.Name, err)
+
+			policy.Eventf(recorder, tnt, policy.SecretsStoreForbiddenParameter, corev1.EventTypeWarning, "ForbiddenSecretProviderParameter", "Pod %s/%s is mounting SecretProviderClass %s: %s", req.Namespace, pod.GetName(), name, err.Error())
+
+			response := policy.Deny(policy.SecretsStoreForbiddenParameter, err.Error())
+
+			return &response
+		}
+	}
+
+	return nil
+}
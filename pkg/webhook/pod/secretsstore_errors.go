@@ -0,0 +1,24 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"fmt"
+)
+
+type secretProviderClassNotFoundError struct {
+	volume string
+	name   string
+}
+
+func NewSecretProviderClassNotFoundError(volume, name string) error {
+	return &secretProviderClassNotFoundError{
+		volume: volume,
+		name:   name,
+	}
+}
+
+func (s secretProviderClassNotFoundError) Error() string {
+	return fmt.Sprintf("volume %s refers to the SecretProviderClass %s which does not exist, cannot validate it against the Tenant restrictions", s.volume, s.name)
+}
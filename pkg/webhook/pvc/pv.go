@@ -15,6 +15,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
@@ -52,7 +53,9 @@ func (p PV) OnCreate(client client.Client, decoder admission.Decoder, _ record.E
 		// A PersistentVolume selector cannot help in preventing a cross-tenant mount:
 		// thus, disallowing that in first place.
 		if pvc.Spec.Selector != nil {
-			return utils.ErroredResponse(NewPVSelectorError())
+			response := policy.Deny(policy.PersistentVolumeSelector, NewPVSelectorError().Error())
+
+			return &response
 		}
 		// The PVC hasn't any volumeName pre-claimed, it can be skipped
 		if len(pvc.Spec.VolumeName) == 0 {
@@ -69,16 +72,22 @@ func (p PV) OnCreate(client client.Client, decoder admission.Decoder, _ record.E
 		}
 
 		if pv.GetLabels() == nil {
-			return utils.ErroredResponse(NewMissingPVLabelsError(pv.GetName()))
+			response := policy.Deny(policy.PersistentVolumeCrossTenant, NewMissingPVLabelsError(pv.GetName()).Error())
+
+			return &response
 		}
 
 		value, ok := pv.GetLabels()[p.capsuleLabel]
 		if !ok {
-			return utils.ErroredResponse(NewMissingTenantPVLabelsError(pv.GetName()))
+			response := policy.Deny(policy.PersistentVolumeCrossTenant, NewMissingTenantPVLabelsError(pv.GetName()).Error())
+
+			return &response
 		}
 
 		if value != tnt.Name {
-			return utils.ErroredResponse(NewCrossTenantPVMountError(pv.GetName()))
+			response := policy.Deny(policy.PersistentVolumeCrossTenant, NewCrossTenantPVMountError(pv.GetName()).Error())
+
+			return &response
 		}
 
 		return nil
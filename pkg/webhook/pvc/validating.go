@@ -13,6 +13,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
@@ -48,9 +49,9 @@ func (h *validating) OnCreate(c client.Client, decoder admission.Decoder, record
 		storageClass := pvc.Spec.StorageClassName
 
 		if storageClass == nil {
-			recorder.Eventf(tnt, corev1.EventTypeWarning, "MissingStorageClass", "PersistentVolumeClaim %s/%s is missing StorageClass", req.Namespace, req.Name)
+			policy.Eventf(recorder, tnt, policy.StorageClassMissing, corev1.EventTypeWarning, "MissingStorageClass", "PersistentVolumeClaim %s/%s is missing StorageClass", req.Namespace, req.Name)
 
-			response := admission.Denied(NewStorageClassNotValid(*tnt.Spec.StorageClasses).Error())
+			response := policy.Deny(policy.StorageClassMissing, NewStorageClassNotValid(*tnt.Spec.StorageClasses).Error())
 
 			return &response
 		}
@@ -78,9 +79,9 @@ func (h *validating) OnCreate(c client.Client, decoder admission.Decoder, record
 		case allowed.Match(*storageClass) || selector:
 			return nil
 		default:
-			recorder.Eventf(tnt, corev1.EventTypeWarning, "ForbiddenStorageClass", "PersistentVolumeClaim %s/%s StorageClass %s is forbidden for the current Tenant", req.Namespace, req.Name, *storageClass)
+			policy.Eventf(recorder, tnt, policy.StorageClassForbidden, corev1.EventTypeWarning, "ForbiddenStorageClass", "PersistentVolumeClaim %s/%s StorageClass %s is forbidden for the current Tenant", req.Namespace, req.Name, *storageClass)
 
-			response := admission.Denied(NewStorageClassForbidden(*pvc.Spec.StorageClassName, *tnt.Spec.StorageClasses).Error())
+			response := policy.Deny(policy.StorageClassForbidden, NewStorageClassForbidden(*pvc.Spec.StorageClassName, *tnt.Spec.StorageClasses).Error())
 
 			return &response
 		}
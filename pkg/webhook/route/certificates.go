@@ -0,0 +1,26 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package route
+
+import (
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+)
+
+// +kubebuilder:webhook:path=/certificates,mutating=false,sideEffects=None,admissionReviewVersions=v1,failurePolicy=fail,groups="cert-manager.io",resources=certificates,verbs=create,versions=v1,name=certificates.projectcapsule.dev
+
+type certificate struct {
+	handlers []capsulewebhook.Handler
+}
+
+func Certificate(handler ...capsulewebhook.Handler) capsulewebhook.Webhook {
+	return &certificate{handlers: handler}
+}
+
+func (w *certificate) GetHandlers() []capsulewebhook.Handler {
+	return w.handlers
+}
+
+func (w *certificate) GetPath() string {
+	return "/certificates"
+}
@@ -0,0 +1,26 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package route
+
+import (
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+)
+
+// +kubebuilder:webhook:path=/secretproviderclasses,mutating=false,sideEffects=None,admissionReviewVersions=v1,failurePolicy=fail,groups="secrets-store.csi.x-k8s.io",resources=secretproviderclasses,verbs=create;update,versions=v1,name=secretproviderclasses.projectcapsule.dev
+
+type secretProviderClass struct {
+	handlers []capsulewebhook.Handler
+}
+
+func SecretProviderClass(handler ...capsulewebhook.Handler) capsulewebhook.Webhook {
+	return &secretProviderClass{handlers: handler}
+}
+
+func (w *secretProviderClass) GetHandlers() []capsulewebhook.Handler {
+	return w.handlers
+}
+
+func (w *secretProviderClass) GetPath() string {
+	return "/secretproviderclasses"
+}
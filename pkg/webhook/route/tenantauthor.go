@@ -0,0 +1,26 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package route
+
+import (
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+)
+
+// +kubebuilder:webhook:path=/tenant-author,mutating=true,sideEffects=None,admissionReviewVersions=v1,failurePolicy=fail,groups="capsule.clastix.io",resources=tenants,verbs=create;update,versions=v1beta2,name=author.tenant.projectcapsule.dev
+
+type tenantAuthor struct {
+	handlers []capsulewebhook.Handler
+}
+
+func TenantAuthor(handler ...capsulewebhook.Handler) capsulewebhook.Webhook {
+	return &tenantAuthor{handlers: handler}
+}
+
+func (w *tenantAuthor) GetHandlers() []capsulewebhook.Handler {
+	return w.handlers
+}
+
+func (w *tenantAuthor) GetPath() string {
+	return "/tenant-author"
+}
@@ -7,14 +7,20 @@ import (
 	"context"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/client-go/tools/record"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/history"
+	"github.com/projectcapsule/capsule/pkg/policy"
 )
 
-func Register(manager controllerruntime.Manager, webhookList ...Webhook) error {
+// Register serves the webhooks: the denials are recorded in the audit history, unless the history recorder is nil.
+func Register(manager controllerruntime.Manager, historyRecorder *history.Recorder, webhookList ...Webhook) error {
 	recorder := manager.GetEventRecorderFor("tenant-webhook")
 
 	server := manager.GetWebhookServer()
@@ -25,6 +31,7 @@ func Register(manager controllerruntime.Manager, webhookList ...Webhook) error {
 				client:   manager.GetClient(),
 				decoder:  admission.NewDecoder(manager.GetScheme()),
 				recorder: recorder,
+				history:  historyRecorder,
 				handlers: wh.GetHandlers(),
 			},
 		})
@@ -37,33 +44,104 @@ type handlerRouter struct {
 	client   client.Client
 	decoder  admission.Decoder
 	recorder record.EventRecorder
+	history  *history.Recorder
 
 	handlers []Handler
 }
 
 func (r *handlerRouter) Handle(ctx context.Context, req admission.Request) admission.Response {
-	switch req.Operation {
-	case admissionv1.Create:
-		for _, h := range r.handlers {
-			if response := h.OnCreate(r.client, r.decoder, r.recorder)(ctx, req); response != nil {
-				return *response
-			}
+	var warnings []string
+
+	for _, h := range r.handlers {
+		var fn Func
+
+		switch req.Operation {
+		case admissionv1.Create:
+			fn = h.OnCreate(r.client, r.decoder, r.recorder)
+		case admissionv1.Update:
+			fn = h.OnUpdate(r.client, r.decoder, r.recorder)
+		case admissionv1.Delete:
+			fn = h.OnDelete(r.client, r.decoder, r.recorder)
+		default:
+			return admission.Allowed("")
 		}
-	case admissionv1.Update:
-		for _, h := range r.handlers {
-			if response := h.OnUpdate(r.client, r.decoder, r.recorder)(ctx, req); response != nil {
-				return *response
-			}
+
+		response := fn(ctx, req)
+		if response == nil {
+			continue
 		}
-	case admissionv1.Delete:
-		for _, h := range r.handlers {
-			if response := h.OnDelete(r.client, r.decoder, r.recorder)(ctx, req); response != nil {
-				return *response
-			}
+		// Responses only carrying warnings let the following handlers evaluate the request.
+		if isWarningOnly(response) {
+			warnings = append(warnings, response.Warnings...)
+
+			continue
 		}
-	case admissionv1.Connect:
-		return admission.Allowed("")
+
+		response.Warnings = append(warnings, response.Warnings...)
+
+		policy.Record(*response)
+		r.audit(ctx, req, *response)
+
+		return *response
+	}
+
+	return admission.Allowed("").WithWarnings(warnings...)
+}
+
+func isWarningOnly(response *admission.Response) bool {
+	return response.Allowed && len(response.Warnings) > 0 && len(response.Patches) == 0 && len(response.Patch) == 0
+}
+
+// audit records the denial of the request in the audit history.
+func (r *handlerRouter) audit(ctx context.Context, req admission.Request, response admission.Response) {
+	if r.history == nil {
+		return
+	}
+
+	code, ok := policy.CodeOf(response)
+	if !ok {
+		return
+	}
+
+	record := history.Record{
+		Kind:      history.KindAudit,
+		Tenant:    r.tenantOf(ctx, req),
+		Namespace: req.Namespace,
+		Resource:  req.Resource.Resource,
+		Name:      req.Name,
+		Operation: string(req.Operation),
+		User:      req.UserInfo.Username,
+		Code:      string(code),
+	}
+
+	if response.Result != nil {
+		record.Message = response.Result.Message
+	}
+
+	r.history.Record(record)
+}
+
+// tenantOf returns the Tenant of the request, if any: the Tenant itself, or the one of the Namespace.
+func (r *handlerRouter) tenantOf(ctx context.Context, req admission.Request) string {
+	namespace := req.Namespace
+
+	switch req.Kind.Kind {
+	case "Tenant":
+		return req.Name
+	case "Namespace":
+		namespace = req.Name
+	}
+
+	if len(namespace) == 0 {
+		return ""
+	}
+
+	tntList := &capsulev1beta2.TenantList{}
+	if err := r.client.List(ctx, tntList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(".status.namespaces", namespace),
+	}); err != nil || len(tntList.Items) == 0 {
+		return ""
 	}
 
-	return admission.Allowed("")
+	return tntList.Items[0].GetName()
 }
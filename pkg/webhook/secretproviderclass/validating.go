@@ -0,0 +1,79 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package secretproviderclass
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/projectcapsule/capsule/pkg/policy"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type handler struct{}
+
+func Handler() capsulewebhook.Handler {
+	return &handler{}
+}
+
+func (h *handler) OnCreate(c client.Client, _ admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, c, recorder, req)
+	}
+}
+
+func (h *handler) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *handler) OnUpdate(c client.Client, _ admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, c, recorder, req)
+	}
+}
+
+func (h *handler) validate(ctx context.Context, c client.Client, recorder record.EventRecorder, req admission.Request) *admission.Response {
+	spc := &utils.SecretProviderClass{}
+	if err := json.Unmarshal(req.Object.Raw, spc); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	tnt, err := utils.TenantByStatusNamespace(ctx, c, req.Namespace)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if len(tnt.GetName()) == 0 || tnt.Spec.SecretsStore == nil {
+		return nil
+	}
+
+	if err = tnt.Spec.SecretsStore.ValidateProvider(spc.Spec.Provider); err != nil {
+		policy.Eventf(recorder, tnt, policy.SecretsStoreForbiddenProvider, corev1.EventTypeWarning, "ForbiddenSecretProvider", "SecretProviderClass %s/%s is using the forbidden provider %s", req.Namespace, req.Name, spc.Spec.Provider)
+
+		response := policy.Deny(policy.SecretsStoreForbiddenProvider, err.Error())
+
+		return &response
+	}
+
+	if err = tnt.Spec.SecretsStore.Validate(spc.Spec.Parameters); err != nil {
+		err = errors.Wrap(err, "SecretProviderClass parameters validation failed")
+
+		policy.Eventf(recorder, tnt, policy.SecretsStoreForbiddenParameter, corev1.EventTypeWarning, "ForbiddenSecretProviderParameter", "SecretProviderClass %s/%s: %s", req.Namespace, req.Name, err.Error())
+
+		response := policy.Deny(policy.SecretsStoreForbiddenParameter, err.Error())
+
+		return &response
+	}
+
+	return nil
+}
@@ -17,6 +17,7 @@ import (
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
 	"github.com/projectcapsule/capsule/pkg/api"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
@@ -47,25 +48,25 @@ func (r *handler) handleService(ctx context.Context, clt client.Client, decoder
 	tnt := tntList.Items[0]
 
 	if svc.Spec.Type == corev1.ServiceTypeNodePort && tnt.Spec.ServiceOptions != nil && tnt.Spec.ServiceOptions.AllowedServices != nil && !*tnt.Spec.ServiceOptions.AllowedServices.NodePort {
-		recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenNodePort", "Service %s/%s cannot be type of NodePort for the current Tenant", req.Namespace, req.Name)
+		policy.Eventf(recorder, &tnt, policy.ServiceNodePortForbidden, corev1.EventTypeWarning, "ForbiddenNodePort", "Service %s/%s cannot be type of NodePort for the current Tenant", req.Namespace, req.Name)
 
-		response := admission.Denied(NewNodePortDisabledError().Error())
+		response := policy.Deny(policy.ServiceNodePortForbidden, NewNodePortDisabledError().Error())
 
 		return &response
 	}
 
 	if svc.Spec.Type == corev1.ServiceTypeExternalName && tnt.Spec.ServiceOptions != nil && tnt.Spec.ServiceOptions.AllowedServices != nil && !*tnt.Spec.ServiceOptions.AllowedServices.ExternalName {
-		recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenExternalName", "Service %s/%s cannot be type of ExternalName for the current Tenant", req.Namespace, req.Name)
+		policy.Eventf(recorder, &tnt, policy.ServiceExternalNameForbidden, corev1.EventTypeWarning, "ForbiddenExternalName", "Service %s/%s cannot be type of ExternalName for the current Tenant", req.Namespace, req.Name)
 
-		response := admission.Denied(NewExternalNameDisabledError().Error())
+		response := policy.Deny(policy.ServiceExternalNameForbidden, NewExternalNameDisabledError().Error())
 
 		return &response
 	}
 
 	if svc.Spec.Type == corev1.ServiceTypeLoadBalancer && tnt.Spec.ServiceOptions != nil && tnt.Spec.ServiceOptions.AllowedServices != nil && !*tnt.Spec.ServiceOptions.AllowedServices.LoadBalancer {
-		recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenLoadBalancer", "Service %s/%s cannot be type of LoadBalancer for the current Tenant", req.Namespace, req.Name)
+		policy.Eventf(recorder, &tnt, policy.ServiceLoadBalancerForbidden, corev1.EventTypeWarning, "ForbiddenLoadBalancer", "Service %s/%s cannot be type of LoadBalancer for the current Tenant", req.Namespace, req.Name)
 
-		response := admission.Denied(NewLoadBalancerDisabled().Error())
+		response := policy.Deny(policy.ServiceLoadBalancerForbidden, NewLoadBalancerDisabled().Error())
 
 		return &response
 	}
@@ -74,8 +75,8 @@ func (r *handler) handleService(ctx context.Context, clt client.Client, decoder
 		err := api.ValidateForbidden(svc.Annotations, tnt.Spec.ServiceOptions.ForbiddenAnnotations)
 		if err != nil {
 			err = errors.Wrap(err, "service annotations validation failed")
-			recorder.Eventf(&tnt, corev1.EventTypeWarning, api.ForbiddenAnnotationReason, err.Error())
-			response := admission.Denied(err.Error())
+			policy.Eventf(recorder, &tnt, policy.ServiceForbiddenAnnotation, corev1.EventTypeWarning, api.ForbiddenAnnotationReason, err.Error())
+			response := policy.Deny(policy.ServiceForbiddenAnnotation, err.Error())
 
 			return &response
 		}
@@ -83,13 +84,33 @@ func (r *handler) handleService(ctx context.Context, clt client.Client, decoder
 		err = api.ValidateForbidden(svc.Labels, tnt.Spec.ServiceOptions.ForbiddenLabels)
 		if err != nil {
 			err = errors.Wrap(err, "service labels validation failed")
-			recorder.Eventf(&tnt, corev1.EventTypeWarning, api.ForbiddenLabelReason, err.Error())
-			response := admission.Denied(err.Error())
+			policy.Eventf(recorder, &tnt, policy.ServiceForbiddenLabel, corev1.EventTypeWarning, api.ForbiddenLabelReason, err.Error())
+			response := policy.Deny(policy.ServiceForbiddenLabel, err.Error())
 
 			return &response
 		}
 	}
 
+	if tnt.Spec.ServiceOptions != nil && tnt.Spec.ServiceOptions.Ports != nil {
+		for _, port := range svc.Spec.Ports {
+			if err := tnt.Spec.ServiceOptions.Ports.ValidateProtocol(port); err != nil {
+				policy.Eventf(recorder, &tnt, policy.ServiceForbiddenProtocol, corev1.EventTypeWarning, "ForbiddenServiceProtocol", "Service %s/%s port %d protocol is forbidden for the current Tenant", req.Namespace, req.Name, port.Port)
+
+				response := policy.Deny(policy.ServiceForbiddenProtocol, err.Error())
+
+				return &response
+			}
+
+			if err := tnt.Spec.ServiceOptions.Ports.ValidatePort(port); err != nil {
+				policy.Eventf(recorder, &tnt, policy.ServiceForbiddenPort, corev1.EventTypeWarning, "ForbiddenServicePort", "Service %s/%s port %d is forbidden for the current Tenant", req.Namespace, req.Name, port.Port)
+
+				response := policy.Deny(policy.ServiceForbiddenPort, err.Error())
+
+				return &response
+			}
+		}
+	}
+
 	if svc.Spec.ExternalIPs == nil || (tnt.Spec.ServiceOptions == nil || tnt.Spec.ServiceOptions.ExternalServiceIPs == nil) {
 		return nil
 	}
@@ -114,9 +135,9 @@ func (r *handler) handleService(ctx context.Context, clt client.Client, decoder
 		ip := net.ParseIP(externalIP)
 
 		if !ipInCIDR(ip) {
-			recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenExternalServiceIP", "Service %s/%s external IP %s is forbidden for the current Tenant", req.Namespace, req.Name, ip.String())
+			policy.Eventf(recorder, &tnt, policy.ServiceForbiddenExternalIP, corev1.EventTypeWarning, "ForbiddenExternalServiceIP", "Service %s/%s external IP %s is forbidden for the current Tenant", req.Namespace, req.Name, ip.String())
 
-			response := admission.Denied(NewExternalServiceIPForbidden(tnt.Spec.ServiceOptions.ExternalServiceIPs.Allowed).Error())
+			response := policy.Deny(policy.ServiceForbiddenExternalIP, NewExternalServiceIPForbidden(tnt.Spec.ServiceOptions.ExternalServiceIPs.Allowed).Error())
 
 			return &response
 		}
@@ -0,0 +1,94 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type authorHandler struct{}
+
+// AuthorHandler records the user changing the Tenant specification in the Tenant annotations,
+// allowing the changes applied by Capsule upon the Tenant reconciliation to be attributed to the user.
+func AuthorHandler() capsulewebhook.Handler {
+	return &authorHandler{}
+}
+
+func (h *authorHandler) OnCreate(_ client.Client, decoder admission.Decoder, _ record.EventRecorder) capsulewebhook.Func {
+	return func(_ context.Context, req admission.Request) *admission.Response {
+		tnt := &capsulev1beta2.Tenant{}
+		if err := decoder.Decode(req, tnt); err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		return h.mutate(req, tnt, req.UserInfo.Username)
+	}
+}
+
+func (h *authorHandler) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *authorHandler) OnUpdate(_ client.Client, decoder admission.Decoder, _ record.EventRecorder) capsulewebhook.Func {
+	return func(_ context.Context, req admission.Request) *admission.Response {
+		oldTnt, newTnt := &capsulev1beta2.Tenant{}, &capsulev1beta2.Tenant{}
+
+		if err := decoder.DecodeRaw(req.OldObject, oldTnt); err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		if err := decoder.Decode(req, newTnt); err != nil {
+			return utils.ErroredResponse(err)
+		}
+		// Keeping the previous author when the specification is unchanged,
+		// preventing the annotation from being set by the users themselves.
+		author := oldTnt.GetAnnotations()[capsulev1beta2.TenantSpecAuthorAnnotation]
+		if !equality.Semantic.DeepEqual(oldTnt.Spec, newTnt.Spec) {
+			author = req.UserInfo.Username
+		}
+
+		return h.mutate(req, newTnt, author)
+	}
+}
+
+func (h *authorHandler) mutate(req admission.Request, tnt *capsulev1beta2.Tenant, author string) *admission.Response {
+	annotations := tnt.GetAnnotations()
+
+	if annotations[capsulev1beta2.TenantSpecAuthorAnnotation] == author {
+		return nil
+	}
+
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+
+	if len(author) > 0 {
+		annotations[capsulev1beta2.TenantSpecAuthorAnnotation] = author
+	} else {
+		delete(annotations, capsulev1beta2.TenantSpecAuthorAnnotation)
+	}
+
+	tnt.SetAnnotations(annotations)
+
+	marshaled, err := json.Marshal(tnt)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	response := admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+
+	return &response
+}
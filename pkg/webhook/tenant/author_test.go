@@ -0,0 +1,89 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+func TestAuthorHandler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, capsulev1beta2.AddToScheme(scheme))
+
+	decoder := admission.NewDecoder(scheme)
+
+	tenant := func(author string, quota int32) *capsulev1beta2.Tenant {
+		tnt := &capsulev1beta2.Tenant{ObjectMeta: metav1.ObjectMeta{Name: "oil"}}
+		tnt.Spec.NamespaceOptions = &capsulev1beta2.NamespaceOptions{Quota: ptr.To(quota)}
+
+		if len(author) > 0 {
+			tnt.SetAnnotations(map[string]string{capsulev1beta2.TenantSpecAuthorAnnotation: author})
+		}
+
+		return tnt
+	}
+
+	raw := func(tnt *capsulev1beta2.Tenant) runtime.RawExtension {
+		b, err := json.Marshal(tnt)
+		require.NoError(t, err)
+
+		return runtime.RawExtension{Raw: b}
+	}
+
+	update := func(oldTnt, newTnt *capsulev1beta2.Tenant) *admission.Response {
+		req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Update,
+			UserInfo:  authenticationv1.UserInfo{Username: "alice"},
+			OldObject: raw(oldTnt),
+			Object:    raw(newTnt),
+		}}
+
+		return AuthorHandler().OnUpdate(nil, decoder, nil)(context.Background(), req)
+	}
+
+	t.Run("spec change records the user", func(t *testing.T) {
+		response := update(tenant("bill", 3), tenant("bill", 5))
+		require.NotNil(t, response)
+		assert.True(t, response.Allowed)
+		require.Len(t, response.Patches, 1)
+		assert.Equal(t, "alice", response.Patches[0].Value)
+	})
+
+	t.Run("unchanged spec keeps the author", func(t *testing.T) {
+		assert.Nil(t, update(tenant("bill", 3), tenant("bill", 3)))
+	})
+
+	t.Run("forged author is restored", func(t *testing.T) {
+		response := update(tenant("bill", 3), tenant("alice", 3))
+		require.NotNil(t, response)
+		require.Len(t, response.Patches, 1)
+		assert.Equal(t, "bill", response.Patches[0].Value)
+	})
+
+	t.Run("creation records the user", func(t *testing.T) {
+		req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			UserInfo:  authenticationv1.UserInfo{Username: "bill"},
+			Object:    raw(tenant("", 3)),
+		}}
+
+		response := AuthorHandler().OnCreate(nil, decoder, nil)(context.Background(), req)
+		require.NotNil(t, response)
+		require.Len(t, response.Patches, 1)
+		assert.Equal(t, map[string]interface{}{capsulev1beta2.TenantSpecAuthorAnnotation: "bill"}, response.Patches[0].Value)
+	})
+}
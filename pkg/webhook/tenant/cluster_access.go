@@ -0,0 +1,105 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/policy"
+	capsuleutils "github.com/projectcapsule/capsule/pkg/utils"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type clusterAccessHandler struct{}
+
+// ClusterAccessHandler denies the cluster access resources which are namespaced, since the access is granted by a
+// ClusterRoleBinding, and would apply to the resources with the same name in every Namespace. The resources which
+// cannot be resolved yet are allowed: the Tenant controller grants the access once they are known as cluster scoped.
+func ClusterAccessHandler() capsulewebhook.Handler {
+	return &clusterAccessHandler{}
+}
+
+func (h *clusterAccessHandler) validate(c client.Client, decoder admission.Decoder, req admission.Request) *admission.Response {
+	tenant := &capsulev1beta2.Tenant{}
+	if err := decoder.Decode(req, tenant); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if tenant.Spec.ClusterAccess == nil {
+		return nil
+	}
+
+	var (
+		messages   []string
+		violations []policy.Violation
+	)
+
+	for i, resource := range tenant.Spec.ClusterAccess.Resources {
+		field := fmt.Sprintf("spec.clusterAccess.resources[%d]", i)
+
+		if resource.APIGroup == "*" || resource.Resource == "*" {
+			messages = append(messages, fmt.Sprintf("wildcards are not allowed in the clusterAccess resources, got %s", schemaResource(resource.APIGroup, resource.Resource)))
+			violations = append(violations, policy.Violation{Field: field, Value: schemaResource(resource.APIGroup, resource.Resource)})
+
+			continue
+		}
+
+		clusterScoped, err := capsuleutils.IsClusterScopedResource(c.RESTMapper(), resource.APIGroup, resource.Resource)
+		if err != nil {
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+
+			return utils.ErroredResponse(err)
+		}
+
+		if !clusterScoped {
+			messages = append(messages, fmt.Sprintf("the clusterAccess resource %s is namespaced, only the cluster scoped resources are allowed", schemaResource(resource.APIGroup, resource.Resource)))
+			violations = append(violations, policy.Violation{Field: field, Value: schemaResource(resource.APIGroup, resource.Resource)})
+		}
+	}
+
+	if len(violations) > 0 {
+		response := policy.DenyViolations(policy.TenantNamespacedClusterAccess, strings.Join(messages, ", "), violations...)
+
+		return &response
+	}
+
+	return nil
+}
+
+func schemaResource(group, resource string) string {
+	if len(group) == 0 {
+		return resource
+	}
+
+	return fmt.Sprintf("%s.%s", resource, group)
+}
+
+func (h *clusterAccessHandler) OnCreate(c client.Client, decoder admission.Decoder, _ record.EventRecorder) capsulewebhook.Func {
+	return func(_ context.Context, req admission.Request) *admission.Response {
+		return h.validate(c, decoder, req)
+	}
+}
+
+func (h *clusterAccessHandler) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *clusterAccessHandler) OnUpdate(c client.Client, decoder admission.Decoder, _ record.EventRecorder) capsulewebhook.Func {
+	return func(_ context.Context, req admission.Request) *admission.Response {
+		return h.validate(c, decoder, req)
+	}
+}
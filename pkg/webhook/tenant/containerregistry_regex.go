@@ -13,6 +13,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
@@ -31,7 +32,7 @@ func (h *containerRegistryRegexHandler) validate(decoder admission.Decoder, req
 
 	if tenant.Spec.ContainerRegistries != nil && len(tenant.Spec.ContainerRegistries.Regex) > 0 {
 		if _, err := regexp.Compile(tenant.Spec.ContainerRegistries.Regex); err != nil {
-			response := admission.Denied("unable to compile containerRegistries allowedRegex")
+			response := policy.DenyViolations(policy.TenantInvalidRegex, "unable to compile containerRegistries allowedRegex", policy.Violation{Field: "spec.containerRegistries.allowedRegex", Value: tenant.Spec.ContainerRegistries.Regex})
 
 			return &response
 		}
@@ -17,6 +17,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
@@ -25,6 +26,8 @@ type resourceCounterHandler struct {
 	client client.Client
 }
 
+// ResourceCounterHandler counts the objects created in the Tenant Namespaces in the Tenant used resources annotations:
+// being the count updated upon the admission, it must be the last handler of its route, counting only the admitted objects.
 func ResourceCounterHandler(client client.Client) capsulewebhook.Handler {
 	return &resourceCounterHandler{
 		client: client,
@@ -91,7 +94,11 @@ func (r *resourceCounterHandler) OnCreate(clt client.Client, _ admission.Decoder
 		})
 		if err != nil {
 			if errors.As(err, &customResourceQuotaError{}) {
-				recorder.Eventf(tnt, corev1.EventTypeWarning, "ResourceQuota", "Resource %s/%s in API group %s cannot be created, limit usage of %d has been reached", req.Namespace, req.Name, kgv, limit)
+				policy.Eventf(recorder, tnt, policy.ResourceQuotaExceeded, corev1.EventTypeWarning, "ResourceQuota", "Resource %s/%s in API group %s cannot be created, limit usage of %d has been reached", req.Namespace, req.Name, kgv, limit)
+
+				response := policy.Deny(policy.ResourceQuotaExceeded, err.Error())
+
+				return &response
 			}
 
 			return utils.ErroredResponse(err)
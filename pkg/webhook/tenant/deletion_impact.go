@@ -0,0 +1,81 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/configuration"
+	"github.com/projectcapsule/capsule/pkg/impact"
+	"github.com/projectcapsule/capsule/pkg/policy"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type deletionImpactHandler struct {
+	cfg    configuration.Configuration
+	reader client.Reader
+}
+
+// DeletionImpactHandler requires the confirmation of the Tenant deletions exceeding the configured impact thresholds:
+// the reader is expected to be a non cached one, since the Tenant workloads are not cached.
+func DeletionImpactHandler(cfg configuration.Configuration, reader client.Reader) capsulewebhook.Handler {
+	return &deletionImpactHandler{cfg: cfg, reader: reader}
+}
+
+func (h *deletionImpactHandler) OnCreate(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *deletionImpactHandler) OnDelete(clt client.Client, _ admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		thresholds := h.cfg.DeletionImpactThresholds()
+		if thresholds == nil {
+			return nil
+		}
+
+		tnt := &capsulev1beta2.Tenant{}
+		if err := clt.Get(ctx, types.NamespacedName{Name: req.Name}, tnt); err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		if tnt.GetAnnotations()[impact.ConfirmDeletionAnnotation] == tnt.GetName() {
+			return nil
+		}
+
+		summary, err := impact.Compute(ctx, h.reader, tnt)
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		exceeded := summary.Exceeded(thresholds)
+		if len(exceeded) == 0 {
+			return nil
+		}
+
+		policy.Eventf(recorder, tnt, policy.TenantDeletionNotConfirmed, corev1.EventTypeWarning, "TenantDeletionNotConfirmed", "Tenant deletion requires confirmation: %s", strings.Join(exceeded, ", "))
+
+		response := policy.Deny(policy.TenantDeletionNotConfirmed, fmt.Sprintf("the deletion of the Tenant requires a confirmation, since %s (%s): annotate it with %s=%s to confirm",
+			strings.Join(exceeded, ", "), summary.String(), impact.ConfirmDeletionAnnotation, tnt.GetName()))
+
+		return &response
+	}
+}
+
+func (h *deletionImpactHandler) OnUpdate(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
@@ -7,12 +7,14 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"strings"
 
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
@@ -33,19 +35,33 @@ func (h *forbiddenAnnotationsRegexHandler) validate(decoder admission.Decoder, r
 		return nil
 	}
 
-	annotationsToCheck := map[string]string{
-		"labels":      tenant.Spec.NamespaceOptions.ForbiddenLabels.Regex,
-		"annotations": tenant.Spec.NamespaceOptions.ForbiddenAnnotations.Regex,
+	annotationsToCheck := []struct {
+		scope, field, regex string
+	}{
+		{"forbidden labels", "spec.namespaceOptions.forbiddenLabels.deniedRegex", tenant.Spec.NamespaceOptions.ForbiddenLabels.Regex},
+		{"forbidden annotations", "spec.namespaceOptions.forbiddenAnnotations.deniedRegex", tenant.Spec.NamespaceOptions.ForbiddenAnnotations.Regex},
+		{"immutable labels", "spec.namespaceOptions.immutableLabels.keysRegex", tenant.Spec.NamespaceOptions.ImmutableLabels.Regex},
+		{"immutable annotations", "spec.namespaceOptions.immutableAnnotations.keysRegex", tenant.Spec.NamespaceOptions.ImmutableAnnotations.Regex},
 	}
 
-	for scope, annotation := range annotationsToCheck {
-		if _, err := regexp.Compile(tenant.Spec.NamespaceOptions.ForbiddenLabels.Regex); err != nil {
-			response := admission.Denied(fmt.Sprintf("unable to compile %s regex for forbidden %s", annotation, scope))
+	var (
+		messages   []string
+		violations []policy.Violation
+	)
 
-			return &response
+	for _, annotation := range annotationsToCheck {
+		if _, err := regexp.Compile(annotation.regex); err != nil {
+			messages = append(messages, fmt.Sprintf("unable to compile %s regex for %s", annotation.regex, annotation.scope))
+			violations = append(violations, policy.Violation{Field: annotation.field, Value: annotation.regex})
 		}
 	}
 
+	if len(violations) > 0 {
+		response := policy.DenyViolations(policy.TenantInvalidRegex, strings.Join(messages, ", "), violations...)
+
+		return &response
+	}
+
 	return nil
 }
 
@@ -13,6 +13,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
@@ -31,7 +32,7 @@ func (h *hostnameRegexHandler) validate(decoder admission.Decoder, req admission
 
 	if tenant.Spec.IngressOptions.AllowedHostnames != nil && len(tenant.Spec.IngressOptions.AllowedHostnames.Regex) > 0 {
 		if _, err := regexp.Compile(tenant.Spec.IngressOptions.AllowedHostnames.Regex); err != nil {
-			response := admission.Denied("unable to compile allowedHostnames allowedRegex")
+			response := policy.DenyViolations(policy.TenantInvalidRegex, "unable to compile allowedHostnames allowedRegex", policy.Violation{Field: "spec.ingressOptions.allowedHostnames.allowedRegex", Value: tenant.Spec.IngressOptions.AllowedHostnames.Regex})
 
 			return &response
 		}
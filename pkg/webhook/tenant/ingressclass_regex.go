@@ -13,6 +13,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
@@ -31,7 +32,7 @@ func (h *ingressClassRegexHandler) validate(decoder admission.Decoder, req admis
 
 	if tenant.Spec.IngressOptions.AllowedClasses != nil && len(tenant.Spec.IngressOptions.AllowedClasses.Regex) > 0 {
 		if _, err := regexp.Compile(tenant.Spec.IngressOptions.AllowedClasses.Regex); err != nil {
-			response := admission.Denied("unable to compile ingressClasses allowedRegex")
+			response := policy.DenyViolations(policy.TenantInvalidRegex, "unable to compile ingressClasses allowedRegex", policy.Violation{Field: "spec.ingressOptions.allowedClasses.allowedRegex", Value: tenant.Spec.IngressOptions.AllowedClasses.Regex})
 
 			return &response
 		}
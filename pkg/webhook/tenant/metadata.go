@@ -13,6 +13,7 @@ import (
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
 	capsuleapi "github.com/projectcapsule/capsule/pkg/api"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
@@ -45,7 +46,7 @@ func (h *metaHandler) OnUpdate(_ client.Client, decoder admission.Decoder, _ rec
 		if tenant.Labels != nil {
 			if tenant.Labels[capsuleapi.TenantNameLabel] != "" {
 				if tenant.Labels[capsuleapi.TenantNameLabel] != tenant.Name {
-					response := admission.Denied(fmt.Sprintf("tenant label '%s' is immutable", capsuleapi.TenantNameLabel))
+					response := policy.DenyViolations(policy.TenantImmutableLabel, fmt.Sprintf("tenant label '%s' is immutable", capsuleapi.TenantNameLabel), policy.Violation{Field: fmt.Sprintf("metadata.labels[%s]", capsuleapi.TenantNameLabel), Value: tenant.Labels[capsuleapi.TenantNameLabel]})
 
 					return &response
 				}
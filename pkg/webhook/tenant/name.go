@@ -12,6 +12,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
@@ -31,7 +32,7 @@ func (h *nameHandler) OnCreate(_ client.Client, decoder admission.Decoder, _ rec
 
 		matched, _ := regexp.MatchString(`[a-z0-9]([-a-z0-9]*[a-z0-9])?`, tenant.GetName())
 		if !matched {
-			response := admission.Denied("tenant name has forbidden characters")
+			response := policy.Deny(policy.TenantInvalidName, "tenant name has forbidden characters")
 
 			return &response
 		}
@@ -0,0 +1,96 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/policy"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type objectSizeHandler struct{}
+
+func ObjectSizeHandler() capsulewebhook.Handler {
+	return &objectSizeHandler{}
+}
+
+func (h *objectSizeHandler) validate(ctx context.Context, clt client.Client, req admission.Request, recorder record.EventRecorder) *admission.Response {
+	if len(req.Namespace) == 0 {
+		return nil
+	}
+
+	tntList := &capsulev1beta2.TenantList{}
+
+	if err := clt.List(ctx, tntList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(".status.namespaces", req.Namespace),
+	}); err != nil {
+		return utils.ErroredResponse(err)
+	}
+	// resource is not inside a Tenant namespace
+	if len(tntList.Items) == 0 {
+		return nil
+	}
+
+	tnt := tntList.Items[0]
+
+	limit := tnt.Spec.ObjectSizeLimits.LimitFor(schema.GroupKind{Group: req.Kind.Group, Kind: req.Kind.Kind})
+	if limit == nil {
+		return nil
+	}
+	// The size of the JSON serialization is used, being the format sent by clients and a close upper bound of the stored one.
+	if size := len(req.Object.Raw); int64(size) > limit.MaxSize.Value() {
+		policy.Eventf(recorder, &tnt, policy.ResourceSizeExceeded, corev1.EventTypeWarning, "ObjectSizeExceeded", "%s %s/%s cannot be %sd, size of %d bytes exceeds the limit of %s", req.Kind.Kind, req.Namespace, req.Name, strings.ToLower(string(req.Operation)), size, limit.MaxSize.String())
+
+		response := policy.Deny(policy.ResourceSizeExceeded, NewObjectSizeExceededError(req.Kind.Kind, size, limit.MaxSize).Error())
+
+		return &response
+	}
+
+	return nil
+}
+
+func (h *objectSizeHandler) OnCreate(client client.Client, _ admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, client, req, recorder)
+	}
+}
+
+func (h *objectSizeHandler) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+// OnUpdate enforces the limit only on the updates growing the object: the objects already exceeding it,
+// such as the ones created before the limit, can still be updated by controllers, or have their finalizers removed.
+func (h *objectSizeHandler) OnUpdate(client client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		if len(req.Object.Raw) <= len(req.OldObject.Raw) {
+			return nil
+		}
+
+		obj := &unstructured.Unstructured{}
+		if err := decoder.DecodeRaw(req.Object, obj); err != nil {
+			return utils.ErroredResponse(err)
+		}
+		// Objects being deleted must not be blocked, waiting for their finalizers to be removed.
+		if obj.GetDeletionTimestamp() != nil {
+			return nil
+		}
+
+		return h.validate(ctx, client, req, recorder)
+	}
+}
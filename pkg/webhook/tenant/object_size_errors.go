@@ -0,0 +1,28 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+type objectSizeExceededError struct {
+	kind  string
+	size  int
+	limit resource.Quantity
+}
+
+func NewObjectSizeExceededError(kind string, size int, limit resource.Quantity) error {
+	return &objectSizeExceededError{
+		kind:  kind,
+		size:  size,
+		limit: limit,
+	}
+}
+
+func (o objectSizeExceededError) Error() string {
+	return fmt.Sprintf("%s size of %d bytes exceeds the Tenant limit of %s", o.kind, o.size, o.limit.String())
+}
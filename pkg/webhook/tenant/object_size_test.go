@@ -0,0 +1,92 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+)
+
+func TestObjectSizeDenialIsNotCounted(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, capsulev1beta2.AddToScheme(scheme))
+
+	kgv := "configmaps._v1"
+
+	tnt := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "oil",
+			Annotations: map[string]string{capsulev1beta2.LimitAnnotationForResource(kgv): "10"},
+		},
+		Spec: capsulev1beta2.TenantSpec{
+			ObjectSizeLimits: api.ObjectSizeLimitsSpec{{Kind: "ConfigMap", MaxSize: resource.MustParse("1Ki")}},
+		},
+		Status: capsulev1beta2.TenantStatus{Namespaces: []string{"oil-production"}},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(tnt).
+		WithIndex(&capsulev1beta2.Tenant{}, ".status.namespaces", func(object client.Object) []string {
+			return object.(*capsulev1beta2.Tenant).Status.Namespaces //nolint:forcetypeassert
+		}).
+		Build()
+	// The same handlers order of the cordoning route, the resource counter being the last one.
+	handlers := []capsulewebhook.Handler{ObjectSizeHandler(), ResourceCounterHandler(c)}
+
+	create := func(size int) *admission.Response {
+		req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Name:      "blob",
+			Namespace: "oil-production",
+			Operation: admissionv1.Create,
+			Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+			Resource:  metav1.GroupVersionResource{Version: "v1", Resource: "configmaps"},
+			Object:    runtime.RawExtension{Raw: []byte(`{"data":{"blob":"` + strings.Repeat("x", size) + `"}}`)},
+		}}
+
+		for _, h := range handlers {
+			if response := h.OnCreate(c, nil, record.NewFakeRecorder(10))(context.Background(), req); response != nil {
+				return response
+			}
+		}
+
+		return nil
+	}
+
+	used := func() string {
+		current := &capsulev1beta2.Tenant{}
+		require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: "oil"}, current))
+
+		return current.GetAnnotations()[capsulev1beta2.UsedAnnotationForResource(kgv)]
+	}
+
+	response := create(2048)
+	if assert.NotNil(t, response) {
+		assert.False(t, response.Allowed)
+	}
+
+	assert.Empty(t, used(), "the oversized ConfigMap must not be counted")
+
+	assert.Nil(t, create(16))
+	assert.Equal(t, "1", used())
+}
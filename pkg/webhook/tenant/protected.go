@@ -12,6 +12,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
@@ -37,7 +38,7 @@ func (h *protectedHandler) OnDelete(clt client.Client, _ admission.Decoder, _ re
 		}
 
 		if tenant.Spec.PreventDeletion {
-			response := admission.Denied("tenant is protected and cannot be deleted")
+			response := policy.Deny(policy.TenantProtected, "tenant is protected and cannot be deleted")
 
 			return &response
 		}
@@ -15,6 +15,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
@@ -31,21 +32,29 @@ func (h *rbRegexHandler) validate(req admission.Request, decoder admission.Decod
 		return utils.ErroredResponse(err)
 	}
 
-	if len(tenant.Spec.AdditionalRoleBindings) > 0 {
-		for _, binding := range tenant.Spec.AdditionalRoleBindings {
-			for _, subject := range binding.Subjects {
-				if subject.Kind == rbacv1.ServiceAccountKind {
-					err := validation.IsDNS1123Subdomain(subject.Name)
-					if len(err) > 0 {
-						response := admission.Denied(fmt.Sprintf("Subject Name '%v' for binding '%v' is invalid. %v", subject.Name, binding.ClusterRoleName, strings.Join(err, ", ")))
+	var (
+		messages   []string
+		violations []policy.Violation
+	)
 
-						return &response
-					}
+	for i, binding := range tenant.Spec.AdditionalRoleBindings {
+		for j, subject := range binding.Subjects {
+			if subject.Kind == rbacv1.ServiceAccountKind {
+				err := validation.IsDNS1123Subdomain(subject.Name)
+				if len(err) > 0 {
+					messages = append(messages, fmt.Sprintf("Subject Name '%v' for binding '%v' is invalid. %v", subject.Name, binding.ClusterRoleName, strings.Join(err, ", ")))
+					violations = append(violations, policy.Violation{Field: fmt.Sprintf("spec.additionalRoleBindings[%d].subjects[%d].name", i, j), Value: subject.Name})
 				}
 			}
 		}
 	}
 
+	if len(violations) > 0 {
+		response := policy.DenyViolations(policy.TenantInvalidBindingSubject, strings.Join(messages, " "), violations...)
+
+		return &response
+	}
+
 	return nil
 }
 
@@ -7,12 +7,14 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"strings"
 
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
@@ -31,18 +33,28 @@ func (h *saNameHandler) validateServiceAccountName(req admission.Request, decode
 
 	compiler := regexp.MustCompile(`^.*:.*:.*(:.*)?$`)
 
-	for _, owner := range tenant.Spec.Owners {
+	var (
+		names      []string
+		violations []policy.Violation
+	)
+
+	for i, owner := range tenant.Spec.Owners {
 		if owner.Kind != "ServiceAccount" {
 			continue
 		}
 
 		if !compiler.MatchString(owner.Name) {
-			response := admission.Denied(fmt.Sprintf("owner name %s is not a valid Service Account name ", owner.Name))
-
-			return &response
+			names = append(names, owner.Name)
+			violations = append(violations, policy.Violation{Field: fmt.Sprintf("spec.owners[%d].name", i), Value: owner.Name})
 		}
 	}
 
+	if len(violations) > 0 {
+		response := policy.DenyViolations(policy.TenantInvalidOwner, fmt.Sprintf("owner name %s is not a valid Service Account name ", strings.Join(names, ", ")), violations...)
+
+		return &response
+	}
+
 	return nil
 }
 
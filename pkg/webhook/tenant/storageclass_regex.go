@@ -13,6 +13,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
@@ -31,7 +32,7 @@ func (h *storageClassRegexHandler) validate(decoder admission.Decoder, req admis
 
 	if tenant.Spec.StorageClasses != nil && len(tenant.Spec.StorageClasses.Regex) > 0 {
 		if _, err := regexp.Compile(tenant.Spec.StorageClasses.Regex); err != nil {
-			response := admission.Denied("unable to compile storageClasses allowedRegex")
+			response := policy.DenyViolations(policy.TenantInvalidRegex, "unable to compile storageClasses allowedRegex", policy.Violation{Field: "spec.storageClasses.allowedRegex", Value: tenant.Spec.StorageClasses.Regex})
 
 			return &response
 		}
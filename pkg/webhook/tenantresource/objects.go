@@ -16,6 +16,7 @@ import (
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
 	"github.com/projectcapsule/capsule/pkg/indexer/tenantresource"
+	"github.com/projectcapsule/capsule/pkg/policy"
 	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
 	"github.com/projectcapsule/capsule/pkg/webhook/utils"
 )
@@ -60,9 +61,9 @@ func (h *cordoningHandler) handler(ctx context.Context, clt client.Client, req a
 	if len(local.Items) > 0 || len(global.Items) > 0 {
 		tnt := tntList.Items[0]
 
-		recorder.Eventf(&tnt, corev1.EventTypeWarning, "TenantResourceWriteOp", "%s %s/%s cannot be %sd, resource is managed by the Tenant", req.Kind.String(), req.Namespace, req.Name, strings.ToLower(string(req.Operation)))
+		policy.Eventf(recorder, &tnt, policy.ResourceManagedByTenant, corev1.EventTypeWarning, "TenantResourceWriteOp", "%s %s/%s cannot be %sd, resource is managed by the Tenant", req.Kind.String(), req.Namespace, req.Name, strings.ToLower(string(req.Operation)))
 
-		response := admission.Denied(fmt.Sprintf("resource %s is managed at the Tenant level", req.Name))
+		response := policy.Deny(policy.ResourceManagedByTenant, fmt.Sprintf("resource %s is managed at the Tenant level", req.Name))
 
 		return &response
 	}
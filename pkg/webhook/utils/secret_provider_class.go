@@ -0,0 +1,55 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SecretsStoreCSIDriver is the name of the Secrets Store CSI driver.
+	SecretsStoreCSIDriver = "secrets-store.csi.k8s.io"
+	// SecretProviderClassAttribute is the CSI volume attribute referring to the SecretProviderClass.
+	SecretProviderClassAttribute = "secretProviderClass"
+)
+
+//nolint:gochecknoglobals
+var SecretProviderClassGroupVersionKind = schema.GroupVersionKind{
+	Group:   "secrets-store.csi.x-k8s.io",
+	Version: "v1",
+	Kind:    "SecretProviderClass",
+}
+
+// SecretProviderClass is the subset of the Secrets Store CSI driver resource Capsule is interested in,
+// avoiding a dependency on the driver module.
+type SecretProviderClass struct {
+	Spec SecretProviderClassSpec `json:"spec,omitempty"`
+}
+
+type SecretProviderClassSpec struct {
+	Provider   string            `json:"provider,omitempty"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+func GetSecretProviderClass(ctx context.Context, c client.Client, namespace, name string) (*SecretProviderClass, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(SecretProviderClassGroupVersionKind)
+
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, obj); err != nil {
+		return nil, err
+	}
+
+	spc := &SecretProviderClass{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), spc); err != nil {
+		return nil, err
+	}
+
+	return spc, nil
+}
@@ -0,0 +1,23 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package workload
+
+import (
+	"fmt"
+	"strings"
+)
+
+type workloadHygieneError struct {
+	kind       string
+	name       string
+	violations []string
+}
+
+func NewWorkloadHygieneError(kind, name string, violations []string) error {
+	return &workloadHygieneError{kind: kind, name: name, violations: violations}
+}
+
+func (w workloadHygieneError) Error() string {
+	return fmt.Sprintf("%s %s does not comply with the Tenant workload hygiene rules: %s", w.kind, w.name, strings.Join(w.violations, ", "))
+}
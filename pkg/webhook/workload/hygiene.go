@@ -0,0 +1,165 @@
+// Copyright 2020-2023 Project Capsule Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package workload
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/projectcapsule/capsule/pkg/policy"
+	capsulewebhook "github.com/projectcapsule/capsule/pkg/webhook"
+	"github.com/projectcapsule/capsule/pkg/webhook/utils"
+)
+
+type hygieneHandler struct{}
+
+// HygieneHandler checks the Pods and the workloads created in the Tenant Namespaces against the Tenant workload hygiene rules.
+// The objects managed by a controller are skipped, since the violations are reported on the workload owning them.
+func HygieneHandler() capsulewebhook.Handler {
+	return &hygieneHandler{}
+}
+
+func (h *hygieneHandler) OnCreate(c client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.validate(ctx, c, decoder, recorder, req)
+	}
+}
+
+func (h *hygieneHandler) OnDelete(client.Client, admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+// OnUpdate checks only the updates changing the labels or the Pod specification: the existing objects not complying
+// with the rules can still be updated, such as by the garbage collector removing their finalizers.
+func (h *hygieneHandler) OnUpdate(c client.Client, decoder admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		oldObj, oldTemplate := workloadFor(req)
+		if oldObj == nil {
+			return nil
+		}
+
+		newObj, newTemplate := workloadFor(req)
+
+		if err := decoder.DecodeRaw(req.OldObject, oldObj); err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		if err := decoder.DecodeRaw(req.Object, newObj); err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		if newObj.GetDeletionTimestamp() != nil {
+			return nil
+		}
+
+		oldSpec, _ := oldTemplate()
+		newSpec, _ := newTemplate()
+
+		if equality.Semantic.DeepEqual(oldObj.GetLabels(), newObj.GetLabels()) && equality.Semantic.DeepEqual(oldSpec, newSpec) {
+			return nil
+		}
+
+		return h.validate(ctx, c, decoder, recorder, req)
+	}
+}
+
+func (h *hygieneHandler) validate(ctx context.Context, c client.Client, decoder admission.Decoder, recorder record.EventRecorder, req admission.Request) *admission.Response {
+	obj, template := workloadFor(req)
+	if obj == nil {
+		return nil
+	}
+
+	tnt, err := utils.TenantByStatusNamespace(ctx, c, req.Namespace)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if len(tnt.GetName()) == 0 || tnt.Spec.WorkloadHygiene == nil {
+		return nil
+	}
+
+	if err = decoder.Decode(req, obj); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if metav1.GetControllerOf(obj) != nil {
+		return nil
+	}
+
+	spec, longRunning := template()
+
+	violations := tnt.Spec.WorkloadHygiene.Violations(obj.GetLabels(), spec, longRunning)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	if !tnt.Spec.WorkloadHygiene.IsEnforced() {
+		warnings := make([]string, 0, len(violations))
+
+		for _, violation := range violations {
+			warnings = append(warnings, fmt.Sprintf("[%s] %s %s: %s", policy.PodWorkloadHygiene, req.Kind.Kind, req.Name, violation))
+		}
+
+		response := admission.Allowed("").WithWarnings(warnings...)
+
+		return &response
+	}
+
+	policy.Eventf(recorder, tnt, policy.PodWorkloadHygiene, corev1.EventTypeWarning, "WorkloadHygiene", "%s %s/%s does not comply with the workload hygiene rules: %s", req.Kind.Kind, req.Namespace, req.Name, strings.Join(violations, ", "))
+
+	response := policy.Deny(policy.PodWorkloadHygiene, NewWorkloadHygieneError(req.Kind.Kind, req.Name, violations).Error())
+
+	return &response
+}
+
+// workloadFor returns the object to decode the request into, along with the function returning its Pod specification
+// and whether it is long-running, once decoded: nil if the request kind is not a workload.
+func workloadFor(req admission.Request) (client.Object, func() (corev1.PodSpec, bool)) {
+	switch req.Kind.Group + "/" + req.Kind.Kind {
+	case "/Pod":
+		obj := &corev1.Pod{}
+
+		return obj, func() (corev1.PodSpec, bool) {
+			return obj.Spec, obj.Spec.RestartPolicy != corev1.RestartPolicyNever && obj.Spec.RestartPolicy != corev1.RestartPolicyOnFailure
+		}
+	case "apps/Deployment":
+		obj := &appsv1.Deployment{}
+
+		return obj, func() (corev1.PodSpec, bool) { return obj.Spec.Template.Spec, true }
+	case "apps/StatefulSet":
+		obj := &appsv1.StatefulSet{}
+
+		return obj, func() (corev1.PodSpec, bool) { return obj.Spec.Template.Spec, true }
+	case "apps/DaemonSet":
+		obj := &appsv1.DaemonSet{}
+
+		return obj, func() (corev1.PodSpec, bool) { return obj.Spec.Template.Spec, true }
+	case "apps/ReplicaSet":
+		obj := &appsv1.ReplicaSet{}
+
+		return obj, func() (corev1.PodSpec, bool) { return obj.Spec.Template.Spec, true }
+	case "batch/Job":
+		obj := &batchv1.Job{}
+
+		return obj, func() (corev1.PodSpec, bool) { return obj.Spec.Template.Spec, false }
+	case "batch/CronJob":
+		obj := &batchv1.CronJob{}
+
+		return obj, func() (corev1.PodSpec, bool) { return obj.Spec.JobTemplate.Spec.Template.Spec, false }
+	default:
+		return nil, nil
+	}
+}